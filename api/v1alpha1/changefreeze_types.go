@@ -42,6 +42,11 @@ type ChangeFreezeSpec struct {
 	// rules define which actions are denied while within [startTime, endTime].
 	Rules PolicyRulesSpec `json:"rules"`
 
+	// noticePeriod is how long before startTime matching requests are still allowed
+	// but receive an admission warning that the freeze is about to start.
+	// +optional
+	NoticePeriod *metav1.Duration `json:"noticePeriod,omitempty"`
+
 	// behavior configures optional side-effects.
 	// +optional
 	Behavior PolicyBehaviorSpec `json:"behavior,omitempty"`
@@ -49,6 +54,12 @@ type ChangeFreezeSpec struct {
 	// message configures user-facing denial message data.
 	// +optional
 	Message MessageSpec `json:"message,omitempty"`
+
+	// ttlAfterExpired is how long the ChangeFreeze is kept once it has expired, after
+	// which the operator deletes it. Expired objects are kept when unset.
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('0s')",message="ttlAfterExpired must not be negative"
+	// +optional
+	TTLAfterExpired *metav1.Duration `json:"ttlAfterExpired,omitempty"`
 }
 
 // ChangeFreezeStatus defines the observed state of ChangeFreeze.
@@ -61,6 +72,20 @@ type ChangeFreezeStatus struct {
 	// +optional
 	TimeRemaining *metav1.Duration `json:"timeRemaining,omitempty"`
 
+	// phase is Scheduled before the freeze starts, Active while it is in effect and
+	// Expired once it has ended.
+	// +optional
+	Phase PolicyPhase `json:"phase,omitempty"`
+
+	// timeUntilStart is an optional derived value for UX, set while the phase is
+	// Scheduled and refreshed at least every minute.
+	// +optional
+	TimeUntilStart *metav1.Duration `json:"timeUntilStart,omitempty"`
+
+	// lastTransitionTime is when the phase last changed.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
 	// observedGeneration is the last observed generation.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
@@ -74,6 +99,16 @@ type ChangeFreezeStatus struct {
 	// +optional
 	GitopsLastReconcileTime *metav1.Time `json:"gitopsLastReconcileTime,omitempty"`
 
+	// affectedWorkloads summarizes the namespaces and workloads the target selects.
+	// +optional
+	AffectedWorkloads *AffectedWorkloadsStatus `json:"affectedWorkloads,omitempty"`
+
+	// hooks records the Job each hook launched for the policy's last transitions.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Hooks []HookStatus `json:"hooks,omitempty"`
+
 	// conditions represent the current state of the ChangeFreeze resource.
 	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
 	//
@@ -92,6 +127,11 @@ type ChangeFreezeStatus struct {
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Start",type=string,JSONPath=`.spec.startTime`
+// +kubebuilder:printcolumn:name="End",type=string,JSONPath=`.spec.endTime`
+// +kubebuilder:printcolumn:name="Starts In",type=string,JSONPath=`.status.timeUntilStart`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // ChangeFreeze is the Schema for the changefreezes API
 type ChangeFreeze struct {
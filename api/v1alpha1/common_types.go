@@ -18,6 +18,11 @@ package v1alpha1
 
 import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+// AnnotationBreakGlass on a workload overrides every freeze policy for the request
+// that sets it, if the requester belongs to a configured break-glass group. The value
+// is the reason. The operator removes the annotation after the change is admitted.
+const AnnotationBreakGlass = "freeze-operator.io/break-glass"
+
 // Action represents an operation category that can be denied/allowed by policies.
 //
 // Note: UPDATE is mapped into more specific actions like ROLL_OUT / SCALE.
@@ -66,10 +71,47 @@ type TargetSpec struct {
 }
 
 // PolicyRulesSpec defines deny rules for a policy.
+// +kubebuilder:validation:XValidation:rule="has(self.deny) || has(self.rateLimit)",message="at least one of deny or rateLimit is required"
 type PolicyRulesSpec struct {
 	// deny lists which actions are denied when the policy is active.
 	// +kubebuilder:validation:MinItems=1
-	Deny []Action `json:"deny"`
+	// +optional
+	Deny []Action `json:"deny,omitempty"`
+
+	// rateLimit admits at most a number of changes per period while the policy is
+	// active, instead of denying them outright. Actions listed in deny stay denied.
+	// +optional
+	RateLimit *RateLimitSpec `json:"rateLimit,omitempty"`
+}
+
+// RateLimitScope defines how a rate limit budget is shared.
+// +kubebuilder:validation:Enum=Cluster;Namespace
+type RateLimitScope string
+
+const (
+	// RateLimitScopeCluster shares one budget across all matching namespaces.
+	RateLimitScopeCluster RateLimitScope = "Cluster"
+	// RateLimitScopeNamespace gives every matching namespace its own budget.
+	RateLimitScopeNamespace RateLimitScope = "Namespace"
+)
+
+// RateLimitSpec defines a change budget.
+type RateLimitSpec struct {
+	// actions lists the actions that consume the budget.
+	// +kubebuilder:validation:MinItems=1
+	Actions []Action `json:"actions"`
+
+	// limit is the number of changes admitted per period.
+	// +kubebuilder:validation:Minimum=1
+	Limit int32 `json:"limit"`
+
+	// period is the length of the sliding window the limit applies to.
+	Period metav1.Duration `json:"period"`
+
+	// scope selects whether the budget is shared cluster-wide or per namespace.
+	// +kubebuilder:default=Cluster
+	// +optional
+	Scope RateLimitScope `json:"scope,omitempty"`
 }
 
 // MessageSpec configures user-facing denial messages.
@@ -168,11 +210,137 @@ type PolicyBehaviorSpec struct {
 	// +optional
 	SuspendCronJobs bool `json:"suspendCronJobs,omitempty"`
 
+	// pauseDeployments sets spec.paused on matching Deployments while the policy is active
+	// and restores the original value afterwards. Updates that keep a Deployment paused
+	// are admitted, so manifests can land while no pods are rolled.
+	// +optional
+	PauseDeployments bool `json:"pauseDeployments,omitempty"`
+
+	// pinAutoscalers fixes the capacity of matching Deployments and StatefulSets while the
+	// policy is active: HorizontalPodAutoscalers are pinned to their current replicas and
+	// KEDA ScaledObjects are paused. The original settings are restored afterwards.
+	// +optional
+	PinAutoscalers bool `json:"pinAutoscalers,omitempty"`
+
 	// gitops configures GitOps engine pause/resume behavior during active freeze.
 	// When enabled, the operator will pause ArgoCD Applications and/or suspend Flux resources
 	// to prevent sync noise during the freeze window.
 	// +optional
 	GitOps *GitOpsSpec `json:"gitops,omitempty"`
+
+	// deferChanges records CREATE and UPDATE requests denied by this policy as
+	// DeferredChanges, which the operator applies once the policies allow them.
+	// +optional
+	DeferChanges bool `json:"deferChanges,omitempty"`
+
+	// hooks launches Jobs when the policy starts or stops enforcing.
+	// +optional
+	Hooks *PolicyHooksSpec `json:"hooks,omitempty"`
+}
+
+// PolicyHooksSpec defines the Jobs launched on policy transitions. A policy is
+// enforcing while a ChangeFreeze is active and while a MaintenanceWindow is outside
+// its windows. Each hook runs once per transition.
+type PolicyHooksSpec struct {
+	// onActivate runs when the policy starts enforcing.
+	// +optional
+	OnActivate *HookSpec `json:"onActivate,omitempty"`
+
+	// onDeactivate runs when the policy stops enforcing.
+	// +optional
+	OnDeactivate *HookSpec `json:"onDeactivate,omitempty"`
+}
+
+// HookSpec references the Job template a hook launches.
+type HookSpec struct {
+	// jobTemplateRef names a CronJob whose spec.jobTemplate is launched as a Job in
+	// the CronJob's namespace, as kubectl create job --from=cronjob/<name> does.
+	// The CronJob should be suspended so that it only runs as a hook. Setting it
+	// requires permission to create Jobs in that namespace.
+	JobTemplateRef JobTemplateReference `json:"jobTemplateRef"`
+}
+
+// JobTemplateReference identifies the CronJob holding a hook's Job template.
+type JobTemplateReference struct {
+	// namespace is the namespace of the CronJob and of the launched Jobs.
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// name is the name of the CronJob.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// HookType identifies a policy hook.
+// +kubebuilder:validation:Enum=OnActivate;OnDeactivate
+type HookType string
+
+const (
+	HookTypeOnActivate   HookType = "OnActivate"
+	HookTypeOnDeactivate HookType = "OnDeactivate"
+)
+
+// PolicyPhase is where a ChangeFreeze or FreezeException is in its lifetime.
+// +kubebuilder:validation:Enum=Scheduled;Active;Expired
+type PolicyPhase string
+
+const (
+	// PolicyPhaseScheduled means the policy has not started yet.
+	PolicyPhaseScheduled PolicyPhase = "Scheduled"
+	// PolicyPhaseActive means the policy is in effect.
+	PolicyPhaseActive PolicyPhase = "Active"
+	// PolicyPhaseExpired means the policy has ended.
+	PolicyPhaseExpired PolicyPhase = "Expired"
+)
+
+// HookPhase is the state of a hook Job.
+// +kubebuilder:validation:Enum=Running;Succeeded;Failed
+type HookPhase string
+
+const (
+	HookPhaseRunning   HookPhase = "Running"
+	HookPhaseSucceeded HookPhase = "Succeeded"
+	HookPhaseFailed    HookPhase = "Failed"
+)
+
+// HookStatus records the Job launched by a hook for the policy's last transition.
+type HookStatus struct {
+	// type is the hook that ran.
+	Type HookType `json:"type"`
+
+	// transitionTime identifies the transition the hook ran for. A hook is not
+	// launched again for the same transition.
+	TransitionTime metav1.Time `json:"transitionTime"`
+
+	// jobNamespace is the namespace of the launched Job.
+	// +optional
+	JobNamespace string `json:"jobNamespace,omitempty"`
+
+	// jobName is the name of the launched Job.
+	// +optional
+	JobName string `json:"jobName,omitempty"`
+
+	// startTime is when the Job was launched.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// phase is the state of the Job.
+	// +optional
+	Phase HookPhase `json:"phase,omitempty"`
+
+	// message explains a failed launch or Job.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// PolicyReference identifies a freeze policy.
+type PolicyReference struct {
+	// kind is the policy kind.
+	// +kubebuilder:validation:Enum=ChangeFreeze;MaintenanceWindow
+	Kind string `json:"kind"`
+
+	// name is the policy name.
+	Name string `json:"name"`
 }
 
 // WindowStatus describes an evaluated maintenance window interval.
@@ -189,3 +357,46 @@ type WindowStatus struct {
 	// +optional
 	EndTime metav1.Time `json:"endTime,omitempty"`
 }
+
+// AffectedWorkloadsStatus summarizes the workloads a policy's target selects.
+type AffectedWorkloadsStatus struct {
+	// namespaces is the number of namespaces matched by the namespaceSelector.
+	// +optional
+	Namespaces int `json:"namespaces,omitempty"`
+
+	// total is the number of matching workloads across all target kinds.
+	// +optional
+	Total int `json:"total,omitempty"`
+
+	// counts is the number of matching workloads per kind.
+	// +listType=map
+	// +listMapKey=kind
+	// +optional
+	Counts []WorkloadCount `json:"counts,omitempty"`
+
+	// sample lists up to 20 matching workloads, sorted by namespace, kind and name.
+	// The full list is served by the CI helper API.
+	// +optional
+	Sample []AffectedWorkload `json:"sample,omitempty"`
+}
+
+// WorkloadCount is the number of matching workloads of one kind.
+type WorkloadCount struct {
+	// kind is the workload kind.
+	Kind TargetKind `json:"kind"`
+
+	// count is the number of matching workloads.
+	Count int `json:"count"`
+}
+
+// AffectedWorkload identifies a workload selected by a policy.
+type AffectedWorkload struct {
+	// kind is the workload kind.
+	Kind TargetKind `json:"kind"`
+
+	// namespace is the workload namespace.
+	Namespace string `json:"namespace"`
+
+	// name is the workload name.
+	Name string `json:"name"`
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeferredOperation is the admission operation a DeferredChange replays.
+// +kubebuilder:validation:Enum=CREATE;UPDATE
+type DeferredOperation string
+
+const (
+	DeferredOperationCreate DeferredOperation = "CREATE"
+	DeferredOperationUpdate DeferredOperation = "UPDATE"
+)
+
+// DeferredChangePhase is the lifecycle phase of a DeferredChange.
+// +kubebuilder:validation:Enum=Pending;Applied;Conflict;Superseded;Failed
+type DeferredChangePhase string
+
+const (
+	// DeferredChangePhasePending waits for the blocking policies to allow the change.
+	DeferredChangePhasePending DeferredChangePhase = "Pending"
+	// DeferredChangePhaseApplied means the change was applied.
+	DeferredChangePhaseApplied DeferredChangePhase = "Applied"
+	// DeferredChangePhaseConflict means the object changed since the change was
+	// made, so it was not applied.
+	DeferredChangePhaseConflict DeferredChangePhase = "Conflict"
+	// DeferredChangePhaseSuperseded means a newer DeferredChange for the same object exists.
+	DeferredChangePhaseSuperseded DeferredChangePhase = "Superseded"
+	// DeferredChangePhaseFailed means the API server rejected the change.
+	DeferredChangePhaseFailed DeferredChangePhase = "Failed"
+)
+
+// DeferredChangeSpec defines the desired state of DeferredChange
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+type DeferredChangeSpec struct {
+	// kind is the kind of the deferred workload.
+	Kind TargetKind `json:"kind"`
+
+	// name is the name of the workload, in the namespace of the DeferredChange. It is
+	// empty for a CREATE that left the name to metadata.generateName.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// operation is the denied admission operation.
+	Operation DeferredOperation `json:"operation"`
+
+	// action is the classified action of the denied request.
+	Action Action `json:"action"`
+
+	// object is the workload as submitted, without server-populated fields.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:EmbeddedResource
+	Object runtime.RawExtension `json:"object"`
+
+	// baseObject is the workload the UPDATE was made against, without
+	// server-populated fields. Only the fields that differ between baseObject and
+	// object are replayed, and the change is not applied if one of them changed
+	// differently in the meantime.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:EmbeddedResource
+	BaseObject *runtime.RawExtension `json:"baseObject,omitempty"`
+
+	// baseGeneration is the metadata.generation of the workload the change was made
+	// against; 0 for CREATE. A change recorded without baseObject is not applied if
+	// the workload's generation differs when the policies allow it.
+	// +optional
+	BaseGeneration int64 `json:"baseGeneration,omitempty"`
+
+	// requester is the user whose request was deferred.
+	Requester DeferredChangeRequester `json:"requester"`
+
+	// blockingPolicy is the policy that denied the request.
+	BlockingPolicy DeferredChangePolicyRef `json:"blockingPolicy"`
+}
+
+// DeferredChangeRequester identifies the user whose request was deferred.
+type DeferredChangeRequester struct {
+	// username of the requester.
+	Username string `json:"username"`
+
+	// groups of the requester; FreezeExceptions are matched against them.
+	// +optional
+	// +listType=atomic
+	Groups []string `json:"groups,omitempty"`
+}
+
+// DeferredChangePolicyRef references a freeze policy.
+type DeferredChangePolicyRef struct {
+	// kind of the policy, e.g. ChangeFreeze.
+	Kind string `json:"kind"`
+
+	// name of the policy.
+	Name string `json:"name"`
+}
+
+// DeferredChangeStatus defines the observed state of DeferredChange.
+type DeferredChangeStatus struct {
+	// phase is the lifecycle phase of the change.
+	// +optional
+	Phase DeferredChangePhase `json:"phase,omitempty"`
+
+	// message explains the phase.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// appliedTime is when the change was applied.
+	// +optional
+	AppliedTime *metav1.Time `json:"appliedTime,omitempty"`
+
+	// conditions represent the current state of the DeferredChange resource.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Kind",type=string,JSONPath=`.spec.kind`
+// +kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.spec.name`
+// +kubebuilder:printcolumn:name="Requester",type=string,JSONPath=`.spec.requester.username`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// DeferredChange is the Schema for the deferredchanges API.
+// It holds a workload change denied by a policy, to be applied once the policies allow it.
+type DeferredChange struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the deferred change
+	// +required
+	Spec DeferredChangeSpec `json:"spec"`
+
+	// status defines the observed state of DeferredChange
+	// +optional
+	Status DeferredChangeStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// DeferredChangeList contains a list of DeferredChange
+type DeferredChangeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []DeferredChange `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DeferredChange{}, &DeferredChangeList{})
+}
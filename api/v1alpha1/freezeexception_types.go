@@ -23,8 +23,13 @@ import (
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// AnnotationCreatedBy records the user that created a FreezeException. It is set by the
+// mutating webhook from the admission request and cannot be changed afterwards.
+const AnnotationCreatedBy = "freeze-operator.io/created-by"
+
 // FreezeExceptionSpec defines the desired state of FreezeException
 // +kubebuilder:validation:XValidation:rule="self.activeTo > self.activeFrom",message="activeTo must be after activeFrom"
+// +kubebuilder:validation:XValidation:rule="has(self.target) != has(self.objects)",message="exactly one of target or objects must be set"
 type FreezeExceptionSpec struct {
 	// activeFrom is when this exception becomes effective.
 	ActiveFrom metav1.Time `json:"activeFrom"`
@@ -33,7 +38,15 @@ type FreezeExceptionSpec struct {
 	ActiveTo metav1.Time `json:"activeTo"`
 
 	// target selects namespaces/objects/kinds this exception applies to.
-	Target TargetSpec `json:"target"`
+	// Exactly one of target and objects must be set.
+	// +optional
+	Target *TargetSpec `json:"target,omitempty"`
+
+	// objects lists the workloads this exception applies to by name, as an
+	// alternative to target.
+	// +kubebuilder:validation:MinItems=1
+	// +optional
+	Objects []ExceptionObjectReference `json:"objects,omitempty"`
 
 	// allow lists which actions are allowed even when policies would deny.
 	// +kubebuilder:validation:MinItems=1
@@ -51,9 +64,46 @@ type FreezeExceptionSpec struct {
 	// +optional
 	TicketURL string `json:"ticketURL,omitempty"`
 
-	// approvedBy is a free-form approver identifier.
+	// approvedBy identifies the approver. It is free-form unless the operator requires a
+	// separate approver, in which case it must be the username of an approver-group member,
+	// other than the creator, who sets it themselves by updating the exception; it cannot
+	// be set on create.
 	// +optional
 	ApprovedBy string `json:"approvedBy,omitempty"`
+
+	// requiredApprovals is the number of distinct users, other than the creator, that must
+	// approve the current generation of this exception through FreezeExceptionApproval
+	// objects before it overrides any policy. It can be raised but not lowered, and the
+	// operator may enforce a higher cluster-wide minimum.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RequiredApprovals int32 `json:"requiredApprovals,omitempty"`
+
+	// maxUses limits how many admission requests this exception may admit. Once
+	// status.usage.count reaches maxUses the exception no longer matches. Zero means unlimited.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxUses int32 `json:"maxUses,omitempty"`
+
+	// ttlAfterExpired is how long the FreezeException is kept once it has expired, after
+	// which the operator deletes it. Expired objects are kept when unset.
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('0s')",message="ttlAfterExpired must not be negative"
+	// +optional
+	TTLAfterExpired *metav1.Duration `json:"ttlAfterExpired,omitempty"`
+}
+
+// ExceptionObjectReference identifies a single workload.
+type ExceptionObjectReference struct {
+	// kind is the workload kind.
+	Kind TargetKind `json:"kind"`
+
+	// namespace is the workload namespace.
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// name is the workload name.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
 }
 
 // FreezeExceptionConstraintsSpec adds optional constraints to an exception.
@@ -69,6 +119,53 @@ type FreezeExceptionConstraintsSpec struct {
 	// allowedGroups restricts exception usage to these groups.
 	// +optional
 	AllowedGroups []string `json:"allowedGroups,omitempty"`
+
+	// allowedImages restricts exception usage to changes whose new or changed container
+	// images all match one of these patterns. A pattern is a glob where "*" matches any
+	// characters (including "/") and "?" a single character, or a regular expression
+	// prefixed with "regex:". Patterns must match the whole image reference.
+	// +optional
+	AllowedImages []string `json:"allowedImages,omitempty"`
+
+	// requireDigest requires every new or changed container image to be pinned by
+	// a sha256 digest.
+	// +optional
+	RequireDigest bool `json:"requireDigest,omitempty"`
+
+	// containers restricts which containers may change and how. When set, every
+	// changed container must be listed with the kind of change it makes, and the pod
+	// template outside of containers, including its labels and annotations, must not
+	// change.
+	// +optional
+	Containers []ContainerConstraintSpec `json:"containers,omitempty"`
+}
+
+// ContainerChange is a category of change to a single container.
+// +kubebuilder:validation:Enum=Image;Env;Resources;Command;Other
+type ContainerChange string
+
+const (
+	// ContainerChangeImage is a change of the container image.
+	ContainerChangeImage ContainerChange = "Image"
+	// ContainerChangeEnv is a change of env or envFrom.
+	ContainerChangeEnv ContainerChange = "Env"
+	// ContainerChangeResources is a change of resource requests or limits.
+	ContainerChangeResources ContainerChange = "Resources"
+	// ContainerChangeCommand is a change of command or args.
+	ContainerChangeCommand ContainerChange = "Command"
+	// ContainerChangeOther is any other change, including adding or removing the container.
+	ContainerChangeOther ContainerChange = "Other"
+)
+
+// ContainerConstraintSpec lists the changes an exception allows for one container.
+type ContainerConstraintSpec struct {
+	// name is the container (or init container) name. "*" matches any container.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// allowedChanges lists the changes allowed for this container.
+	// +kubebuilder:validation:MinItems=1
+	AllowedChanges []ContainerChange `json:"allowedChanges"`
 }
 
 // FreezeExceptionStatus defines the observed state of FreezeException.
@@ -77,10 +174,38 @@ type FreezeExceptionStatus struct {
 	// +optional
 	Active bool `json:"active,omitempty"`
 
+	// phase is Scheduled before the exception starts, Active while it is in effect and
+	// Expired once it has ended.
+	// +optional
+	Phase PolicyPhase `json:"phase,omitempty"`
+
+	// timeUntilStart is an optional derived value for UX, set while the phase is
+	// Scheduled and refreshed at least every minute.
+	// +optional
+	TimeUntilStart *metav1.Duration `json:"timeUntilStart,omitempty"`
+
+	// lastTransitionTime is when the phase last changed.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
 	// observedGeneration is the last observed generation.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
+	// approvals lists the users that approved this exception, oldest first. Approvals of an
+	// earlier generation are kept but no longer count towards spec.requiredApprovals.
+	// +listType=atomic
+	// +optional
+	Approvals []FreezeExceptionApproverStatus `json:"approvals,omitempty"`
+
+	// approvalCount is the number of approvals of the current generation.
+	// +optional
+	ApprovalCount int32 `json:"approvalCount,omitempty"`
+
+	// usage records the requests this exception has admitted.
+	// +optional
+	Usage FreezeExceptionUsage `json:"usage,omitzero"`
+
 	// conditions represent the current state of the FreezeException resource.
 	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
 	//
@@ -96,9 +221,49 @@ type FreezeExceptionStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// FreezeExceptionApproverStatus records one approval of a FreezeException.
+type FreezeExceptionApproverStatus struct {
+	// user is the approving user.
+	User string `json:"user"`
+
+	// approval is the name of the FreezeExceptionApproval object.
+	Approval string `json:"approval"`
+
+	// generation is the exception generation that was approved.
+	Generation int64 `json:"generation"`
+
+	// approvedAt is when the approval was created.
+	ApprovedAt metav1.Time `json:"approvedAt"`
+}
+
+// FreezeExceptionUsage records how often a FreezeException admitted a request.
+type FreezeExceptionUsage struct {
+	// count is the number of admitted requests.
+	// +optional
+	Count int32 `json:"count,omitempty"`
+
+	// lastUser is the user of the most recent admitted request.
+	// +optional
+	LastUser string `json:"lastUser,omitempty"`
+
+	// lastObject identifies the object of the most recent admitted request as
+	// Kind namespace/name.
+	// +optional
+	LastObject string `json:"lastObject,omitempty"`
+
+	// lastUsedTime is when the most recent request was admitted.
+	// +optional
+	LastUsedTime *metav1.Time `json:"lastUsedTime,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Start",type=string,JSONPath=`.spec.activeFrom`
+// +kubebuilder:printcolumn:name="End",type=string,JSONPath=`.spec.activeTo`
+// +kubebuilder:printcolumn:name="Starts In",type=string,JSONPath=`.status.timeUntilStart`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // FreezeException is the Schema for the freezeexceptions API
 type FreezeException struct {
@@ -0,0 +1,76 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FreezeExceptionApprovalSpec defines the desired state of FreezeExceptionApproval
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+type FreezeExceptionApprovalSpec struct {
+	// exceptionName is the name of the approved FreezeException.
+	// +kubebuilder:validation:MinLength=1
+	ExceptionName string `json:"exceptionName"`
+
+	// exceptionGeneration is the metadata.generation of the FreezeException being
+	// approved. Any later change to the exception invalidates the approval.
+	// +kubebuilder:validation:Minimum=1
+	ExceptionGeneration int64 `json:"exceptionGeneration"`
+
+	// approver is the approving user. It must be the user creating this object, and that
+	// user must be allowed the "approve" verb on the FreezeException.
+	// +kubebuilder:validation:MinLength=1
+	Approver string `json:"approver"`
+
+	// comment is an optional note from the approver.
+	// +optional
+	Comment string `json:"comment,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Exception",type=string,JSONPath=`.spec.exceptionName`
+// +kubebuilder:printcolumn:name="Approver",type=string,JSONPath=`.spec.approver`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// FreezeExceptionApproval is the Schema for the freezeexceptionapprovals API.
+// It records one user's approval of a FreezeException.
+type FreezeExceptionApproval struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the approval
+	// +required
+	Spec FreezeExceptionApprovalSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// FreezeExceptionApprovalList contains a list of FreezeExceptionApproval
+type FreezeExceptionApprovalList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []FreezeExceptionApproval `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FreezeExceptionApproval{}, &FreezeExceptionApprovalList{})
+}
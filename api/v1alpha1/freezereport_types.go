@@ -0,0 +1,239 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DriftType describes how a workload drifted during a freeze.
+// +kubebuilder:validation:Enum=Added;Removed;Modified
+type DriftType string
+
+const (
+	DriftTypeAdded    DriftType = "Added"
+	DriftTypeRemoved  DriftType = "Removed"
+	DriftTypeModified DriftType = "Modified"
+)
+
+// FreezeReportSpec identifies the freeze occurrence a report covers.
+type FreezeReportSpec struct {
+	// policy is the freeze the report covers.
+	Policy PolicyReference `json:"policy"`
+
+	// startTime is the start of the freeze occurrence.
+	StartTime metav1.Time `json:"startTime"`
+
+	// endTime is the end of the freeze occurrence.
+	EndTime metav1.Time `json:"endTime"`
+}
+
+// FreezeReportStatus holds the findings for a freeze occurrence.
+type FreezeReportStatus struct {
+	// snapshotTime is when the workloads were captured at the start of the freeze.
+	// +optional
+	SnapshotTime *metav1.Time `json:"snapshotTime,omitempty"`
+
+	// comparedTime is when the workloads were compared with the snapshot.
+	// +optional
+	ComparedTime *metav1.Time `json:"comparedTime,omitempty"`
+
+	// workloads is the number of workloads in the snapshot.
+	// +optional
+	Workloads int `json:"workloads,omitempty"`
+
+	// snapshotTruncated is set when the freeze targeted more workloads than the
+	// snapshot holds, so the drift covers only part of them.
+	// +optional
+	SnapshotTruncated bool `json:"snapshotTruncated,omitempty"`
+
+	// drifted is the number of workloads added, removed or modified during the freeze.
+	// +optional
+	Drifted int `json:"drifted,omitempty"`
+
+	// drift lists up to 200 drifted workloads, sorted by namespace, kind and name.
+	// +optional
+	Drift []WorkloadDrift `json:"drift,omitempty"`
+
+	// summary aggregates the requests the webhook decided and the objects the
+	// operator suspended or paused during the freeze.
+	// +optional
+	Summary *FreezeSummary `json:"summary,omitempty"`
+}
+
+// FreezeSummary aggregates what happened during a freeze occurrence. It is kept in
+// memory by the leading operator replica from the time its controller saw the
+// occurrence begin until it ends, so requests served by other replicas, or before
+// the leader last started, are not counted.
+type FreezeSummary struct {
+	// recordedSince is when the operator started recording the occurrence. It is
+	// later than the start of the freeze if the operator restarted during it.
+	RecordedSince metav1.Time `json:"recordedSince"`
+
+	// denied is the number of requests denied by the policy.
+	// +optional
+	Denied int `json:"denied,omitempty"`
+
+	// deniedByUser lists up to 50 users with the most denied requests.
+	// +optional
+	DeniedByUser []RequestCount `json:"deniedByUser,omitempty"`
+
+	// deniedByNamespace lists up to 50 namespaces with the most denied requests.
+	// +optional
+	DeniedByNamespace []RequestCount `json:"deniedByNamespace,omitempty"`
+
+	// deniedByAction counts denied requests per action.
+	// +optional
+	DeniedByAction []RequestCount `json:"deniedByAction,omitempty"`
+
+	// exceptions lists up to 50 FreezeExceptions that admitted requests, per user.
+	// +optional
+	Exceptions []ExceptionUse `json:"exceptions,omitempty"`
+
+	// breakGlassOverrides is the number of requests admitted with break-glass.
+	// +optional
+	BreakGlassOverrides int `json:"breakGlassOverrides,omitempty"`
+
+	// breakGlass lists the first 50 break-glass overrides.
+	// +optional
+	BreakGlass []BreakGlassUse `json:"breakGlass,omitempty"`
+
+	// gitopsPaused is the largest number of GitOps objects paused at once.
+	// +optional
+	GitopsPaused int `json:"gitopsPaused,omitempty"`
+
+	// gitopsResumed is the number of GitOps objects resumed when the freeze ended.
+	// +optional
+	GitopsResumed int `json:"gitopsResumed,omitempty"`
+
+	// cronJobsSuspended is the largest number of CronJobs suspended at once.
+	// +optional
+	CronJobsSuspended int `json:"cronJobsSuspended,omitempty"`
+}
+
+// RequestCount is the number of requests attributed to a user, namespace or action.
+type RequestCount struct {
+	// name is the user, namespace or action.
+	Name string `json:"name"`
+
+	// count is the number of requests.
+	Count int `json:"count"`
+}
+
+// ExceptionUse is the number of requests a FreezeException admitted for a user.
+type ExceptionUse struct {
+	// exception is the FreezeException name.
+	Exception string `json:"exception"`
+
+	// user is the requesting user.
+	User string `json:"user"`
+
+	// count is the number of requests admitted.
+	Count int `json:"count"`
+}
+
+// BreakGlassUse is a request admitted with break-glass.
+type BreakGlassUse struct {
+	// time is when the request was admitted.
+	Time metav1.Time `json:"time"`
+
+	// user is the requesting user.
+	User string `json:"user"`
+
+	// namespace is the namespace of the object.
+	Namespace string `json:"namespace"`
+
+	// kind is the kind of the object.
+	Kind TargetKind `json:"kind"`
+
+	// name is the name of the object.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// action is the action that was admitted.
+	Action Action `json:"action"`
+
+	// reason is the reason given in the break-glass annotation.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// WorkloadDrift describes a workload that changed during a freeze.
+type WorkloadDrift struct {
+	// kind is the workload kind.
+	Kind TargetKind `json:"kind"`
+
+	// namespace is the workload namespace.
+	Namespace string `json:"namespace"`
+
+	// name is the workload name.
+	Name string `json:"name"`
+
+	// type is how the workload drifted.
+	Type DriftType `json:"type"`
+
+	// changes describes what was modified, for example "replicas: 3 -> 5".
+	// +optional
+	Changes []string `json:"changes,omitempty"`
+
+	// managers are the field managers that wrote the workload during the freeze.
+	// They tell bypasses, exceptions and operator changes apart.
+	// +optional
+	Managers []string `json:"managers,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Policy Kind",type=string,JSONPath=`.spec.policy.kind`
+// +kubebuilder:printcolumn:name="Policy",type=string,JSONPath=`.spec.policy.name`
+// +kubebuilder:printcolumn:name="Start",type=date,JSONPath=`.spec.startTime`
+// +kubebuilder:printcolumn:name="End",type=date,JSONPath=`.spec.endTime`
+// +kubebuilder:printcolumn:name="Drifted",type=integer,JSONPath=`.status.drifted`
+// +kubebuilder:printcolumn:name="Denied",type=integer,JSONPath=`.status.summary.denied`
+
+// FreezeReport is the Schema for the freezereports API.
+// The operator creates one per freeze occurrence once the freeze has ended.
+// ChangeFreezes record drift and a summary, MaintenanceWindows a summary only.
+type FreezeReport struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec identifies the freeze occurrence
+	// +required
+	Spec FreezeReportSpec `json:"spec"`
+
+	// status holds the findings
+	// +optional
+	Status FreezeReportStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// FreezeReportList contains a list of FreezeReport
+type FreezeReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []FreezeReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FreezeReport{}, &FreezeReportList{})
+}
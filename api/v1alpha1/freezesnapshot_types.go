@@ -0,0 +1,118 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FreezeSnapshotSpec holds the state of the workloads a freeze covers, captured
+// when it activated.
+type FreezeSnapshotSpec struct {
+	// policy is the freeze the snapshot was taken for.
+	Policy PolicyReference `json:"policy"`
+
+	// freezeStartTime is the start of the freeze occurrence the snapshot belongs to.
+	FreezeStartTime metav1.Time `json:"freezeStartTime"`
+
+	// takenAt is when the snapshot was taken.
+	TakenAt metav1.Time `json:"takenAt"`
+
+	// workloads is the captured state of the targeted workloads, sorted by
+	// namespace, kind and name. At most 1000 workloads are captured.
+	// +optional
+	// +kubebuilder:validation:MaxItems=1000
+	Workloads []WorkloadSnapshot `json:"workloads,omitempty"`
+
+	// truncated is set when the freeze targets more workloads than were captured.
+	// Drift is then only reported for the captured workloads and the ones that
+	// sort before the last of them.
+	// +optional
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// WorkloadSnapshot is the captured state of one workload.
+type WorkloadSnapshot struct {
+	// kind is the workload kind.
+	Kind TargetKind `json:"kind"`
+
+	// namespace is the workload namespace.
+	Namespace string `json:"namespace"`
+
+	// name is the workload name.
+	Name string `json:"name"`
+
+	// templateHash is a hash of the workload's pod template, or of the job
+	// template for a CronJob.
+	TemplateHash string `json:"templateHash"`
+
+	// replicas is the desired replica count of a Deployment or StatefulSet.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// images lists the image of each container in the template.
+	// +optional
+	Images []ContainerImage `json:"images,omitempty"`
+}
+
+// ContainerImage is the image a container runs.
+type ContainerImage struct {
+	// container is the container name.
+	Container string `json:"container"`
+
+	// image is the image reference in the template.
+	Image string `json:"image"`
+
+	// digests are the image IDs reported by the workload's running pods.
+	// +optional
+	Digests []string `json:"digests,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Policy Kind",type=string,JSONPath=`.spec.policy.kind`
+// +kubebuilder:printcolumn:name="Policy",type=string,JSONPath=`.spec.policy.name`
+// +kubebuilder:printcolumn:name="Taken",type=date,JSONPath=`.spec.takenAt`
+// +kubebuilder:printcolumn:name="Truncated",type=boolean,JSONPath=`.spec.truncated`
+
+// FreezeSnapshot is the Schema for the freezesnapshots API.
+// It is created by the operator when a ChangeFreeze activates and deleted once
+// the freeze's FreezeReport has been produced.
+type FreezeSnapshot struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec holds the captured state
+	// +required
+	Spec FreezeSnapshotSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// FreezeSnapshotList contains a list of FreezeSnapshot
+type FreezeSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []FreezeSnapshot `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FreezeSnapshot{}, &FreezeSnapshotList{})
+}
@@ -43,6 +43,11 @@ type MaintenanceWindowSpec struct {
 	// rules define which actions are denied when the policy is active.
 	Rules PolicyRulesSpec `json:"rules"`
 
+	// noticePeriod is how long before the current window closes matching requests
+	// receive an admission warning that changes are about to be denied.
+	// +optional
+	NoticePeriod *metav1.Duration `json:"noticePeriod,omitempty"`
+
 	// behavior configures optional side-effects.
 	// +optional
 	Behavior PolicyBehaviorSpec `json:"behavior,omitempty"`
@@ -93,6 +98,16 @@ type MaintenanceWindowStatus struct {
 	// +optional
 	GitopsLastReconcileTime *metav1.Time `json:"gitopsLastReconcileTime,omitempty"`
 
+	// affectedWorkloads summarizes the namespaces and workloads the target selects.
+	// +optional
+	AffectedWorkloads *AffectedWorkloadsStatus `json:"affectedWorkloads,omitempty"`
+
+	// hooks records the Job each hook launched for the policy's last transitions.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Hooks []HookStatus `json:"hooks,omitempty"`
+
 	// conditions represent the current state of the MaintenanceWindow resource.
 	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
 	//
@@ -0,0 +1,69 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RolloutLimitSpec defines the desired state of RolloutLimit
+// +kubebuilder:validation:XValidation:rule="self.target.kinds.all(k, k == 'Deployment' || k == 'StatefulSet')",message="target.kinds may only contain Deployment and StatefulSet"
+type RolloutLimitSpec struct {
+	// target selects the namespaces and workloads that share the limit. Workloads in
+	// scope count towards it, and ROLL_OUT requests for them are limited.
+	Target TargetSpec `json:"target"`
+
+	// maxConcurrent is the number of workloads in scope that may roll out at once.
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrent int32 `json:"maxConcurrent"`
+
+	// message configures user-facing denial message data.
+	// +optional
+	Message MessageSpec `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Max",type=integer,JSONPath=`.spec.maxConcurrent`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// RolloutLimit is the Schema for the rolloutlimits API.
+// It limits how many Deployments and StatefulSets may roll out at the same time.
+type RolloutLimit struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the limit
+	// +required
+	Spec RolloutLimitSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// RolloutLimitList contains a list of RolloutLimit
+type RolloutLimitList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []RolloutLimit `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RolloutLimit{}, &RolloutLimitList{})
+}
@@ -22,9 +22,65 @@ package v1alpha1
 
 import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AffectedWorkload) DeepCopyInto(out *AffectedWorkload) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AffectedWorkload.
+func (in *AffectedWorkload) DeepCopy() *AffectedWorkload {
+	if in == nil {
+		return nil
+	}
+	out := new(AffectedWorkload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AffectedWorkloadsStatus) DeepCopyInto(out *AffectedWorkloadsStatus) {
+	*out = *in
+	if in.Counts != nil {
+		in, out := &in.Counts, &out.Counts
+		*out = make([]WorkloadCount, len(*in))
+		copy(*out, *in)
+	}
+	if in.Sample != nil {
+		in, out := &in.Sample, &out.Sample
+		*out = make([]AffectedWorkload, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AffectedWorkloadsStatus.
+func (in *AffectedWorkloadsStatus) DeepCopy() *AffectedWorkloadsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AffectedWorkloadsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BreakGlassUse) DeepCopyInto(out *BreakGlassUse) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BreakGlassUse.
+func (in *BreakGlassUse) DeepCopy() *BreakGlassUse {
+	if in == nil {
+		return nil
+	}
+	out := new(BreakGlassUse)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChangeFreeze) DeepCopyInto(out *ChangeFreeze) {
 	*out = *in
@@ -96,8 +152,18 @@ func (in *ChangeFreezeSpec) DeepCopyInto(out *ChangeFreezeSpec) {
 	}
 	in.Target.DeepCopyInto(&out.Target)
 	in.Rules.DeepCopyInto(&out.Rules)
+	if in.NoticePeriod != nil {
+		in, out := &in.NoticePeriod, &out.NoticePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	in.Behavior.DeepCopyInto(&out.Behavior)
 	out.Message = in.Message
+	if in.TTLAfterExpired != nil {
+		in, out := &in.TTLAfterExpired, &out.TTLAfterExpired
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChangeFreezeSpec.
@@ -118,10 +184,31 @@ func (in *ChangeFreezeStatus) DeepCopyInto(out *ChangeFreezeStatus) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.TimeUntilStart != nil {
+		in, out := &in.TimeUntilStart, &out.TimeUntilStart
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
 	if in.GitopsLastReconcileTime != nil {
 		in, out := &in.GitopsLastReconcileTime, &out.GitopsLastReconcileTime
 		*out = (*in).DeepCopy()
 	}
+	if in.AffectedWorkloads != nil {
+		in, out := &in.AffectedWorkloads, &out.AffectedWorkloads
+		*out = new(AffectedWorkloadsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = make([]HookStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -141,6 +228,219 @@ func (in *ChangeFreezeStatus) DeepCopy() *ChangeFreezeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerConstraintSpec) DeepCopyInto(out *ContainerConstraintSpec) {
+	*out = *in
+	if in.AllowedChanges != nil {
+		in, out := &in.AllowedChanges, &out.AllowedChanges
+		*out = make([]ContainerChange, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerConstraintSpec.
+func (in *ContainerConstraintSpec) DeepCopy() *ContainerConstraintSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerConstraintSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerImage) DeepCopyInto(out *ContainerImage) {
+	*out = *in
+	if in.Digests != nil {
+		in, out := &in.Digests, &out.Digests
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerImage.
+func (in *ContainerImage) DeepCopy() *ContainerImage {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeferredChange) DeepCopyInto(out *DeferredChange) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeferredChange.
+func (in *DeferredChange) DeepCopy() *DeferredChange {
+	if in == nil {
+		return nil
+	}
+	out := new(DeferredChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DeferredChange) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeferredChangeList) DeepCopyInto(out *DeferredChangeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DeferredChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeferredChangeList.
+func (in *DeferredChangeList) DeepCopy() *DeferredChangeList {
+	if in == nil {
+		return nil
+	}
+	out := new(DeferredChangeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DeferredChangeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeferredChangePolicyRef) DeepCopyInto(out *DeferredChangePolicyRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeferredChangePolicyRef.
+func (in *DeferredChangePolicyRef) DeepCopy() *DeferredChangePolicyRef {
+	if in == nil {
+		return nil
+	}
+	out := new(DeferredChangePolicyRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeferredChangeRequester) DeepCopyInto(out *DeferredChangeRequester) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeferredChangeRequester.
+func (in *DeferredChangeRequester) DeepCopy() *DeferredChangeRequester {
+	if in == nil {
+		return nil
+	}
+	out := new(DeferredChangeRequester)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeferredChangeSpec) DeepCopyInto(out *DeferredChangeSpec) {
+	*out = *in
+	in.Object.DeepCopyInto(&out.Object)
+	if in.BaseObject != nil {
+		in, out := &in.BaseObject, &out.BaseObject
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Requester.DeepCopyInto(&out.Requester)
+	out.BlockingPolicy = in.BlockingPolicy
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeferredChangeSpec.
+func (in *DeferredChangeSpec) DeepCopy() *DeferredChangeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeferredChangeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeferredChangeStatus) DeepCopyInto(out *DeferredChangeStatus) {
+	*out = *in
+	if in.AppliedTime != nil {
+		in, out := &in.AppliedTime, &out.AppliedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeferredChangeStatus.
+func (in *DeferredChangeStatus) DeepCopy() *DeferredChangeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeferredChangeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExceptionObjectReference) DeepCopyInto(out *ExceptionObjectReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExceptionObjectReference.
+func (in *ExceptionObjectReference) DeepCopy() *ExceptionObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ExceptionObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExceptionUse) DeepCopyInto(out *ExceptionUse) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExceptionUse.
+func (in *ExceptionUse) DeepCopy() *ExceptionUse {
+	if in == nil {
+		return nil
+	}
+	out := new(ExceptionUse)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FreezeException) DeepCopyInto(out *FreezeException) {
 	*out = *in
@@ -168,6 +468,95 @@ func (in *FreezeException) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezeExceptionApproval) DeepCopyInto(out *FreezeExceptionApproval) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeExceptionApproval.
+func (in *FreezeExceptionApproval) DeepCopy() *FreezeExceptionApproval {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezeExceptionApproval)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FreezeExceptionApproval) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezeExceptionApprovalList) DeepCopyInto(out *FreezeExceptionApprovalList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FreezeExceptionApproval, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeExceptionApprovalList.
+func (in *FreezeExceptionApprovalList) DeepCopy() *FreezeExceptionApprovalList {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezeExceptionApprovalList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FreezeExceptionApprovalList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezeExceptionApprovalSpec) DeepCopyInto(out *FreezeExceptionApprovalSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeExceptionApprovalSpec.
+func (in *FreezeExceptionApprovalSpec) DeepCopy() *FreezeExceptionApprovalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezeExceptionApprovalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezeExceptionApproverStatus) DeepCopyInto(out *FreezeExceptionApproverStatus) {
+	*out = *in
+	in.ApprovedAt.DeepCopyInto(&out.ApprovedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeExceptionApproverStatus.
+func (in *FreezeExceptionApproverStatus) DeepCopy() *FreezeExceptionApproverStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezeExceptionApproverStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FreezeExceptionConstraintsSpec) DeepCopyInto(out *FreezeExceptionConstraintsSpec) {
 	*out = *in
@@ -188,44 +577,326 @@ func (in *FreezeExceptionConstraintsSpec) DeepCopyInto(out *FreezeExceptionConst
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AllowedImages != nil {
+		in, out := &in.AllowedImages, &out.AllowedImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]ContainerConstraintSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeExceptionConstraintsSpec.
+func (in *FreezeExceptionConstraintsSpec) DeepCopy() *FreezeExceptionConstraintsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezeExceptionConstraintsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezeExceptionList) DeepCopyInto(out *FreezeExceptionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FreezeException, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeExceptionList.
+func (in *FreezeExceptionList) DeepCopy() *FreezeExceptionList {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezeExceptionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FreezeExceptionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezeExceptionSpec) DeepCopyInto(out *FreezeExceptionSpec) {
+	*out = *in
+	in.ActiveFrom.DeepCopyInto(&out.ActiveFrom)
+	in.ActiveTo.DeepCopyInto(&out.ActiveTo)
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(TargetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]ExceptionObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = make([]Action, len(*in))
+		copy(*out, *in)
+	}
+	if in.Constraints != nil {
+		in, out := &in.Constraints, &out.Constraints
+		*out = new(FreezeExceptionConstraintsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TTLAfterExpired != nil {
+		in, out := &in.TTLAfterExpired, &out.TTLAfterExpired
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeExceptionSpec.
+func (in *FreezeExceptionSpec) DeepCopy() *FreezeExceptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezeExceptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezeExceptionStatus) DeepCopyInto(out *FreezeExceptionStatus) {
+	*out = *in
+	if in.TimeUntilStart != nil {
+		in, out := &in.TimeUntilStart, &out.TimeUntilStart
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Approvals != nil {
+		in, out := &in.Approvals, &out.Approvals
+		*out = make([]FreezeExceptionApproverStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Usage.DeepCopyInto(&out.Usage)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeExceptionStatus.
+func (in *FreezeExceptionStatus) DeepCopy() *FreezeExceptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezeExceptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezeExceptionUsage) DeepCopyInto(out *FreezeExceptionUsage) {
+	*out = *in
+	if in.LastUsedTime != nil {
+		in, out := &in.LastUsedTime, &out.LastUsedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeExceptionUsage.
+func (in *FreezeExceptionUsage) DeepCopy() *FreezeExceptionUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezeExceptionUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezeReport) DeepCopyInto(out *FreezeReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeReport.
+func (in *FreezeReport) DeepCopy() *FreezeReport {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezeReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FreezeReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezeReportList) DeepCopyInto(out *FreezeReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FreezeReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeReportList.
+func (in *FreezeReportList) DeepCopy() *FreezeReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezeReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FreezeReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezeReportSpec) DeepCopyInto(out *FreezeReportSpec) {
+	*out = *in
+	out.Policy = in.Policy
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.EndTime.DeepCopyInto(&out.EndTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeReportSpec.
+func (in *FreezeReportSpec) DeepCopy() *FreezeReportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezeReportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezeReportStatus) DeepCopyInto(out *FreezeReportStatus) {
+	*out = *in
+	if in.SnapshotTime != nil {
+		in, out := &in.SnapshotTime, &out.SnapshotTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ComparedTime != nil {
+		in, out := &in.ComparedTime, &out.ComparedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]WorkloadDrift, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Summary != nil {
+		in, out := &in.Summary, &out.Summary
+		*out = new(FreezeSummary)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeReportStatus.
+func (in *FreezeReportStatus) DeepCopy() *FreezeReportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezeReportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezeSnapshot) DeepCopyInto(out *FreezeSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeExceptionConstraintsSpec.
-func (in *FreezeExceptionConstraintsSpec) DeepCopy() *FreezeExceptionConstraintsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeSnapshot.
+func (in *FreezeSnapshot) DeepCopy() *FreezeSnapshot {
 	if in == nil {
 		return nil
 	}
-	out := new(FreezeExceptionConstraintsSpec)
+	out := new(FreezeSnapshot)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FreezeSnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FreezeExceptionList) DeepCopyInto(out *FreezeExceptionList) {
+func (in *FreezeSnapshotList) DeepCopyInto(out *FreezeSnapshotList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]FreezeException, len(*in))
+		*out = make([]FreezeSnapshot, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeExceptionList.
-func (in *FreezeExceptionList) DeepCopy() *FreezeExceptionList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeSnapshotList.
+func (in *FreezeSnapshotList) DeepCopy() *FreezeSnapshotList {
 	if in == nil {
 		return nil
 	}
-	out := new(FreezeExceptionList)
+	out := new(FreezeSnapshotList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *FreezeExceptionList) DeepCopyObject() runtime.Object {
+func (in *FreezeSnapshotList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -233,51 +904,69 @@ func (in *FreezeExceptionList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FreezeExceptionSpec) DeepCopyInto(out *FreezeExceptionSpec) {
+func (in *FreezeSnapshotSpec) DeepCopyInto(out *FreezeSnapshotSpec) {
 	*out = *in
-	in.ActiveFrom.DeepCopyInto(&out.ActiveFrom)
-	in.ActiveTo.DeepCopyInto(&out.ActiveTo)
-	in.Target.DeepCopyInto(&out.Target)
-	if in.Allow != nil {
-		in, out := &in.Allow, &out.Allow
-		*out = make([]Action, len(*in))
-		copy(*out, *in)
-	}
-	if in.Constraints != nil {
-		in, out := &in.Constraints, &out.Constraints
-		*out = new(FreezeExceptionConstraintsSpec)
-		(*in).DeepCopyInto(*out)
+	out.Policy = in.Policy
+	in.FreezeStartTime.DeepCopyInto(&out.FreezeStartTime)
+	in.TakenAt.DeepCopyInto(&out.TakenAt)
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = make([]WorkloadSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeExceptionSpec.
-func (in *FreezeExceptionSpec) DeepCopy() *FreezeExceptionSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeSnapshotSpec.
+func (in *FreezeSnapshotSpec) DeepCopy() *FreezeSnapshotSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FreezeExceptionSpec)
+	out := new(FreezeSnapshotSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FreezeExceptionStatus) DeepCopyInto(out *FreezeExceptionStatus) {
+func (in *FreezeSummary) DeepCopyInto(out *FreezeSummary) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+	in.RecordedSince.DeepCopyInto(&out.RecordedSince)
+	if in.DeniedByUser != nil {
+		in, out := &in.DeniedByUser, &out.DeniedByUser
+		*out = make([]RequestCount, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedByNamespace != nil {
+		in, out := &in.DeniedByNamespace, &out.DeniedByNamespace
+		*out = make([]RequestCount, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedByAction != nil {
+		in, out := &in.DeniedByAction, &out.DeniedByAction
+		*out = make([]RequestCount, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exceptions != nil {
+		in, out := &in.Exceptions, &out.Exceptions
+		*out = make([]ExceptionUse, len(*in))
+		copy(*out, *in)
+	}
+	if in.BreakGlass != nil {
+		in, out := &in.BreakGlass, &out.BreakGlass
+		*out = make([]BreakGlassUse, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeExceptionStatus.
-func (in *FreezeExceptionStatus) DeepCopy() *FreezeExceptionStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeSummary.
+func (in *FreezeSummary) DeepCopy() *FreezeSummary {
 	if in == nil {
 		return nil
 	}
-	out := new(FreezeExceptionStatus)
+	out := new(FreezeSummary)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -367,6 +1056,57 @@ func (in *GitOpsSpec) DeepCopy() *GitOpsSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HookSpec) DeepCopyInto(out *HookSpec) {
+	*out = *in
+	out.JobTemplateRef = in.JobTemplateRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookSpec.
+func (in *HookSpec) DeepCopy() *HookSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HookSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HookStatus) DeepCopyInto(out *HookStatus) {
+	*out = *in
+	in.TransitionTime.DeepCopyInto(&out.TransitionTime)
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookStatus.
+func (in *HookStatus) DeepCopy() *HookStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HookStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobTemplateReference) DeepCopyInto(out *JobTemplateReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobTemplateReference.
+func (in *JobTemplateReference) DeepCopy() *JobTemplateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(JobTemplateReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
 	*out = *in
@@ -436,6 +1176,11 @@ func (in *MaintenanceWindowSpec) DeepCopyInto(out *MaintenanceWindowSpec) {
 	}
 	in.Target.DeepCopyInto(&out.Target)
 	in.Rules.DeepCopyInto(&out.Rules)
+	if in.NoticePeriod != nil {
+		in, out := &in.NoticePeriod, &out.NoticePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	in.Behavior.DeepCopyInto(&out.Behavior)
 	out.Message = in.Message
 }
@@ -467,6 +1212,18 @@ func (in *MaintenanceWindowStatus) DeepCopyInto(out *MaintenanceWindowStatus) {
 		in, out := &in.GitopsLastReconcileTime, &out.GitopsLastReconcileTime
 		*out = (*in).DeepCopy()
 	}
+	if in.AffectedWorkloads != nil {
+		in, out := &in.AffectedWorkloads, &out.AffectedWorkloads
+		*out = new(AffectedWorkloadsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = make([]HookStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -525,6 +1282,11 @@ func (in *PolicyBehaviorSpec) DeepCopyInto(out *PolicyBehaviorSpec) {
 		*out = new(GitOpsSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = new(PolicyHooksSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyBehaviorSpec.
@@ -537,6 +1299,46 @@ func (in *PolicyBehaviorSpec) DeepCopy() *PolicyBehaviorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyHooksSpec) DeepCopyInto(out *PolicyHooksSpec) {
+	*out = *in
+	if in.OnActivate != nil {
+		in, out := &in.OnActivate, &out.OnActivate
+		*out = new(HookSpec)
+		**out = **in
+	}
+	if in.OnDeactivate != nil {
+		in, out := &in.OnDeactivate, &out.OnDeactivate
+		*out = new(HookSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyHooksSpec.
+func (in *PolicyHooksSpec) DeepCopy() *PolicyHooksSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyHooksSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyReference) DeepCopyInto(out *PolicyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyReference.
+func (in *PolicyReference) DeepCopy() *PolicyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PolicyRulesSpec) DeepCopyInto(out *PolicyRulesSpec) {
 	*out = *in
@@ -545,6 +1347,11 @@ func (in *PolicyRulesSpec) DeepCopyInto(out *PolicyRulesSpec) {
 		*out = make([]Action, len(*in))
 		copy(*out, *in)
 	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimitSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRulesSpec.
@@ -557,6 +1364,117 @@ func (in *PolicyRulesSpec) DeepCopy() *PolicyRulesSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitSpec) DeepCopyInto(out *RateLimitSpec) {
+	*out = *in
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]Action, len(*in))
+		copy(*out, *in)
+	}
+	out.Period = in.Period
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitSpec.
+func (in *RateLimitSpec) DeepCopy() *RateLimitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestCount) DeepCopyInto(out *RequestCount) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestCount.
+func (in *RequestCount) DeepCopy() *RequestCount {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutLimit) DeepCopyInto(out *RolloutLimit) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutLimit.
+func (in *RolloutLimit) DeepCopy() *RolloutLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RolloutLimit) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutLimitList) DeepCopyInto(out *RolloutLimitList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RolloutLimit, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutLimitList.
+func (in *RolloutLimitList) DeepCopy() *RolloutLimitList {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutLimitList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RolloutLimitList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutLimitSpec) DeepCopyInto(out *RolloutLimitSpec) {
+	*out = *in
+	in.Target.DeepCopyInto(&out.Target)
+	out.Message = in.Message
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutLimitSpec.
+func (in *RolloutLimitSpec) DeepCopy() *RolloutLimitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutLimitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TargetSpec) DeepCopyInto(out *TargetSpec) {
 	*out = *in
@@ -603,3 +1521,70 @@ func (in *WindowStatus) DeepCopy() *WindowStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadCount) DeepCopyInto(out *WorkloadCount) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadCount.
+func (in *WorkloadCount) DeepCopy() *WorkloadCount {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadDrift) DeepCopyInto(out *WorkloadDrift) {
+	*out = *in
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Managers != nil {
+		in, out := &in.Managers, &out.Managers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadDrift.
+func (in *WorkloadDrift) DeepCopy() *WorkloadDrift {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadSnapshot) DeepCopyInto(out *WorkloadSnapshot) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]ContainerImage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadSnapshot.
+func (in *WorkloadSnapshot) DeepCopy() *WorkloadSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -51,18 +51,28 @@ type apiResponse struct {
 	NextAllowed   *string `json:"nextAllowedTime,omitempty"`
 	EvaluatedAt   string  `json:"evaluatedAt"`
 	Error         string  `json:"error,omitempty"`
+
+	Upcoming []apiUpcoming `json:"upcoming,omitempty"`
+}
+
+type apiUpcoming struct {
+	Name       string `json:"name"`
+	PolicyKind string `json:"policyKind"`
+	StartTime  string `json:"startTime"`
+	Message    string `json:"message"`
 }
 
 func usage() {
 	fmt.Fprintf(os.Stderr, `kfo — kube-freeze-operator CLI
 
 Usage:
-  kfo can-i --namespace <ns> --kind <kind> --action <action> [flags]
+  kfo can-i --namespace <ns> --kind <kind> --action <action> [--name <name>] [flags]
 
 Flags:
   --namespace, -n    Target namespace (required)
   --kind, -k         Resource kind: Deployment, StatefulSet, DaemonSet, CronJob (required)
   --action, -a       Action: CREATE, DELETE, ROLL_OUT, SCALE (required)
+  --name             Resource name (matches FreezeExceptions that list objects)
   --api-url          Use API mode: URL of freeze-operator API (e.g. http://localhost:8082)
   --json             Output as JSON
   --help, -h         Show help
@@ -87,7 +97,7 @@ func main() {
 		os.Exit(2)
 	}
 
-	var namespace, kind, action, apiURL string
+	var namespace, kind, action, name, apiURL string
 	var jsonOutput bool
 
 	args := os.Args[2:]
@@ -102,6 +112,9 @@ func main() {
 		case "--action", "-a":
 			i++
 			action = args[i]
+		case "--name":
+			i++
+			name = args[i]
 		case "--api-url":
 			i++
 			apiURL = args[i]
@@ -128,9 +141,9 @@ func main() {
 	var err error
 
 	if apiURL != "" {
-		resp, err = evalViaAPI(apiURL, namespace, kind, action)
+		resp, err = evalViaAPI(apiURL, namespace, kind, action, name)
 	} else {
-		resp, err = evalDirect(namespace, kind, action)
+		resp, err = evalDirect(namespace, kind, action, name)
 	}
 
 	if err != nil {
@@ -151,9 +164,12 @@ func main() {
 	}
 }
 
-func evalViaAPI(apiURL, namespace, kind, action string) (apiResponse, error) {
+func evalViaAPI(apiURL, namespace, kind, action, name string) (apiResponse, error) {
 	url := fmt.Sprintf("%s/v1/evaluate?namespace=%s&kind=%s&action=%s",
 		strings.TrimRight(apiURL, "/"), namespace, kind, action)
+	if name != "" {
+		url += "&name=" + name
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -181,7 +197,7 @@ func evalViaAPI(apiURL, namespace, kind, action string) (apiResponse, error) {
 	return resp, nil
 }
 
-func evalDirect(namespace, kind, action string) (apiResponse, error) {
+func evalDirect(namespace, kind, action, name string) (apiResponse, error) {
 	cfg, err := ctrl.GetConfig()
 	if err != nil {
 		return apiResponse{}, fmt.Errorf("failed to get kubeconfig: %w", err)
@@ -208,6 +224,7 @@ func evalDirect(namespace, kind, action string) (apiResponse, error) {
 		Namespace: namespace,
 		Kind:      parsedKind,
 		Action:    parsedAction,
+		Name:      name,
 	}
 
 	eval := &policy.Evaluator{Client: cl}
@@ -237,6 +254,15 @@ func evalDirect(namespace, kind, action string) (apiResponse, error) {
 		}
 	}
 
+	for _, u := range dec.Upcoming {
+		resp.Upcoming = append(resp.Upcoming, apiUpcoming{
+			Name:       u.Policy.Name,
+			PolicyKind: string(u.Policy.Kind),
+			StartTime:  u.StartTime.Format(time.RFC3339),
+			Message:    u.Message(now),
+		})
+	}
+
 	return resp, nil
 }
 
@@ -283,4 +309,7 @@ func printHuman(resp apiResponse, ns, kind, action string) {
 			fmt.Printf("   Ends at: %s\n", *resp.FreezeEndTime)
 		}
 	}
+	for _, u := range resp.Upcoming {
+		fmt.Printf("⚠️  %s (at %s)\n", u.Message, u.StartTime)
+	}
 }
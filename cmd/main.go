@@ -19,7 +19,11 @@ package main
 import (
 	"crypto/tls"
 	"flag"
+	"math"
 	"os"
+	"regexp"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -39,8 +43,11 @@ import (
 
 	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
 	"github.com/jamalshahverdiev/kube-freeze-operator/internal/api"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/budget"
 	"github.com/jamalshahverdiev/kube-freeze-operator/internal/controller"
 	_ "github.com/jamalshahverdiev/kube-freeze-operator/internal/metrics"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/report"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/webhook/namespaces"
 	webhookv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/internal/webhook/v1alpha1"
 	"github.com/jamalshahverdiev/kube-freeze-operator/internal/webhook/workloads"
 	// +kubebuilder:scaffold:imports
@@ -68,6 +75,17 @@ func main() {
 	var apiAddr string
 	var apiAuthMode string
 	var secureMetrics bool
+	var tamperProtection bool
+	var policyAdminGroups string
+	var exceptionSeparateApprover bool
+	var exceptionApproverGroups string
+	var exceptionMaxDuration time.Duration
+	var exceptionRequireTicketURL bool
+	var exceptionTicketURLPattern string
+	var exceptionMinApprovals int
+	var breakGlassGroups string
+	var orphanSweepInterval time.Duration
+	var orphanSweepDryRun bool
 	var enableHTTP2 bool
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
@@ -75,6 +93,33 @@ func main() {
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.StringVar(&apiAddr, "api-bind-address", ":8082", "The address the CI helper API binds to. Set to 0 to disable.")
 	flag.StringVar(&apiAuthMode, "api-auth-mode", "none", "API authentication mode: none or token (TokenReview).")
+	flag.BoolVar(&tamperProtection, "tamper-protection", false,
+		"Deny deleting, shortening or narrowing enforcing ChangeFreezes and MaintenanceWindows "+
+			"unless the requester is in one of --policy-admin-groups.")
+	flag.StringVar(&policyAdminGroups, "policy-admin-groups", "",
+		"Comma-separated groups allowed to bypass tamper protection.")
+	flag.BoolVar(&exceptionSeparateApprover, "exception-require-separate-approver", false,
+		"Require FreezeException spec.approvedBy to be set by a member of --exception-approver-groups "+
+			"other than the creator. Unapproved exceptions are ignored.")
+	flag.StringVar(&exceptionApproverGroups, "exception-approver-groups", "",
+		"Comma-separated groups whose members may approve FreezeExceptions.")
+	flag.DurationVar(&exceptionMaxDuration, "exception-max-duration", 0,
+		"Maximum FreezeException duration (activeTo - activeFrom). 0 means unlimited.")
+	flag.BoolVar(&exceptionRequireTicketURL, "exception-require-ticket-url", false,
+		"Require spec.ticketURL on every FreezeException.")
+	flag.StringVar(&exceptionTicketURLPattern, "exception-ticket-url-pattern", "",
+		"Regular expression that FreezeException spec.ticketURL must match.")
+	flag.IntVar(&exceptionMinApprovals, "exception-min-approvals", 0,
+		"Minimum number of FreezeExceptionApprovals every FreezeException needs, "+
+			"regardless of a lower spec.requiredApprovals.")
+	flag.StringVar(&breakGlassGroups, "break-glass-groups", "",
+		"Comma-separated groups whose members may override freeze policies with the "+
+			"freeze-operator.io/break-glass annotation. Break-glass is disabled when empty.")
+	flag.DurationVar(&orphanSweepInterval, "orphan-sweep-interval", 10*time.Minute,
+		"Interval at which CronJobs and GitOps objects held by a deleted or inactive policy are restored. "+
+			"Set to 0 to disable the sweeper.")
+	flag.BoolVar(&orphanSweepDryRun, "orphan-sweep-dry-run", false,
+		"Only report orphaned objects through logs, Events and metrics instead of restoring them.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
@@ -97,6 +142,26 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	exceptionPolicy := webhookv1alpha1.ExceptionPolicy{
+		RequireSeparateApprover: exceptionSeparateApprover,
+		ApproverGroups:          splitList(exceptionApproverGroups),
+		MaxDuration:             exceptionMaxDuration,
+		RequireTicketURL:        exceptionRequireTicketURL,
+	}
+	if exceptionTicketURLPattern != "" {
+		re, err := regexp.Compile(exceptionTicketURLPattern)
+		if err != nil {
+			setupLog.Error(err, "invalid --exception-ticket-url-pattern")
+			os.Exit(1)
+		}
+		exceptionPolicy.TicketURLPattern = re
+	}
+	if exceptionMinApprovals < 0 || exceptionMinApprovals > math.MaxInt32 {
+		setupLog.Error(nil, "invalid --exception-min-approvals", "value", exceptionMinApprovals)
+		os.Exit(1)
+	}
+	minApprovals := int32(exceptionMinApprovals)
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -188,51 +253,130 @@ func main() {
 		os.Exit(1)
 	}
 
+	// The webhook and the policy controllers share one aggregator for the
+	// summaries in FreezeReports.
+	reports := &report.Aggregator{}
+
 	if err := (&controller.MaintenanceWindowReconciler{
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
 		Recorder: mgr.GetEventRecorderFor("maintenancewindow-controller"), //nolint:staticcheck
+		Reports:  reports,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "MaintenanceWindow")
 		os.Exit(1)
 	}
 	if err := (&controller.ChangeFreezeReconciler{
-		Client:   mgr.GetClient(),
-		Scheme:   mgr.GetScheme(),
-		Recorder: mgr.GetEventRecorderFor("changefreeze-controller"), //nolint:staticcheck
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Recorder:  mgr.GetEventRecorderFor("changefreeze-controller"), //nolint:staticcheck
+		APIReader: mgr.GetAPIReader(),
+		Reports:   reports,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ChangeFreeze")
 		os.Exit(1)
 	}
 	if err := (&controller.FreezeExceptionReconciler{
-		Client:   mgr.GetClient(),
-		Scheme:   mgr.GetScheme(),
-		Recorder: mgr.GetEventRecorderFor("freezeexception-controller"), //nolint:staticcheck
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		Recorder:     mgr.GetEventRecorderFor("freezeexception-controller"), //nolint:staticcheck
+		MinApprovals: minApprovals,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "FreezeException")
 		os.Exit(1)
 	}
+	if err := (&controller.DeferredChangeReconciler{
+		Client:                    mgr.GetClient(),
+		Scheme:                    mgr.GetScheme(),
+		Recorder:                  mgr.GetEventRecorderFor("deferredchange-controller"), //nolint:staticcheck
+		APIReader:                 mgr.GetAPIReader(),
+		RequireApprovedExceptions: exceptionSeparateApprover,
+		MinApprovals:              minApprovals,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DeferredChange")
+		os.Exit(1)
+	}
+	if len(splitList(breakGlassGroups)) > 0 {
+		for _, gvk := range controller.BreakGlassKinds {
+			if err := (&controller.BreakGlassReconciler{
+				Client:   mgr.GetClient(),
+				Scheme:   mgr.GetScheme(),
+				Recorder: mgr.GetEventRecorderFor("breakglass-controller"), //nolint:staticcheck
+				GVK:      gvk,
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "BreakGlass", "kind", gvk.Kind)
+				os.Exit(1)
+			}
+		}
+	}
+	if orphanSweepInterval > 0 {
+		if err := mgr.Add(&controller.OrphanSweeper{
+			Client:    mgr.GetClient(),
+			Recorder:  mgr.GetEventRecorderFor("orphan-sweeper"), //nolint:staticcheck
+			APIReader: mgr.GetAPIReader(),
+			Interval:  orphanSweepInterval,
+			DryRun:    orphanSweepDryRun,
+		}); err != nil {
+			setupLog.Error(err, "unable to add orphan sweeper")
+			os.Exit(1)
+		}
+	}
 	// nolint:goconst
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
-		if err := webhookv1alpha1.SetupMaintenanceWindowWebhookWithManager(mgr); err != nil {
+		tamper := webhookv1alpha1.TamperProtection{
+			Enabled:     tamperProtection,
+			AdminGroups: splitList(policyAdminGroups),
+			Recorder:    mgr.GetEventRecorderFor("freeze-operator-webhook"), //nolint:staticcheck
+		}
+		if err := webhookv1alpha1.SetupMaintenanceWindowWebhookWithManager(mgr, tamper); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "MaintenanceWindow")
 			os.Exit(1)
 		}
-		if err := webhookv1alpha1.SetupChangeFreezeWebhookWithManager(mgr); err != nil {
+		if err := webhookv1alpha1.SetupChangeFreezeWebhookWithManager(mgr, tamper); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "ChangeFreeze")
 			os.Exit(1)
 		}
-		if err := webhookv1alpha1.SetupFreezeExceptionWebhookWithManager(mgr); err != nil {
+		if err := webhookv1alpha1.SetupFreezeExceptionWebhookWithManager(mgr, exceptionPolicy); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "FreezeException")
 			os.Exit(1)
 		}
+		if err := webhookv1alpha1.SetupFreezeExceptionApprovalWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "FreezeExceptionApproval")
+			os.Exit(1)
+		}
+		if err := webhookv1alpha1.SetupDeferredChangeWebhookWithManager(mgr, os.Getenv("POD_NAMESPACE")); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DeferredChange")
+			os.Exit(1)
+		}
+
+		// Change budgets are counted in Leases in the operator namespace.
+		var budgets *budget.Counter
+		if ns := os.Getenv("POD_NAMESPACE"); ns != "" {
+			budgets = &budget.Counter{Client: mgr.GetClient(), Reader: mgr.GetAPIReader(), Namespace: ns}
+		} else {
+			setupLog.Info("POD_NAMESPACE is not set; requests subject to a rate limit will be rejected")
+		}
 
 		decoder := admission.NewDecoder(mgr.GetScheme())
 		mgr.GetWebhookServer().Register(workloads.WebhookPath, &admission.Webhook{
 			Handler: &workloads.Validator{
-				Client:  mgr.GetClient(),
-				Reader:  mgr.GetAPIReader(),
-				Decoder: decoder,
+				Client:                    mgr.GetClient(),
+				Reader:                    mgr.GetAPIReader(),
+				Decoder:                   decoder,
+				RequireApprovedExceptions: exceptionSeparateApprover,
+				MinApprovals:              minApprovals,
+				BreakGlassGroups:          splitList(breakGlassGroups),
+				Recorder:                  mgr.GetEventRecorderFor("freeze-operator-webhook"), //nolint:staticcheck
+				Budgets:                   budgets,
+				Reports:                   reports,
+			},
+		})
+		mgr.GetWebhookServer().Register(namespaces.WebhookPath, &admission.Webhook{
+			Handler: &namespaces.Validator{
+				Client:                    mgr.GetClient(),
+				Decoder:                   decoder,
+				RequireApprovedExceptions: exceptionSeparateApprover,
+				MinApprovals:              minApprovals,
 			},
 		})
 	}
@@ -248,7 +392,11 @@ func main() {
 	}
 
 	if apiAddr != "0" {
-		var serverOpts []api.ServerOption
+		serverOpts := []api.ServerOption{
+			api.WithRequireApprovedExceptions(exceptionSeparateApprover),
+			api.WithMinApprovals(minApprovals),
+			api.WithWorkloadReader(mgr.GetClient()),
+		}
 		if api.AuthMode(apiAuthMode) == api.AuthModeToken {
 			cs, err := kubernetes.NewForConfig(ctrl.GetConfigOrDie())
 			if err != nil {
@@ -270,3 +418,14 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// splitList parses a comma-separated flag value, dropping empty entries.
+func splitList(v string) []string {
+	var out []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
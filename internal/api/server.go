@@ -23,10 +23,12 @@ import (
 	"net/http"
 	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/inventory"
 	freezemetrics "github.com/jamalshahverdiev/kube-freeze-operator/internal/metrics"
 	"github.com/jamalshahverdiev/kube-freeze-operator/internal/policy"
 )
@@ -50,6 +52,29 @@ type EvaluateResponse struct {
 	FreezeEndTime *string `json:"freezeEndTime,omitempty"`
 	NextAllowed   *string `json:"nextAllowedTime,omitempty"`
 	EvaluatedAt   string  `json:"evaluatedAt"`
+
+	// Upcoming lists policies inside their notice period that will deny this request.
+	Upcoming []UpcomingPolicy `json:"upcoming,omitempty"`
+}
+
+// UpcomingPolicy describes a policy that starts to deny a request soon.
+type UpcomingPolicy struct {
+	Name       string `json:"name"`
+	PolicyKind string `json:"policyKind"`
+	StartTime  string `json:"startTime"`
+	Message    string `json:"message"`
+}
+
+// WorkloadsResponse is the JSON reply from GET /v1/policies/{kind}/{name}/workloads.
+type WorkloadsResponse struct {
+	PolicyKind string `json:"policyKind"`
+	Name       string `json:"name"`
+	Namespaces int    `json:"namespaces"`
+	Total      int    `json:"total"`
+
+	// Workloads lists every workload the policy's target selects, sorted by
+	// namespace, kind and name.
+	Workloads []freezev1alpha1.AffectedWorkload `json:"workloads"`
 }
 
 // Server serves the freeze-operator CI helper API.
@@ -58,11 +83,17 @@ type Server struct {
 	addr     string
 	authMode AuthMode
 	auth     *TokenAuthMiddleware
+
+	requireApprovedExceptions bool
+	minApprovals              int32
+
+	// workloads reads the workloads listed by the inventory endpoint.
+	workloads client.Reader
 }
 
 // NewServer creates a new API server.
 func NewServer(c client.Reader, addr string, opts ...ServerOption) *Server {
-	s := &Server{client: c, addr: addr, authMode: AuthModeNone}
+	s := &Server{client: c, addr: addr, authMode: AuthModeNone, workloads: c}
 	for _, o := range opts {
 		o(s)
 	}
@@ -80,11 +111,36 @@ func WithTokenAuth(auth *TokenAuthMiddleware) ServerOption {
 	}
 }
 
+// WithRequireApprovedExceptions makes evaluations ignore FreezeExceptions without
+// spec.approvedBy, matching the admission webhook configuration.
+func WithRequireApprovedExceptions(require bool) ServerOption {
+	return func(s *Server) {
+		s.requireApprovedExceptions = require
+	}
+}
+
+// WithMinApprovals sets the operator-wide minimum number of approvals every
+// FreezeException needs, matching the admission webhook configuration.
+func WithMinApprovals(n int32) ServerOption {
+	return func(s *Server) {
+		s.minApprovals = n
+	}
+}
+
+// WithWorkloadReader makes the inventory endpoint list workloads through r,
+// typically the manager's cache, instead of the reader used for evaluations.
+func WithWorkloadReader(r client.Reader) ServerOption {
+	return func(s *Server) {
+		s.workloads = r
+	}
+}
+
 // Start runs the HTTP server. It blocks until ctx is cancelled.
 func (s *Server) Start(ctx context.Context) error {
 	mux := http.NewServeMux()
 	mux.HandleFunc("POST /v1/evaluate", s.handleEvaluate)
 	mux.HandleFunc("GET /v1/evaluate", s.handleEvaluateGET)
+	mux.HandleFunc("GET /v1/policies/{kind}/{name}/workloads", s.handleWorkloads)
 	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("ok"))
@@ -168,9 +224,10 @@ func (s *Server) evaluate(w http.ResponseWriter, r *http.Request, req EvaluateRe
 		Namespace: req.Namespace,
 		Kind:      kind,
 		Action:    action,
+		Name:      req.Name,
 	}
 
-	eval := &policy.Evaluator{Client: s.client}
+	eval := &policy.Evaluator{Client: s.client, RequireApprovedExceptions: s.requireApprovedExceptions, MinApprovals: s.minApprovals}
 	dec, err := eval.Evaluate(r.Context(), in)
 	if err != nil {
 		freezemetrics.APIErrors.WithLabelValues("internal").Inc()
@@ -209,6 +266,67 @@ func (s *Server) evaluate(w http.ResponseWriter, r *http.Request, req EvaluateRe
 		}
 	}
 
+	for _, u := range dec.Upcoming {
+		resp.Upcoming = append(resp.Upcoming, UpcomingPolicy{
+			Name:       u.Policy.Name,
+			PolicyKind: string(u.Policy.Kind),
+			StartTime:  u.StartTime.Format(time.RFC3339),
+			Message:    u.Message(now),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) handleWorkloads(w http.ResponseWriter, r *http.Request) {
+	kind, name := r.PathValue("kind"), r.PathValue("name")
+
+	var target *freezev1alpha1.TargetSpec
+	var err error
+	switch policy.PolicyKind(kind) {
+	case policy.PolicyKindChangeFreeze:
+		cf := &freezev1alpha1.ChangeFreeze{}
+		err = s.client.Get(r.Context(), client.ObjectKey{Name: name}, cf)
+		target = &cf.Spec.Target
+	case policy.PolicyKindMaintenanceWindow:
+		mw := &freezev1alpha1.MaintenanceWindow{}
+		err = s.client.Get(r.Context(), client.ObjectKey{Name: name}, mw)
+		target = &mw.Spec.Target
+	default:
+		freezemetrics.APIErrors.WithLabelValues("bad_request").Inc()
+		writeError(w, http.StatusBadRequest, "unsupported policy kind: "+kind+"; valid: ChangeFreeze, MaintenanceWindow")
+		return
+	}
+	if apierrors.IsNotFound(err) {
+		freezemetrics.APIErrors.WithLabelValues("not_found").Inc()
+		writeError(w, http.StatusNotFound, kind+" "+name+" not found")
+		return
+	}
+
+	var inv inventory.Inventory
+	if err == nil {
+		inv, err = inventory.Compute(r.Context(), s.workloads, target)
+	}
+	if err != nil {
+		freezemetrics.APIErrors.WithLabelValues("internal").Inc()
+		log.Error(err, "inventory failed", "policyKind", kind, "name", name)
+		writeError(w, http.StatusInternalServerError, "inventory error: "+err.Error())
+		return
+	}
+
+	resp := WorkloadsResponse{
+		PolicyKind: kind,
+		Name:       name,
+		Namespaces: inv.Namespaces,
+		Total:      len(inv.Workloads),
+		Workloads:  inv.Workloads,
+	}
+	if resp.Workloads == nil {
+		resp.Workloads = []freezev1alpha1.AffectedWorkload{}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(resp)
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -39,6 +40,9 @@ func newTestServer(t *testing.T, objs ...runtime.Object) *Server {
 	if err := corev1.AddToScheme(scheme); err != nil {
 		t.Fatal(err)
 	}
+	if err := appsv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
 	if err := freezev1alpha1.AddToScheme(scheme); err != nil {
 		t.Fatal(err)
 	}
@@ -255,7 +259,7 @@ func TestEvaluate_AllowWithException(t *testing.T) {
 		Spec: freezev1alpha1.FreezeExceptionSpec{
 			ActiveFrom: metav1.NewTime(start),
 			ActiveTo:   metav1.NewTime(end),
-			Target: freezev1alpha1.TargetSpec{
+			Target: &freezev1alpha1.TargetSpec{
 				NamespaceSelector: &metav1.LabelSelector{
 					MatchLabels: map[string]string{"env": "prod"},
 				},
@@ -279,3 +283,93 @@ func TestEvaluate_AllowWithException(t *testing.T) {
 	g.Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
 	g.Expect(resp.Allow).To(BeTrue())
 }
+
+func TestEvaluate_UpcomingFreeze(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Now().UTC()
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod", Labels: map[string]string{"env": "prod"}}}
+	cf := &freezev1alpha1.ChangeFreeze{
+		ObjectMeta: metav1.ObjectMeta{Name: "holiday-2026"},
+		Spec: freezev1alpha1.ChangeFreezeSpec{
+			StartTime:    metav1.NewTime(now.Add(2 * time.Hour)),
+			EndTime:      metav1.NewTime(now.Add(48 * time.Hour)),
+			NoticePeriod: &metav1.Duration{Duration: 24 * time.Hour},
+			Target: freezev1alpha1.TargetSpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				Kinds:             []freezev1alpha1.TargetKind{freezev1alpha1.TargetKindDeployment},
+			},
+			Rules: freezev1alpha1.PolicyRulesSpec{Deny: []freezev1alpha1.Action{freezev1alpha1.ActionRollout}},
+		},
+	}
+
+	srv := newTestServer(t, ns, cf)
+
+	w := postEvaluate(srv, EvaluateRequest{Namespace: "prod", Kind: "Deployment", Action: "ROLL_OUT"})
+	g.Expect(w.Code).To(Equal(http.StatusOK))
+
+	var resp EvaluateResponse
+	g.Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+	g.Expect(resp.Allow).To(BeTrue())
+	g.Expect(resp.Upcoming).To(HaveLen(1))
+	g.Expect(resp.Upcoming[0].Name).To(Equal("holiday-2026"))
+	g.Expect(resp.Upcoming[0].PolicyKind).To(Equal("ChangeFreeze"))
+	g.Expect(resp.Upcoming[0].Message).To(HavePrefix("ChangeFreeze holiday-2026 starts in "))
+}
+
+func getWorkloads(srv *Server, kind, name string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/v1/policies/"+kind+"/"+name+"/workloads", nil)
+	req.SetPathValue("kind", kind)
+	req.SetPathValue("name", name)
+	w := httptest.NewRecorder()
+	srv.handleWorkloads(w, req)
+	return w
+}
+
+func TestWorkloads_ListsMatchingWorkloads(t *testing.T) {
+	g := NewWithT(t)
+
+	mw := &freezev1alpha1.MaintenanceWindow{
+		ObjectMeta: metav1.ObjectMeta{Name: "weekdays"},
+		Spec: freezev1alpha1.MaintenanceWindowSpec{
+			Target: freezev1alpha1.TargetSpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				Kinds:             []freezev1alpha1.TargetKind{freezev1alpha1.TargetKindDeployment},
+			},
+		},
+	}
+	srv := newTestServer(t, mw,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod", Labels: map[string]string{"env": "prod"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev", Labels: map[string]string{"env": "dev"}}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "prod"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "dev"}},
+	)
+
+	w := getWorkloads(srv, "MaintenanceWindow", "weekdays")
+	g.Expect(w.Code).To(Equal(http.StatusOK))
+
+	var resp WorkloadsResponse
+	g.Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+	g.Expect(resp.Namespaces).To(Equal(1))
+	g.Expect(resp.Total).To(Equal(1))
+	g.Expect(resp.Workloads).To(ConsistOf(freezev1alpha1.AffectedWorkload{
+		Kind: freezev1alpha1.TargetKindDeployment, Namespace: "prod", Name: "api",
+	}))
+}
+
+func TestWorkloads_NotFound(t *testing.T) {
+	g := NewWithT(t)
+	srv := newTestServer(t)
+
+	w := getWorkloads(srv, "ChangeFreeze", "missing")
+	g.Expect(w.Code).To(Equal(http.StatusNotFound))
+}
+
+func TestWorkloads_BadKind(t *testing.T) {
+	g := NewWithT(t)
+	srv := newTestServer(t)
+
+	w := getWorkloads(srv, "FreezeException", "x")
+	g.Expect(w.Code).To(Equal(http.StatusBadRequest))
+	g.Expect(w.Body.String()).To(ContainSubstring("unsupported policy kind"))
+}
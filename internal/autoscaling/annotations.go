@@ -0,0 +1,42 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaling
+
+// Annotations written by the operator on managed autoscalers.
+const (
+	// AnnotationManaged marks an object as currently managed by the freeze-operator.
+	AnnotationManaged = "freeze-operator.io/managed"
+
+	// AnnotationOriginalMinReplicas stores the original HPA spec.minReplicas before the
+	// operator pinned it. The value is empty if minReplicas was unset.
+	AnnotationOriginalMinReplicas = "freeze-operator.io/original-min-replicas"
+
+	// AnnotationOriginalMaxReplicas stores the original HPA spec.maxReplicas before the
+	// operator pinned it.
+	AnnotationOriginalMaxReplicas = "freeze-operator.io/original-max-replicas"
+
+	// AnnotationOriginalKEDAPaused stores the original value of the KEDA pause annotation.
+	// It is absent if the ScaledObject had no pause annotation.
+	AnnotationOriginalKEDAPaused = "freeze-operator.io/original-keda-paused"
+
+	// AnnotationKEDAPaused is the annotation KEDA reads to pause a ScaledObject at its
+	// current replica count.
+	AnnotationKEDAPaused = "autoscaling.keda.sh/paused"
+
+	// annotationManagedValue is the value written to AnnotationManaged.
+	annotationManagedValue = "true"
+)
@@ -0,0 +1,225 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package autoscaling pins autoscalers to the current capacity of the workloads
+// they scale while a freeze is active, so that capacity stays fixed.
+//
+// HorizontalPodAutoscalers are pinned by setting minReplicas and maxReplicas to
+// the current replica count. KEDA ScaledObjects are paused with the
+// autoscaling.keda.sh/paused annotation; they are accessed through the
+// unstructured client, and if the KEDA CRDs are absent they are skipped.
+//
+// Managed objects are annotated with:
+//   - freeze-operator.io/managed = "true"
+//   - freeze-operator.io/held-by = <Kind/name>,... (see internal/hold)
+//   - freeze-operator.io/original-min-replicas, original-max-replicas (HPA only)
+//   - freeze-operator.io/original-keda-paused (ScaledObject only)
+//
+// Several policies may pin the same autoscaler; it is restored when the last one
+// releases it. The operator will ONLY restore state it originally changed.
+package autoscaling
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/hold"
+)
+
+// Reconciler pins/restores autoscalers according to active freeze policies.
+type Reconciler struct {
+	// Client is the controller-runtime client used for all K8s operations.
+	Client client.Client
+}
+
+// Result holds the output of a single Reconcile call.
+type Result struct {
+	// PinnedCount is the number of autoscalers currently pinned by the policy.
+	PinnedCount int
+}
+
+// Reconcile pins (active=true) or releases (active=false) the HPAs and KEDA
+// ScaledObjects that scale workloads selected by target. Autoscalers the policy
+// pins but no longer selects are released either way.
+//
+// Parameters:
+//   - target the TargetSpec of the policy; only Deployment and StatefulSet kinds are autoscaled
+//   - holder the held-by reference of the policy, see hold.Policy
+//   - active whether the policy currently pins autoscalers
+func (r *Reconciler) Reconcile(
+	ctx context.Context,
+	target *freezev1alpha1.TargetSpec,
+	holder string,
+	active bool,
+) (Result, error) {
+	var result Result
+	if target == nil {
+		return result, nil
+	}
+
+	namespaces, err := listMatchingNamespaces(ctx, r.Client, target.NamespaceSelector)
+	if err != nil {
+		return result, err
+	}
+
+	var errs []error
+
+	n, err := reconcileHPAs(ctx, r.Client, target, namespaces, holder, active)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("hpa: %w", err))
+	}
+	result.PinnedCount += n
+
+	n, err = reconcileScaledObjects(ctx, r.Client, target, namespaces, holder, active)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("keda: %w", err))
+	}
+	result.PinnedCount += n
+
+	return result, errors.Join(errs...)
+}
+
+// ListManaged returns every HPA and KEDA ScaledObject the operator currently pins,
+// whichever policies hold it. ScaledObjects are skipped if the KEDA CRDs are not
+// installed.
+func ListManaged(ctx context.Context, c client.Client) ([]client.Object, error) {
+	var out []client.Object
+
+	hpas := &autoscalingv2.HorizontalPodAutoscalerList{}
+	if err := c.List(ctx, hpas); err != nil {
+		return nil, fmt.Errorf("list HorizontalPodAutoscalers: %w", err)
+	}
+	for i := range hpas.Items {
+		if len(hold.Holders(hpas.Items[i].Annotations)) > 0 {
+			out = append(out, &hpas.Items[i])
+		}
+	}
+
+	scaledObjects := &unstructured.UnstructuredList{}
+	scaledObjects.SetGroupVersionKind(scaledObjectGVK.GroupVersion().WithKind(scaledObjectGVK.Kind + "List"))
+	if err := c.List(ctx, scaledObjects); err != nil {
+		if isNoMatchError(err) {
+			return out, nil
+		}
+		return out, fmt.Errorf("list ScaledObjects: %w", err)
+	}
+	for i := range scaledObjects.Items {
+		if len(hold.Holders(scaledObjects.Items[i].GetAnnotations())) > 0 {
+			out = append(out, &scaledObjects.Items[i])
+		}
+	}
+	return out, nil
+}
+
+// Release releases the hold of holder on an autoscaler returned by ListManaged,
+// restoring its original state if holder was the last policy holding it.
+func Release(ctx context.Context, c client.Client, obj client.Object, holder string) error {
+	var err error
+	switch o := obj.(type) {
+	case *autoscalingv2.HorizontalPodAutoscaler:
+		_, err = reconcileHPA(ctx, c, o, holder, false)
+	case *unstructured.Unstructured:
+		_, err = reconcileScaledObject(ctx, c, o, holder, false)
+	default:
+		err = fmt.Errorf("unsupported autoscaler %T", obj)
+	}
+	return err
+}
+
+// ---------------------------------------------------------------------------
+// helpers shared between hpa.go and keda.go
+// ---------------------------------------------------------------------------
+
+// listMatchingNamespaces returns the names of all namespaces that match the
+// given LabelSelector. If selector is nil, all namespaces are returned.
+func listMatchingNamespaces(ctx context.Context, c client.Client, selector *metav1.LabelSelector) ([]string, error) {
+	nsList := &corev1.NamespaceList{}
+
+	var listOpts []client.ListOption
+	if selector != nil {
+		sel, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespaceSelector: %w", err)
+		}
+		listOpts = append(listOpts, client.MatchingLabelsSelector{Selector: sel})
+	}
+
+	if err := c.List(ctx, nsList, listOpts...); err != nil {
+		return nil, fmt.Errorf("list namespaces: %w", err)
+	}
+
+	names := make([]string, 0, len(nsList.Items))
+	for _, ns := range nsList.Items {
+		names = append(names, ns.Name)
+	}
+	return names, nil
+}
+
+// scalesTarget reports whether the workload an autoscaler scales is selected by
+// target: its kind must be one of the target kinds and its labels must match the
+// objectSelector. A workload that does not exist is not selected.
+func scalesTarget(
+	ctx context.Context,
+	c client.Client,
+	target *freezev1alpha1.TargetSpec,
+	namespace, apiVersion, kind, name string,
+) (bool, error) {
+	if !slices.Contains(target.Kinds, freezev1alpha1.TargetKind(kind)) {
+		return false, nil
+	}
+	if target.ObjectSelector == nil {
+		return true, nil
+	}
+
+	sel, err := metav1.LabelSelectorAsSelector(target.ObjectSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid objectSelector: %w", err)
+	}
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return false, fmt.Errorf("parse scaleTargetRef apiVersion %q: %w", apiVersion, err)
+	}
+
+	obj := &metav1.PartialObjectMetadata{}
+	obj.SetGroupVersionKind(gv.WithKind(kind))
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("get %s %s/%s: %w", kind, namespace, name, err)
+	}
+	return sel.Matches(labels.Set(obj.GetLabels())), nil
+}
+
+// isNoMatchError returns true if the error indicates that the requested
+// API resource (CRD) is not registered in the cluster — i.e. KEDA is not
+// installed.
+func isNoMatchError(err error) bool {
+	return meta.IsNoMatchError(err)
+}
@@ -0,0 +1,452 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaling
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/hold"
+)
+
+// ---------------------------------------------------------------------------
+// helpers
+// ---------------------------------------------------------------------------
+
+const (
+	testNS     = "prod"
+	testPolicy = "ChangeFreeze/holiday"
+)
+
+func newScheme(withKEDA bool) *runtime.Scheme {
+	s := runtime.NewScheme()
+	_ = corev1.AddToScheme(s)
+	_ = appsv1.AddToScheme(s)
+	_ = autoscalingv2.AddToScheme(s)
+	if withKEDA {
+		s.AddKnownTypeWithName(scaledObjectGVK, &unstructured.Unstructured{})
+		s.AddKnownTypeWithName(scaledObjectGVK.GroupVersion().WithKind(scaledObjectGVK.Kind+"List"), &unstructured.UnstructuredList{})
+	}
+	return s
+}
+
+func buildFakeClient(withKEDA bool, objs ...client.Object) client.Client {
+	return fake.NewClientBuilder().
+		WithScheme(newScheme(withKEDA)).
+		WithObjects(objs...).
+		Build()
+}
+
+func newNamespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"env": name}}}
+}
+
+func newDeployment(name string, labels map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNS, Labels: labels}}
+}
+
+func newHPA(name, target string, minReplicas *int32, maxReplicas, current int32) *autoscalingv2.HorizontalPodAutoscaler {
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNS},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: target},
+			MinReplicas:    minReplicas,
+			MaxReplicas:    maxReplicas,
+		},
+		Status: autoscalingv2.HorizontalPodAutoscalerStatus{CurrentReplicas: current},
+	}
+}
+
+func newScaledObject(name, target string, annotations map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(scaledObjectGVK)
+	obj.SetName(name)
+	obj.SetNamespace(testNS)
+	obj.SetAnnotations(annotations)
+	obj.Object["spec"] = map[string]any{
+		"scaleTargetRef": map[string]any{"name": target},
+	}
+	return obj
+}
+
+func newTarget(objectSelector *metav1.LabelSelector) *freezev1alpha1.TargetSpec {
+	return &freezev1alpha1.TargetSpec{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": testNS}},
+		ObjectSelector:    objectSelector,
+		Kinds:             []freezev1alpha1.TargetKind{freezev1alpha1.TargetKindDeployment},
+	}
+}
+
+func getHPA(ctx context.Context, c client.Client, name string) *autoscalingv2.HorizontalPodAutoscaler {
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	_ = c.Get(ctx, client.ObjectKey{Name: name, Namespace: testNS}, hpa)
+	return hpa
+}
+
+func getScaledObject(ctx context.Context, c client.Client, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(scaledObjectGVK)
+	_ = c.Get(ctx, client.ObjectKey{Name: name, Namespace: testNS}, obj)
+	return obj
+}
+
+func int32Ptr(v int32) *int32 { return &v }
+
+// ---------------------------------------------------------------------------
+// HPA tests
+// ---------------------------------------------------------------------------
+
+func TestReconcileHPA_PinAndRestore(t *testing.T) {
+	ctx := context.Background()
+	c := buildFakeClient(false,
+		newNamespace(testNS),
+		newDeployment("web", nil),
+		newHPA("web", "web", int32Ptr(2), 10, 4),
+	)
+	r := &Reconciler{Client: c}
+
+	res, err := r.Reconcile(ctx, newTarget(nil), testPolicy, true)
+	if err != nil {
+		t.Fatalf("pin: %v", err)
+	}
+	if res.PinnedCount != 1 {
+		t.Errorf("expected 1 pinned autoscaler, got %d", res.PinnedCount)
+	}
+	hpa := getHPA(ctx, c, "web")
+	if hpa.Spec.MinReplicas == nil || *hpa.Spec.MinReplicas != 4 || hpa.Spec.MaxReplicas != 4 {
+		t.Errorf("expected HPA pinned to 4 replicas, got min=%v max=%d", hpa.Spec.MinReplicas, hpa.Spec.MaxReplicas)
+	}
+	if hpa.Annotations[AnnotationOriginalMinReplicas] != "2" || hpa.Annotations[AnnotationOriginalMaxReplicas] != "10" {
+		t.Errorf("unexpected original annotations: %v", hpa.Annotations)
+	}
+
+	// Pinning again is a no-op, even if the HPA observed other replicas meanwhile.
+	hpa.Status.CurrentReplicas = 6
+	if err := c.Update(ctx, hpa); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, newTarget(nil), testPolicy, true); err != nil {
+		t.Fatalf("pin again: %v", err)
+	}
+	if hpa := getHPA(ctx, c, "web"); hpa.Spec.MaxReplicas != 4 || hpa.Annotations[AnnotationOriginalMaxReplicas] != "10" {
+		t.Errorf("expected pinned HPA to be left alone, got max=%d annotations=%v", hpa.Spec.MaxReplicas, hpa.Annotations)
+	}
+
+	res, err = r.Reconcile(ctx, newTarget(nil), testPolicy, false)
+	if err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if res.PinnedCount != 0 {
+		t.Errorf("expected 0 pinned autoscalers, got %d", res.PinnedCount)
+	}
+	hpa = getHPA(ctx, c, "web")
+	if hpa.Spec.MinReplicas == nil || *hpa.Spec.MinReplicas != 2 || hpa.Spec.MaxReplicas != 10 {
+		t.Errorf("expected original bounds 2..10, got min=%v max=%d", hpa.Spec.MinReplicas, hpa.Spec.MaxReplicas)
+	}
+	for _, a := range []string{AnnotationManaged, hold.AnnotationHeldBy, AnnotationOriginalMinReplicas, AnnotationOriginalMaxReplicas} {
+		if _, ok := hpa.Annotations[a]; ok {
+			t.Errorf("annotation %s should be removed on restore", a)
+		}
+	}
+}
+
+func TestReconcileHPA_UnsetMinReplicasRestored(t *testing.T) {
+	ctx := context.Background()
+	c := buildFakeClient(false, newNamespace(testNS), newHPA("web", "web", nil, 5, 3))
+	r := &Reconciler{Client: c}
+
+	if _, err := r.Reconcile(ctx, newTarget(nil), testPolicy, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, newTarget(nil), testPolicy, false); err != nil {
+		t.Fatal(err)
+	}
+	if hpa := getHPA(ctx, c, "web"); hpa.Spec.MinReplicas != nil || hpa.Spec.MaxReplicas != 5 {
+		t.Errorf("expected minReplicas unset and max 5, got min=%v max=%d", hpa.Spec.MinReplicas, hpa.Spec.MaxReplicas)
+	}
+}
+
+func TestReconcileHPA_ObjectSelectorFiltering(t *testing.T) {
+	ctx := context.Background()
+	c := buildFakeClient(false,
+		newNamespace(testNS),
+		newDeployment("web", map[string]string{"tier": "frontend"}),
+		newDeployment("worker", map[string]string{"tier": "backend"}),
+		newHPA("web", "web", int32Ptr(1), 10, 3),
+		newHPA("worker", "worker", int32Ptr(1), 10, 3),
+		newHPA("missing", "missing", int32Ptr(1), 10, 3),
+	)
+	r := &Reconciler{Client: c}
+
+	sel := &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "frontend"}}
+	res, err := r.Reconcile(ctx, newTarget(sel), testPolicy, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.PinnedCount != 1 {
+		t.Errorf("expected 1 pinned autoscaler, got %d", res.PinnedCount)
+	}
+	if getHPA(ctx, c, "web").Spec.MaxReplicas != 3 {
+		t.Error("HPA of the selected Deployment should be pinned")
+	}
+	for _, name := range []string{"worker", "missing"} {
+		if getHPA(ctx, c, name).Spec.MaxReplicas != 10 {
+			t.Errorf("HPA %s should not be pinned", name)
+		}
+	}
+}
+
+func TestReconcileHPA_KindNotTargeted(t *testing.T) {
+	ctx := context.Background()
+	hpa := newHPA("db", "db", int32Ptr(1), 10, 3)
+	hpa.Spec.ScaleTargetRef.Kind = "StatefulSet"
+	c := buildFakeClient(false, newNamespace(testNS), hpa)
+
+	if _, err := (&Reconciler{Client: c}).Reconcile(ctx, newTarget(nil), testPolicy, true); err != nil {
+		t.Fatal(err)
+	}
+	if getHPA(ctx, c, "db").Spec.MaxReplicas != 10 {
+		t.Error("HPA of a StatefulSet should not be pinned when only Deployments are targeted")
+	}
+}
+
+func TestReconcileHPA_OverlappingPolicies(t *testing.T) {
+	ctx := context.Background()
+	c := buildFakeClient(false, newNamespace(testNS), newHPA("web", "web", int32Ptr(2), 10, 4))
+	r := &Reconciler{Client: c}
+	// A MaintenanceWindow may share the name of the ChangeFreeze.
+	other := "MaintenanceWindow/holiday"
+
+	for _, holder := range []string{testPolicy, other} {
+		if _, err := r.Reconcile(ctx, newTarget(nil), holder, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := getHPA(ctx, c, "web").Annotations[hold.AnnotationHeldBy]; got != testPolicy+","+other {
+		t.Errorf("expected HPA held by both policies, got %q", got)
+	}
+
+	res, err := r.Reconcile(ctx, newTarget(nil), testPolicy, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.PinnedCount != 0 {
+		t.Errorf("expected 0 autoscalers pinned by %s, got %d", testPolicy, res.PinnedCount)
+	}
+	hpa := getHPA(ctx, c, "web")
+	if hpa.Spec.MaxReplicas != 4 || hpa.Annotations[hold.AnnotationHeldBy] != other {
+		t.Errorf("expected HPA still pinned by %s, got max=%d annotations=%v", other, hpa.Spec.MaxReplicas, hpa.Annotations)
+	}
+
+	if _, err := r.Reconcile(ctx, newTarget(nil), other, false); err != nil {
+		t.Fatal(err)
+	}
+	if hpa := getHPA(ctx, c, "web"); hpa.Spec.MaxReplicas != 10 || len(hpa.Annotations) != 0 {
+		t.Errorf("expected HPA restored by the last policy, got max=%d annotations=%v", hpa.Spec.MaxReplicas, hpa.Annotations)
+	}
+}
+
+func TestReconcileHPA_LegacyOwnerReleased(t *testing.T) {
+	ctx := context.Background()
+	hpa := newHPA("web", "web", int32Ptr(2), 2, 2)
+	hpa.Annotations = map[string]string{
+		AnnotationManaged:                      annotationManagedValue,
+		"freeze-operator.io/managed-by-policy": "holiday",
+		AnnotationOriginalMinReplicas:          "1",
+		AnnotationOriginalMaxReplicas:          "10",
+	}
+	c := buildFakeClient(false, newNamespace(testNS), hpa)
+
+	if _, err := (&Reconciler{Client: c}).Reconcile(ctx, newTarget(nil), testPolicy, false); err != nil {
+		t.Fatal(err)
+	}
+	got := getHPA(ctx, c, "web")
+	if got.Spec.MaxReplicas != 10 || len(got.Annotations) != 0 {
+		t.Errorf("expected HPA pinned by an older operator restored, got max=%d annotations=%v", got.Spec.MaxReplicas, got.Annotations)
+	}
+}
+
+func TestReconcileHPA_ReleasesUnselected(t *testing.T) {
+	ctx := context.Background()
+	c := buildFakeClient(false,
+		newNamespace(testNS),
+		newDeployment("web", map[string]string{"tier": "frontend"}),
+		newHPA("web", "web", int32Ptr(1), 10, 3),
+	)
+	r := &Reconciler{Client: c}
+
+	sel := &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "frontend"}}
+	if _, err := r.Reconcile(ctx, newTarget(sel), testPolicy, true); err != nil {
+		t.Fatal(err)
+	}
+	// The policy no longer selects the Deployment.
+	sel = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "backend"}}
+	if _, err := r.Reconcile(ctx, newTarget(sel), testPolicy, true); err != nil {
+		t.Fatal(err)
+	}
+	if hpa := getHPA(ctx, c, "web"); hpa.Spec.MaxReplicas != 10 || hpa.Annotations[AnnotationManaged] != "" {
+		t.Errorf("expected HPA of an unselected Deployment released, got max=%d annotations=%v", hpa.Spec.MaxReplicas, hpa.Annotations)
+	}
+}
+
+func TestReconcileHPA_NoCurrentReplicas_NotPinned(t *testing.T) {
+	ctx := context.Background()
+	c := buildFakeClient(false, newNamespace(testNS), newHPA("web", "web", int32Ptr(1), 10, 0))
+
+	if _, err := (&Reconciler{Client: c}).Reconcile(ctx, newTarget(nil), testPolicy, true); err != nil {
+		t.Fatal(err)
+	}
+	if hpa := getHPA(ctx, c, "web"); hpa.Spec.MaxReplicas != 10 || hpa.Annotations[AnnotationManaged] != "" {
+		t.Error("HPA without current replicas should not be pinned")
+	}
+}
+
+func TestReconcileHPA_OwnedByScaledObject_Skipped(t *testing.T) {
+	ctx := context.Background()
+	hpa := newHPA("keda-hpa-web", "web", int32Ptr(1), 10, 3)
+	hpa.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion: "keda.sh/v1alpha1", Kind: "ScaledObject", Name: "web", UID: "uid",
+	}}
+	c := buildFakeClient(false, newNamespace(testNS), hpa)
+
+	if _, err := (&Reconciler{Client: c}).Reconcile(ctx, newTarget(nil), testPolicy, true); err != nil {
+		t.Fatal(err)
+	}
+	if getHPA(ctx, c, "keda-hpa-web").Spec.MaxReplicas != 10 {
+		t.Error("HPA owned by a ScaledObject should not be pinned")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// KEDA tests
+// ---------------------------------------------------------------------------
+
+func TestReconcileScaledObject_PauseAndRestore(t *testing.T) {
+	ctx := context.Background()
+	c := buildFakeClient(true, newNamespace(testNS), newScaledObject("web", "web", nil))
+	r := &Reconciler{Client: c}
+
+	res, err := r.Reconcile(ctx, newTarget(nil), testPolicy, true)
+	if err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+	if res.PinnedCount != 1 {
+		t.Errorf("expected 1 pinned autoscaler, got %d", res.PinnedCount)
+	}
+	ann := getScaledObject(ctx, c, "web").GetAnnotations()
+	if ann[AnnotationKEDAPaused] != "true" || ann[hold.AnnotationHeldBy] != testPolicy {
+		t.Errorf("expected ScaledObject paused by %s, got %v", testPolicy, ann)
+	}
+	if _, ok := ann[AnnotationOriginalKEDAPaused]; ok {
+		t.Error("no original pause annotation should be recorded when there was none")
+	}
+
+	if _, err := r.Reconcile(ctx, newTarget(nil), testPolicy, false); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if ann := getScaledObject(ctx, c, "web").GetAnnotations(); len(ann) != 0 {
+		t.Errorf("expected all annotations removed on restore, got %v", ann)
+	}
+}
+
+func TestReconcileScaledObject_AlreadyPaused_PreservesOriginal(t *testing.T) {
+	ctx := context.Background()
+	c := buildFakeClient(true, newNamespace(testNS),
+		newScaledObject("web", "web", map[string]string{AnnotationKEDAPaused: "false"}))
+	r := &Reconciler{Client: c}
+
+	if _, err := r.Reconcile(ctx, newTarget(nil), testPolicy, true); err != nil {
+		t.Fatal(err)
+	}
+	if got := getScaledObject(ctx, c, "web").GetAnnotations()[AnnotationOriginalKEDAPaused]; got != "false" {
+		t.Errorf("expected original pause annotation %q, got %q", "false", got)
+	}
+	if _, err := r.Reconcile(ctx, newTarget(nil), testPolicy, false); err != nil {
+		t.Fatal(err)
+	}
+	if got := getScaledObject(ctx, c, "web").GetAnnotations()[AnnotationKEDAPaused]; got != "false" {
+		t.Errorf("expected pause annotation restored to %q, got %q", "false", got)
+	}
+}
+
+func TestReconcileScaledObject_OverlappingPolicies(t *testing.T) {
+	ctx := context.Background()
+	c := buildFakeClient(true, newNamespace(testNS), newScaledObject("web", "web", nil))
+	r := &Reconciler{Client: c}
+	other := "MaintenanceWindow/holiday"
+
+	for _, holder := range []string{testPolicy, other} {
+		if _, err := r.Reconcile(ctx, newTarget(nil), holder, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := r.Reconcile(ctx, newTarget(nil), testPolicy, false); err != nil {
+		t.Fatal(err)
+	}
+	if ann := getScaledObject(ctx, c, "web").GetAnnotations(); ann[AnnotationKEDAPaused] != "true" || ann[hold.AnnotationHeldBy] != other {
+		t.Errorf("expected ScaledObject still paused by %s, got %v", other, ann)
+	}
+	if _, err := r.Reconcile(ctx, newTarget(nil), other, false); err != nil {
+		t.Fatal(err)
+	}
+	if ann := getScaledObject(ctx, c, "web").GetAnnotations(); len(ann) != 0 {
+		t.Errorf("expected ScaledObject restored by the last policy, got %v", ann)
+	}
+}
+
+func TestReconcileScaledObject_CRDNotInstalled(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().
+		WithScheme(newScheme(false)).
+		WithObjects(newNamespace(testNS)).
+		WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				if u, ok := list.(*unstructured.UnstructuredList); ok && u.GroupVersionKind().Group == scaledObjectGVK.Group {
+					return &meta.NoKindMatchError{GroupKind: scaledObjectGVK.GroupKind(), SearchedVersions: []string{scaledObjectGVK.Version}}
+				}
+				return c.List(ctx, list, opts...)
+			},
+		}).
+		Build()
+
+	res, err := (&Reconciler{Client: c}).Reconcile(ctx, newTarget(nil), testPolicy, true)
+	if err != nil {
+		t.Fatalf("expected no error without KEDA CRDs, got %v", err)
+	}
+	if res.PinnedCount != 0 {
+		t.Errorf("expected 0 pinned autoscalers, got %d", res.PinnedCount)
+	}
+}
+
+func TestReconciler_NilTarget(t *testing.T) {
+	res, err := (&Reconciler{Client: buildFakeClient(false)}).Reconcile(context.Background(), nil, testPolicy, true)
+	if err != nil || res.PinnedCount != 0 {
+		t.Errorf("expected no-op for nil target, got %+v, %v", res, err)
+	}
+}
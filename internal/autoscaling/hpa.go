@@ -0,0 +1,178 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaling
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/hold"
+)
+
+// reconcileHPAs pins the HorizontalPodAutoscalers that scale a targeted workload in
+// namespaces on behalf of holder, or releases them. HPAs holder pins but no longer
+// selects are released too.
+//
+// Returns the number of HPAs currently pinned by holder.
+func reconcileHPAs(
+	ctx context.Context,
+	c client.Client,
+	target *freezev1alpha1.TargetSpec,
+	namespaces []string,
+	holder string,
+	active bool,
+) (int, error) {
+	logger := log.FromContext(ctx).WithName("autoscaling.hpa")
+
+	list := &autoscalingv2.HorizontalPodAutoscalerList{}
+	if err := c.List(ctx, list); err != nil {
+		return 0, fmt.Errorf("list HorizontalPodAutoscalers: %w", err)
+	}
+
+	total := 0
+	for i := range list.Items {
+		hpa := &list.Items[i]
+		if ownedByScaledObject(hpa) {
+			// KEDA owns this HPA and would revert any change; the ScaledObject is paused instead.
+			continue
+		}
+		selected := false
+		if slices.Contains(namespaces, hpa.Namespace) {
+			ref := hpa.Spec.ScaleTargetRef
+			var err error
+			selected, err = scalesTarget(ctx, c, target, hpa.Namespace, ref.APIVersion, ref.Kind, ref.Name)
+			if err != nil {
+				logger.Error(err, "failed to resolve HPA scale target", "name", hpa.Name, "namespace", hpa.Namespace)
+				continue
+			}
+		}
+		if !selected && !hold.HeldBy(hpa.Annotations, holder) {
+			continue
+		}
+		pinned, err := reconcileHPA(ctx, c, hpa, holder, active && selected)
+		if err != nil {
+			logger.Error(err, "failed to reconcile HPA", "name", hpa.Name, "namespace", hpa.Namespace)
+			continue
+		}
+		if pinned {
+			total++
+		}
+	}
+
+	return total, nil
+}
+
+// reconcileHPA pins a single HPA to its current replica count on behalf of holder,
+// or releases holder's hold. The first holder saves the original bounds and the
+// last one restores them. Returns true if holder pins the HPA.
+func reconcileHPA(
+	ctx context.Context,
+	c client.Client,
+	hpa *autoscalingv2.HorizontalPodAutoscaler,
+	holder string,
+	active bool,
+) (bool, error) {
+	annotations := hpa.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	held := hold.HeldBy(annotations, holder)
+
+	switch {
+	case active:
+		if !held && len(hold.Holders(annotations)) == 0 && hpa.Status.CurrentReplicas < 1 {
+			// Nothing to pin to yet; try again on the next reconcile.
+			log.FromContext(ctx).V(1).Info("HPA has no current replicas, not pinning",
+				"name", hpa.Name, "namespace", hpa.Namespace)
+			return false, nil
+		}
+		first, changed := hold.Add(annotations, holder)
+		if !changed {
+			// A manual change of the bounds while pinned is left alone.
+			return true, nil
+		}
+		if first {
+			// Capture the current bounds.
+			original := ""
+			if hpa.Spec.MinReplicas != nil {
+				original = strconv.Itoa(int(*hpa.Spec.MinReplicas))
+			}
+			annotations[AnnotationOriginalMinReplicas] = original
+			annotations[AnnotationOriginalMaxReplicas] = strconv.Itoa(int(hpa.Spec.MaxReplicas))
+			annotations[AnnotationManaged] = annotationManagedValue
+
+			replicas := hpa.Status.CurrentReplicas
+			hpa.Spec.MinReplicas = &replicas
+			hpa.Spec.MaxReplicas = replicas
+		}
+		hpa.SetAnnotations(annotations)
+
+		if err := c.Update(ctx, hpa); err != nil {
+			return false, fmt.Errorf("pin HorizontalPodAutoscaler %s/%s: %w", hpa.Namespace, hpa.Name, err)
+		}
+		if first {
+			log.FromContext(ctx).Info("HPA pinned",
+				"name", hpa.Name, "namespace", hpa.Namespace, "replicas", hpa.Spec.MaxReplicas, "policy", holder)
+		}
+		return true, nil
+
+	case held:
+		if _, last := hold.Remove(annotations, holder); last {
+			// Restore the original bounds.
+			if v, err := strconv.ParseInt(annotations[AnnotationOriginalMinReplicas], 10, 32); err == nil {
+				minReplicas := int32(v)
+				hpa.Spec.MinReplicas = &minReplicas
+			} else {
+				hpa.Spec.MinReplicas = nil
+			}
+			if v, err := strconv.ParseInt(annotations[AnnotationOriginalMaxReplicas], 10, 32); err == nil {
+				hpa.Spec.MaxReplicas = int32(v)
+			}
+			delete(annotations, AnnotationManaged)
+			delete(annotations, AnnotationOriginalMinReplicas)
+			delete(annotations, AnnotationOriginalMaxReplicas)
+		}
+		hpa.SetAnnotations(annotations)
+
+		if err := c.Update(ctx, hpa); err != nil {
+			return false, fmt.Errorf("restore HorizontalPodAutoscaler %s/%s: %w", hpa.Namespace, hpa.Name, err)
+		}
+		log.FromContext(ctx).Info("HPA released",
+			"name", hpa.Name, "namespace", hpa.Namespace, "policy", holder)
+		return false, nil
+
+	default:
+		return false, nil
+	}
+}
+
+// ownedByScaledObject reports whether hpa was created by a KEDA ScaledObject.
+func ownedByScaledObject(hpa *autoscalingv2.HorizontalPodAutoscaler) bool {
+	for _, ref := range hpa.OwnerReferences {
+		if ref.Kind == scaledObjectGVK.Kind && ref.APIVersion == scaledObjectGVK.GroupVersion().String() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaling
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/hold"
+)
+
+// scaledObjectGVK is the GroupVersionKind for KEDA ScaledObject resources.
+var scaledObjectGVK = schema.GroupVersionKind{
+	Group:   "keda.sh",
+	Version: "v1alpha1",
+	Kind:    "ScaledObject",
+}
+
+// reconcileScaledObjects pauses the KEDA ScaledObjects that scale a targeted workload
+// in namespaces on behalf of holder, or releases them. ScaledObjects holder pauses
+// but no longer selects are released too. If the KEDA CRDs are not installed the
+// function returns (0, nil) gracefully.
+//
+// Returns the number of ScaledObjects currently paused by holder.
+func reconcileScaledObjects(
+	ctx context.Context,
+	c client.Client,
+	target *freezev1alpha1.TargetSpec,
+	namespaces []string,
+	holder string,
+	active bool,
+) (int, error) {
+	logger := log.FromContext(ctx).WithName("autoscaling.keda")
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(scaledObjectGVK.GroupVersion().WithKind(scaledObjectGVK.Kind + "List"))
+	if err := c.List(ctx, list); err != nil {
+		if isNoMatchError(err) {
+			logger.V(1).Info("KEDA CRD not found, skipping")
+			return 0, nil
+		}
+		return 0, fmt.Errorf("list ScaledObjects: %w", err)
+	}
+
+	total := 0
+	for i := range list.Items {
+		obj := &list.Items[i]
+		selected := false
+		if slices.Contains(namespaces, obj.GetNamespace()) {
+			apiVersion, kind, name := scaledObjectTarget(obj)
+			var err error
+			selected, err = scalesTarget(ctx, c, target, obj.GetNamespace(), apiVersion, kind, name)
+			if err != nil {
+				logger.Error(err, "failed to resolve ScaledObject scale target", "name", obj.GetName(), "namespace", obj.GetNamespace())
+				continue
+			}
+		}
+		if !selected && !hold.HeldBy(obj.GetAnnotations(), holder) {
+			continue
+		}
+		paused, err := reconcileScaledObject(ctx, c, obj, holder, active && selected)
+		if err != nil {
+			logger.Error(err, "failed to reconcile ScaledObject", "name", obj.GetName(), "namespace", obj.GetNamespace())
+			continue
+		}
+		if paused {
+			total++
+		}
+	}
+
+	return total, nil
+}
+
+// scaledObjectTarget returns spec.scaleTargetRef of a ScaledObject with KEDA's
+// defaults applied.
+func scaledObjectTarget(obj *unstructured.Unstructured) (apiVersion, kind, name string) {
+	apiVersion, _, _ = unstructured.NestedString(obj.Object, "spec", "scaleTargetRef", "apiVersion")
+	kind, _, _ = unstructured.NestedString(obj.Object, "spec", "scaleTargetRef", "kind")
+	name, _, _ = unstructured.NestedString(obj.Object, "spec", "scaleTargetRef", "name")
+	if apiVersion == "" {
+		apiVersion = "apps/v1"
+	}
+	if kind == "" {
+		kind = string(freezev1alpha1.TargetKindDeployment)
+	}
+	return apiVersion, kind, name
+}
+
+// reconcileScaledObject manages the KEDA pause annotation on a single ScaledObject
+// on behalf of holder. The first holder saves the original pause annotation and
+// the last one restores it. Returns true if holder pauses the ScaledObject.
+func reconcileScaledObject(
+	ctx context.Context,
+	c client.Client,
+	obj *unstructured.Unstructured,
+	holder string,
+	active bool,
+) (bool, error) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	held := hold.HeldBy(annotations, holder)
+
+	switch {
+	case active:
+		first, changed := hold.Add(annotations, holder)
+		if !changed {
+			return true, nil
+		}
+		if first {
+			// Capture the current pause annotation, if any.
+			if original, ok := annotations[AnnotationKEDAPaused]; ok {
+				annotations[AnnotationOriginalKEDAPaused] = original
+			}
+			annotations[AnnotationManaged] = annotationManagedValue
+			annotations[AnnotationKEDAPaused] = "true"
+		}
+		obj.SetAnnotations(annotations)
+
+		if err := c.Update(ctx, obj); err != nil {
+			return false, fmt.Errorf("pause ScaledObject %s/%s: %w", obj.GetNamespace(), obj.GetName(), err)
+		}
+		if first {
+			log.FromContext(ctx).Info("ScaledObject paused",
+				"name", obj.GetName(), "namespace", obj.GetNamespace(), "policy", holder)
+		}
+		return true, nil
+
+	case held:
+		if _, last := hold.Remove(annotations, holder); last {
+			// Restore the original pause annotation.
+			if original, ok := annotations[AnnotationOriginalKEDAPaused]; ok {
+				annotations[AnnotationKEDAPaused] = original
+			} else {
+				delete(annotations, AnnotationKEDAPaused)
+			}
+			delete(annotations, AnnotationManaged)
+			delete(annotations, AnnotationOriginalKEDAPaused)
+		}
+		obj.SetAnnotations(annotations)
+
+		if err := c.Update(ctx, obj); err != nil {
+			return false, fmt.Errorf("restore ScaledObject %s/%s: %w", obj.GetNamespace(), obj.GetName(), err)
+		}
+		log.FromContext(ctx).Info("ScaledObject released",
+			"name", obj.GetName(), "namespace", obj.GetNamespace(), "policy", holder)
+		return false, nil
+
+	default:
+		return false, nil
+	}
+}
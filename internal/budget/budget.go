@@ -0,0 +1,216 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package budget keeps rate limit counters in Leases, so that every webhook replica
+// consumes from the same budget.
+package budget
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/policy"
+)
+
+const (
+	// AnnotationAdmissions holds the admission times inside the current period as a
+	// JSON array of Unix nanoseconds, oldest first.
+	AnnotationAdmissions = "freeze-operator.io/budget-admissions"
+
+	// AnnotationPolicy records the policy ("Kind/name") a Lease counts for.
+	AnnotationPolicy = "freeze-operator.io/budget-policy"
+
+	// AnnotationScope records the namespace of a namespace-scoped budget.
+	AnnotationScope = "freeze-operator.io/budget-namespace"
+
+	// LabelBudget marks Leases used as budget counters.
+	LabelBudget = "freeze-operator.io/budget"
+
+	holderIdentity = "kube-freeze-operator"
+)
+
+// +kubebuilder:rbac:groups=coordination.k8s.io,namespace=system,resources=leases,verbs=get;create;update
+
+// Counter consumes change budgets. Each budget is a sliding window of admission
+// times stored on a Lease; updates rely on resourceVersion conflicts, so concurrent
+// replicas cannot overspend a budget.
+type Counter struct {
+	Client client.Client
+	// Reader must read from the API server rather than a cache.
+	Reader client.Reader
+	// Namespace holds the Leases, usually the operator namespace.
+	Namespace string
+}
+
+// Consume takes one change from b at now. When the budget is exhausted it returns
+// false and the time the oldest admission leaves the window. With dryRun the
+// budget is checked but not consumed.
+func (c *Counter) Consume(ctx context.Context, b policy.Budget, now time.Time, dryRun bool) (bool, time.Time, error) {
+	var (
+		ok     bool
+		freeAt time.Time
+	)
+	err := retry.OnError(retry.DefaultBackoff, retriable, func() error {
+		lease, exists, err := c.get(ctx, b)
+		if err != nil {
+			return err
+		}
+		admissions, err := decode(lease)
+		if err != nil {
+			return err
+		}
+		admissions = inWindow(admissions, now, b.Period)
+		if int32(len(admissions)) >= b.Limit {
+			ok, freeAt = false, time.Unix(0, admissions[0]).UTC().Add(b.Period)
+			return nil
+		}
+		ok = true
+		if dryRun {
+			return nil
+		}
+		return c.save(ctx, lease, exists, append(admissions, now.UnixNano()), now, b.Period)
+	})
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("consume budget of %s/%s: %w", b.Policy.Kind, b.Policy.Name, err)
+	}
+	return ok, freeAt, nil
+}
+
+// Release gives back a change consumed at at, for a request that was denied after
+// consuming it.
+func (c *Counter) Release(ctx context.Context, b policy.Budget, at time.Time) error {
+	err := retry.OnError(retry.DefaultBackoff, retriable, func() error {
+		lease, exists, err := c.get(ctx, b)
+		if err != nil || !exists {
+			return err
+		}
+		admissions, err := decode(lease)
+		if err != nil {
+			return err
+		}
+		i := slices.Index(admissions, at.UnixNano())
+		if i < 0 {
+			return nil
+		}
+		return c.save(ctx, lease, true, slices.Delete(admissions, i, i+1), at, b.Period)
+	})
+	if err != nil {
+		return fmt.Errorf("release budget of %s/%s: %w", b.Policy.Kind, b.Policy.Name, err)
+	}
+	return nil
+}
+
+func (c *Counter) get(ctx context.Context, b policy.Budget) (*coordinationv1.Lease, bool, error) {
+	lease := &coordinationv1.Lease{}
+	err := c.Reader.Get(ctx, types.NamespacedName{Namespace: c.Namespace, Name: LeaseName(b)}, lease)
+	if apierrors.IsNotFound(err) {
+		return newLease(c.Namespace, b), false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return lease, true, nil
+}
+
+func (c *Counter) save(ctx context.Context, lease *coordinationv1.Lease, exists bool, admissions []int64, now time.Time, period time.Duration) error {
+	data, err := json.Marshal(admissions)
+	if err != nil {
+		return err
+	}
+	if lease.Annotations == nil {
+		lease.Annotations = map[string]string{}
+	}
+	lease.Annotations[AnnotationAdmissions] = string(data)
+	lease.Spec.RenewTime = &metav1.MicroTime{Time: now}
+	lease.Spec.LeaseDurationSeconds = ptr.To(int32(period.Seconds()))
+	if exists {
+		return c.Client.Update(ctx, lease)
+	}
+	return c.Client.Create(ctx, lease)
+}
+
+// LeaseName returns the name of the Lease counting b.
+func LeaseName(b policy.Budget) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{string(b.Policy.Kind), b.Policy.Name, string(b.PolicyUID), b.Namespace}, "/")))
+	return "freeze-budget-" + hex.EncodeToString(sum[:8])
+}
+
+// newLease returns an unsaved Lease for b, owned by its policy so it is garbage
+// collected with it.
+func newLease(namespace string, b policy.Budget) *coordinationv1.Lease {
+	lease := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      LeaseName(b),
+			Namespace: namespace,
+			Labels:    map[string]string{LabelBudget: "true"},
+			Annotations: map[string]string{
+				AnnotationPolicy: fmt.Sprintf("%s/%s", b.Policy.Kind, b.Policy.Name),
+			},
+		},
+		Spec: coordinationv1.LeaseSpec{HolderIdentity: ptr.To(holderIdentity)},
+	}
+	if b.Namespace != "" {
+		lease.Annotations[AnnotationScope] = b.Namespace
+	}
+	if b.PolicyUID != "" {
+		lease.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: freezev1alpha1.GroupVersion.String(),
+			Kind:       string(b.Policy.Kind),
+			Name:       b.Policy.Name,
+			UID:        b.PolicyUID,
+		}}
+	}
+	return lease
+}
+
+func decode(lease *coordinationv1.Lease) ([]int64, error) {
+	raw, ok := lease.Annotations[AnnotationAdmissions]
+	if !ok || raw == "" {
+		return nil, nil
+	}
+	var out []int64
+	if err := json.Unmarshal([]byte(raw), &out); err != nil {
+		return nil, fmt.Errorf("decode %s on Lease %s: %w", AnnotationAdmissions, lease.Name, err)
+	}
+	slices.Sort(out)
+	return out, nil
+}
+
+// inWindow drops admissions that are at least period older than now.
+func inWindow(admissions []int64, now time.Time, period time.Duration) []int64 {
+	cutoff := now.Add(-period).UnixNano()
+	i, _ := slices.BinarySearch(admissions, cutoff+1)
+	return admissions[i:]
+}
+
+func retriable(err error) bool {
+	return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package budget
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/policy"
+)
+
+func newCounter(t *testing.T) *Counter {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := coordinationv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+	return &Counter{Client: cl, Reader: cl, Namespace: "freeze-system"}
+}
+
+func testBudget() policy.Budget {
+	return policy.Budget{
+		Policy:    policy.PolicyRef{Kind: policy.PolicyKindChangeFreeze, Name: "slow-down"},
+		PolicyUID: types.UID("uid-1"),
+		Limit:     2,
+		Period:    time.Hour,
+	}
+}
+
+func TestConsume_SlidingWindow(t *testing.T) {
+	g := NewWithT(t)
+	c := newCounter(t)
+	b := testBudget()
+	t0 := time.Date(2026, 1, 28, 12, 0, 0, 0, time.UTC)
+
+	for _, at := range []time.Time{t0, t0.Add(10 * time.Minute)} {
+		ok, _, err := c.Consume(context.Background(), b, at, false)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(ok).To(BeTrue())
+	}
+
+	ok, freeAt, err := c.Consume(context.Background(), b, t0.Add(20*time.Minute), false)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ok).To(BeFalse())
+	g.Expect(freeAt).To(Equal(t0.Add(time.Hour)))
+
+	// The first admission leaves the window after one period.
+	ok, _, err = c.Consume(context.Background(), b, t0.Add(time.Hour), false)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	lease := &coordinationv1.Lease{}
+	g.Expect(c.Reader.Get(context.Background(), types.NamespacedName{Namespace: "freeze-system", Name: LeaseName(b)}, lease)).To(Succeed())
+	g.Expect(lease.OwnerReferences).To(HaveLen(1))
+	g.Expect(lease.OwnerReferences[0].UID).To(Equal(types.UID("uid-1")))
+	g.Expect(lease.Annotations).To(HaveKeyWithValue(AnnotationPolicy, "ChangeFreeze/slow-down"))
+}
+
+func TestConsume_DryRunAndRelease(t *testing.T) {
+	g := NewWithT(t)
+	c := newCounter(t)
+	b := testBudget()
+	b.Limit = 1
+	t0 := time.Date(2026, 1, 28, 12, 0, 0, 0, time.UTC)
+
+	ok, _, err := c.Consume(context.Background(), b, t0, true)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	ok, _, err = c.Consume(context.Background(), b, t0, false)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ok).To(BeTrue(), "a dry-run must not consume the budget")
+
+	g.Expect(c.Release(context.Background(), b, t0)).To(Succeed())
+	ok, _, err = c.Consume(context.Background(), b, t0.Add(time.Minute), false)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ok).To(BeTrue(), "a released change must be available again")
+}
+
+func TestConsume_NamespaceScope(t *testing.T) {
+	g := NewWithT(t)
+	c := newCounter(t)
+	prod, staging := testBudget(), testBudget()
+	prod.Limit, staging.Limit = 1, 1
+	prod.Namespace, staging.Namespace = "prod", "staging"
+	now := time.Date(2026, 1, 28, 12, 0, 0, 0, time.UTC)
+
+	g.Expect(LeaseName(prod)).ToNot(Equal(LeaseName(staging)))
+	for _, b := range []policy.Budget{prod, staging} {
+		ok, _, err := c.Consume(context.Background(), b, now, false)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(ok).To(BeTrue())
+	}
+	ok, _, err := c.Consume(context.Background(), prod, now.Add(time.Second), false)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ok).To(BeFalse())
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/metrics"
+)
+
+const reasonBreakGlassRemoved = "BreakGlassAnnotationRemoved"
+
+// BreakGlassKinds lists the workload kinds on which the break-glass annotation is honoured.
+var BreakGlassKinds = []schema.GroupVersionKind{
+	{Group: "apps", Version: "v1", Kind: "Deployment"},
+	{Group: "apps", Version: "v1", Kind: "StatefulSet"},
+	{Group: "apps", Version: "v1", Kind: "DaemonSet"},
+	{Group: "batch", Version: "v1", Kind: "CronJob"},
+}
+
+// BreakGlassReconciler removes the break-glass annotation from workloads of one kind
+// once the change that carried it has been admitted, so it cannot be reused.
+// Only object metadata is watched.
+type BreakGlassReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	GVK schema.GroupVersionKind
+}
+
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets;daemonsets,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile removes the break-glass annotation from the workload.
+func (r *BreakGlassReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	startTime := time.Now()
+	defer func() {
+		metrics.ReconciliationDuration.WithLabelValues("breakglass").Observe(time.Since(startTime).Seconds())
+	}()
+
+	logger := log.FromContext(ctx)
+
+	obj := &metav1.PartialObjectMetadata{}
+	obj.SetGroupVersionKind(r.GVK)
+	if err := r.Get(ctx, req.NamespacedName, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	reason, ok := obj.Annotations[freezeoperatorv1alpha1.AnnotationBreakGlass]
+	if !ok {
+		return ctrl.Result{}, nil
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]any{freezeoperatorv1alpha1.AnnotationBreakGlass: nil},
+		},
+	})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.Patch(ctx, obj, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("remove break-glass annotation: %w", err)
+	}
+
+	if r.Recorder != nil {
+		r.Recorder.Event(obj, corev1.EventTypeNormal, reasonBreakGlassRemoved,
+			fmt.Sprintf("Removed break-glass annotation (reason: %s)", reason))
+	}
+	logger.Info("removed break-glass annotation", "kind", r.GVK.Kind, "reason", reason)
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BreakGlassReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	obj := &metav1.PartialObjectMetadata{}
+	obj.SetGroupVersionKind(r.GVK)
+	hasAnnotation := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		_, ok := o.GetAnnotations()[freezeoperatorv1alpha1.AnnotationBreakGlass]
+		return ok
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(obj, builder.WithPredicates(hasAnnotation)).
+		Named("breakglass-" + strings.ToLower(r.GVK.Kind)).
+		Complete(r)
+}
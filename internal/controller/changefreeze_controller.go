@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"time"
 
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -29,11 +30,19 @@ import (
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/autoscaling"
 	"github.com/jamalshahverdiev/kube-freeze-operator/internal/gitops"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/hold"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/inventory"
 	"github.com/jamalshahverdiev/kube-freeze-operator/internal/metrics"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/policy"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/report"
 )
 
 // ChangeFreezeReconciler reconciles a ChangeFreeze object
@@ -41,17 +50,34 @@ type ChangeFreezeReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+
+	// APIReader reads workloads and pods for freeze snapshots; the cached client
+	// is used when nil.
+	APIReader client.Reader
+
+	// Reports collects the summary of each freeze occurrence, written to its
+	// FreezeReport when the freeze ends. Nothing is summarised when nil.
+	Reports *report.Aggregator
 }
 
 // +kubebuilder:rbac:groups=freeze-operator.io,resources=changefreezes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=freeze-operator.io,resources=changefreezes/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=freeze-operator.io,resources=changefreezes/finalizers,verbs=update
 // +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=freeze-operator.io,resources=freezesnapshots,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=freeze-operator.io,resources=freezereports,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=freeze-operator.io,resources=freezereports/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=apps,resources=statefulsets;daemonsets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups=argoproj.io,resources=applications,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=kustomize.toolkit.fluxcd.io,resources=kustomizations,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=helm.toolkit.fluxcd.io,resources=helmreleases,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects,verbs=get;list;watch;update;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -71,6 +97,20 @@ func (r *ChangeFreezeReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		}
 		return ctrl.Result{}, err
 	}
+
+	ref := freezeoperatorv1alpha1.PolicyReference{Kind: string(policy.PolicyKindChangeFreeze), Name: cf.Name}
+
+	// Restore everything the policy holds before it goes away
+	if !cf.DeletionTimestamp.IsZero() {
+		r.Reports.Forget(ref)
+		err := finalizePolicy(ctx, r.Client, r.Recorder, cf, &cf.Status.Conditions, "changefreeze", hold.Policy(string(policy.PolicyKindChangeFreeze), cf.Name))
+		return ctrl.Result{}, err
+	}
+	if controllerutil.AddFinalizer(cf, policyFinalizer) {
+		if err := r.Update(ctx, cf); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
 	cfPatch := client.MergeFrom(cf.DeepCopy())
 
 	// Evaluate current state
@@ -81,12 +121,20 @@ func (r *ChangeFreezeReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	// Determine if freeze is currently active
 	active := !now.Before(freezeStartTime) && now.Before(freezeEndTime)
 
-	// Track state changes for events
+	// Track state changes for events and hooks. A freeze first evaluated while
+	// active runs its onActivate hook as if the start had been observed.
 	wasActive := cf.Status.Active
+	evaluated := cf.Status.ObservedGeneration != 0
 
 	// Update status
 	cf.Status.Active = active
 	cf.Status.ObservedGeneration = cf.Generation
+	setPolicyPhase(&cf.Status.Phase, &cf.Status.TimeUntilStart, &cf.Status.LastTransitionTime, now, freezeStartTime, freezeEndTime)
+
+	// Summarise the occurrence from its start
+	if active {
+		r.Reports.Begin(ref, cf.Spec.StartTime.Time)
+	}
 
 	var requeueAfter time.Duration
 	if active {
@@ -122,9 +170,9 @@ func (r *ChangeFreezeReconciler) Reconcile(ctx context.Context, req ctrl.Request
 			r.Recorder.Event(cf, corev1.EventTypeNormal, reasonDeactivated, "Change freeze deactivated")
 		}
 
-		// If not yet started, requeue at start time
+		// If not yet started, requeue at start time, refreshing timeUntilStart until then
 		if now.Before(freezeStartTime) {
-			requeueAfter = freezeStartTime.Sub(now) + time.Second
+			requeueAfter = min(freezeStartTime.Sub(now)+time.Second, timeUntilStartRefresh)
 		} else {
 			// Already ended, no need to requeue frequently
 			requeueAfter = 10 * time.Minute
@@ -139,35 +187,112 @@ func (r *ChangeFreezeReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		Message:            "Successfully evaluated freeze period",
 	})
 
-	// Update CronJobs if configured
-	if cf.Spec.Behavior.SuspendCronJobs {
-		// For ChangeFreeze: active=true means freeze is on, so DO suspend
-		if err := r.updateCronJobsForPolicy(ctx, &cf.Spec.Target, cf.Name, active); err != nil {
-			logger.Error(err, "failed to update CronJobs")
-			if r.Recorder != nil {
-				r.Recorder.Event(cf, corev1.EventTypeWarning, reasonCronJobUpdateFail, err.Error())
-			}
-		} else if r.Recorder != nil {
-			r.Recorder.Event(cf, corev1.EventTypeNormal, reasonCronJobsUpdated,
-				fmt.Sprintf("CronJobs suspend status updated (suspend=%v)", active))
+	holder := hold.Policy(string(policy.PolicyKindChangeFreeze), cf.Name)
+
+	// Update CronJobs. For ChangeFreeze: active=true means freeze is on, so DO suspend.
+	// Without suspendCronJobs the CronJobs still held by this freeze are released.
+	suspend := cf.Spec.Behavior.SuspendCronJobs && active
+	cronResult, err := updateCronJobsForPolicy(ctx, r.Client, &cf.Spec.Target, holder, suspend)
+	if err != nil {
+		logger.Error(err, "failed to update CronJobs")
+		if r.Recorder != nil {
+			r.Recorder.Event(cf, corev1.EventTypeWarning, reasonCronJobUpdateFail, err.Error())
+		}
+	} else if cronResult.Changed > 0 && r.Recorder != nil {
+		r.Recorder.Event(cf, corev1.EventTypeNormal, reasonCronJobsUpdated,
+			fmt.Sprintf("CronJobs suspend status updated (suspend=%v, updated=%d)", suspend, cronResult.Changed))
+	}
+	suspended := 0
+	for ns, n := range cronResult.Suspended {
+		metrics.CronJobSuspensions.WithLabelValues("changefreeze", cf.Name, ns).Set(float64(n))
+		suspended += n
+	}
+	if err == nil {
+		r.Reports.RecordCronJobs(ref, suspended)
+	}
+
+	// Pause Deployments if configured. Without pauseDeployments the Deployments
+	// still held by this freeze are released.
+	pause := cf.Spec.Behavior.PauseDeployments && active
+	if changed, err := updateDeploymentsForPolicy(ctx, r.Client, &cf.Spec.Target, holder, pause); err != nil {
+		logger.Error(err, "failed to update Deployments")
+		if r.Recorder != nil {
+			r.Recorder.Event(cf, corev1.EventTypeWarning, reasonDeploymentUpdateFail, err.Error())
+		}
+	} else if changed > 0 && r.Recorder != nil {
+		r.Recorder.Event(cf, corev1.EventTypeNormal, reasonDeploymentsUpdated,
+			fmt.Sprintf("Deployments paused status updated (paused=%v, updated=%d)", pause, changed))
+	}
+
+	// Pin autoscalers if configured. Without pinAutoscalers the autoscalers still
+	// pinned by this freeze are released.
+	ar := &autoscaling.Reconciler{Client: r.Client}
+	if _, err := ar.Reconcile(ctx, &cf.Spec.Target, holder, cf.Spec.Behavior.PinAutoscalers && active); err != nil {
+		logger.Error(err, "failed to reconcile autoscalers")
+		if r.Recorder != nil {
+			r.Recorder.Event(cf, corev1.EventTypeWarning, reasonAutoscalerReconcileFail, err.Error())
 		}
 	}
 
 	// Reconcile GitOps engines (pause/resume ArgoCD & Flux) if configured.
 	if cf.Spec.Behavior.GitOps != nil && cf.Spec.Behavior.GitOps.Enabled {
 		gr := &gitops.Reconciler{Client: r.Client}
-		gResult, err := gr.Reconcile(ctx, cf.Spec.Behavior.GitOps, cf.Name, active)
+		gResult, err := gr.Reconcile(ctx, cf.Spec.Behavior.GitOps, holder, active)
 		if err != nil {
 			logger.Error(err, "failed to reconcile GitOps resources")
 			if r.Recorder != nil {
 				r.Recorder.Event(cf, corev1.EventTypeWarning, "GitOpsReconcileFailed", err.Error())
 			}
+		} else {
+			r.Reports.RecordGitOps(ref, gResult.PausedCount)
 		}
 		cf.Status.GitopsPausedCount = gResult.PausedCount
 		now := gResult.ReconcileTime
 		cf.Status.GitopsLastReconcileTime = &now
 	}
 
+	// Run hooks on transitions and track the Jobs they launched
+	var transition *hookTransition
+	if active != wasActive && (evaluated || active) {
+		transition = changeFreezeTransition(cf, active, now)
+	}
+	if err := reconcileHooks(ctx, r.Client, r.Scheme, r.Recorder, cf, holder, cf.Spec.Behavior.Hooks,
+		transition, &cf.Status.Hooks, &cf.Status.Conditions); err != nil {
+		logger.Error(err, "failed to reconcile hooks")
+		requeueAfter = min(requeueAfter, time.Minute)
+	}
+
+	// Snapshot workloads at freeze start and report drift once the freeze ends
+	reported := true
+	if err := r.reconcileSnapshot(ctx, cf, active, now); err != nil {
+		reported = false
+		logger.Error(err, "failed to reconcile freeze snapshot")
+		if r.Recorder != nil {
+			r.Recorder.Event(cf, corev1.EventTypeWarning, reasonSnapshotFailed, err.Error())
+		}
+		requeueAfter = min(requeueAfter, time.Minute)
+	}
+
+	// Write the summary of the occurrence once the freeze ends
+	if !active {
+		end := changeFreezeTransition(cf, false, now).Time
+		if err := reconcileSummary(ctx, r.Client, r.apiReader(), r.Recorder, r.Reports, cf, ref, end); err != nil {
+			reported = false
+			logger.Error(err, "failed to record freeze summary")
+			if r.Recorder != nil {
+				r.Recorder.Event(cf, corev1.EventTypeWarning, reasonSummaryFailed, err.Error())
+			}
+			requeueAfter = min(requeueAfter, time.Minute)
+		}
+	}
+
+	// Publish what the policy covers
+	if inv, err := inventory.Compute(ctx, r.Client, &cf.Spec.Target); err != nil {
+		logger.Error(err, "failed to compute affected workloads")
+	} else {
+		cf.Status.AffectedWorkloads = inv.Summary()
+	}
+
 	// Update status
 	if err := r.Status().Patch(ctx, cf, cfPatch); err != nil {
 		return ctrl.Result{}, err
@@ -180,13 +305,61 @@ func (r *ChangeFreezeReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		metrics.ActiveFreezePolicies.WithLabelValues("changefreeze", cf.Name).Set(0)
 	}
 
+	// Delete the freeze once it has been expired for ttlAfterExpired, but not
+	// before its report is written and its hook Jobs, which it owns, finished
+	if cf.Status.Phase == freezeoperatorv1alpha1.PolicyPhaseExpired && reported && !hooksRunning(cf.Status.Hooks) {
+		after, err := collectExpired(ctx, r.Client, r.Recorder, cf, cf.Spec.TTLAfterExpired, freezeEndTime, now, nil)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if after > 0 {
+			requeueAfter = min(requeueAfter, after)
+		}
+	}
+
 	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
+// changeFreezeTransition returns the hook transition of a ChangeFreeze that
+// started or stopped enforcing. Its time comes from the spec where possible, so
+// that every reconcile observing the transition identifies it the same way.
+func changeFreezeTransition(cf *freezeoperatorv1alpha1.ChangeFreeze, active bool, now time.Time) *hookTransition {
+	if active {
+		return &hookTransition{Type: freezeoperatorv1alpha1.HookTypeOnActivate, Time: cf.Spec.StartTime.Time}
+	}
+	at := now
+	if end := cf.Spec.EndTime.Time; !now.Before(end) {
+		at = end
+	}
+	return &hookTransition{Type: freezeoperatorv1alpha1.HookTypeOnDeactivate, Time: at}
+}
+
+// enqueueChangeFreezes returns a map function enqueueing every ChangeFreeze that want matches.
+func (r *ChangeFreezeReconciler) enqueueChangeFreezes(want policyFilter) handler.MapFunc {
+	return func(ctx context.Context, _ client.Object) []reconcile.Request {
+		var list freezeoperatorv1alpha1.ChangeFreezeList
+		if err := r.List(ctx, &list); err != nil {
+			log.FromContext(ctx).Error(err, "failed to list ChangeFreezes")
+			return nil
+		}
+		var reqs []reconcile.Request
+		for i := range list.Items {
+			cf := &list.Items[i]
+			if want(&cf.Spec.Target, &cf.Spec.Behavior) {
+				reqs = append(reqs, reconcile.Request{NamespacedName: client.ObjectKey{Name: cf.Name}})
+			}
+		}
+		return reqs
+	}
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ChangeFreezeReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	b, err := watchPolicyTargets(mgr, ctrl.NewControllerManagedBy(mgr).
 		For(&freezeoperatorv1alpha1.ChangeFreeze{}).
-		Named("changefreeze").
-		Complete(r)
+		Owns(&batchv1.Job{}), r.enqueueChangeFreezes)
+	if err != nil {
+		return err
+	}
+	return b.Named("changefreeze").Complete(r)
 }
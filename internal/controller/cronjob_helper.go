@@ -2,156 +2,140 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"slices"
+	"strconv"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/hold"
 )
 
 const (
 	// Annotation to track original suspend state before operator interference
 	annotationOriginalSuspend = "freeze-operator.io/original-suspend"
-	annotationManagedBy       = "freeze-operator.io/managed-by"
 )
 
-// updateCronJobsForPolicy updates CronJobs matching the target selector
-func (r *MaintenanceWindowReconciler) updateCronJobsForPolicy(ctx context.Context, target *freezeoperatorv1alpha1.TargetSpec, policyName string, shouldSuspend bool) error {
-	// List all namespaces
+// cronJobResult holds the output of a single updateCronJobsForPolicy call.
+type cronJobResult struct {
+	// Suspended is the number of CronJobs held by the policy, per namespace. Namespaces
+	// with CronJobs but none held are reported with 0.
+	Suspended map[string]int
+	// Changed is the number of CronJobs updated.
+	Changed int
+}
+
+// updateCronJobsForPolicy moves every CronJob towards the state the policy wants:
+// held suspended by holder when shouldSuspend is set and the CronJob is selected by
+// target, released otherwise. CronJobs the policy holds but no longer selects are
+// released too, so stale ownership never outlives a policy change.
+//
+// Like internal/gitops, it only restores what it changed: see reconcileCronJob.
+func updateCronJobsForPolicy(ctx context.Context, c client.Client, target *freezeoperatorv1alpha1.TargetSpec, holder string, shouldSuspend bool) (cronJobResult, error) {
+	result := cronJobResult{Suspended: map[string]int{}}
+
 	var nsList corev1.NamespaceList
-	if err := r.List(ctx, &nsList); err != nil {
-		return fmt.Errorf("list namespaces: %w", err)
+	if err := c.List(ctx, &nsList); err != nil {
+		return result, fmt.Errorf("list namespaces: %w", err)
 	}
-
-	// Filter namespaces by selector
-	matchingNS := []string{}
+	nsLabels := make(map[string]map[string]string, len(nsList.Items))
 	for _, ns := range nsList.Items {
-		if matchesNamespaceSelector(target.NamespaceSelector, ns.Labels) {
-			matchingNS = append(matchingNS, ns.Name)
-		}
+		nsLabels[ns.Name] = ns.Labels
 	}
 
-	// Check if CronJob is in target kinds
-	cronJobTargeted := slices.Contains(target.Kinds, freezeoperatorv1alpha1.TargetKindCronJob)
-
-	if !cronJobTargeted {
-		return nil // CronJobs not targeted by this policy
+	var cronList batchv1.CronJobList
+	if err := c.List(ctx, &cronList); err != nil {
+		return result, fmt.Errorf("list cronjobs: %w", err)
 	}
 
-	// Update CronJobs in matching namespaces
-	for _, ns := range matchingNS {
-		var cronList batchv1.CronJobList
-		if err := r.List(ctx, &cronList, client.InNamespace(ns)); err != nil {
-			return fmt.Errorf("list cronjobs in %s: %w", ns, err)
-		}
-
-		for i := range cronList.Items {
-			cron := &cronList.Items[i]
-
-			// Check objectSelector
-			if !matchesObjectSelector(target.ObjectSelector, cron.Labels) {
+	targeted := slices.Contains(target.Kinds, freezeoperatorv1alpha1.TargetKindCronJob)
+	var errs []error
+	for i := range cronList.Items {
+		cron := &cronList.Items[i]
+		lbls, nsExists := nsLabels[cron.Namespace]
+		selected := targeted && nsExists &&
+			matchesNamespaceSelector(target.NamespaceSelector, lbls) &&
+			matchesObjectSelector(target.ObjectSelector, cron.Labels)
+
+		changed := reconcileCronJob(cron, holder, shouldSuspend && selected)
+		if changed {
+			if err := c.Update(ctx, cron); err != nil {
+				errs = append(errs, fmt.Errorf("update cronjob %s/%s: %w", cron.Namespace, cron.Name, err))
 				continue
 			}
-
-			// Track original state if this is first time managing this CronJob
-			if cron.Annotations == nil {
-				cron.Annotations = make(map[string]string)
-			}
-
-			managedBy := cron.Annotations[annotationManagedBy]
-			if managedBy == "" {
-				// First time management - save original state
-				cron.Annotations[annotationOriginalSuspend] = fmt.Sprintf("%v", cron.Spec.Suspend != nil && *cron.Spec.Suspend)
-				cron.Annotations[annotationManagedBy] = policyName
-			} else if managedBy != policyName {
-				// Managed by different policy - skip to avoid conflicts
-				continue
-			}
-
-			// Update suspend field
-			suspend := shouldSuspend
-			if cron.Spec.Suspend == nil || *cron.Spec.Suspend != suspend {
-				cron.Spec.Suspend = &suspend
-				if err := r.Update(ctx, cron); err != nil {
-					return fmt.Errorf("update cronjob %s/%s: %w", ns, cron.Name, err)
-				}
-			}
+			result.Changed++
+		}
+		n := result.Suspended[cron.Namespace]
+		if heldBy(cron, holder) {
+			n++
 		}
+		result.Suspended[cron.Namespace] = n
 	}
 
-	return nil
+	return result, errors.Join(errs...)
 }
 
-// updateCronJobsForChangeFreezePolicy is used by ChangeFreeze controller
-func (r *ChangeFreezeReconciler) updateCronJobsForPolicy(ctx context.Context, target *freezeoperatorv1alpha1.TargetSpec, policyName string, shouldSuspend bool) error {
-	// List all namespaces
-	var nsList corev1.NamespaceList
-	if err := r.List(ctx, &nsList); err != nil {
-		return fmt.Errorf("list namespaces: %w", err)
+// reconcileCronJob is the suspend/restore state machine of a single CronJob. It
+// reports whether cron changed.
+//
+//   - suspend, not yet held by holder: holder joins the holders. The first holder saves
+//     the original suspend state. The CronJob is suspended.
+//   - suspend, already held by holder: nothing to do. A manual resume during the freeze
+//     is left alone, as in internal/gitops.
+//   - release, held by holder: holder leaves the holders. The last holder restores
+//     the original suspend state and removes the annotations. If the CronJob is no
+//     longer suspended, someone resumed it manually and it is left as it is.
+//   - release, not held by holder: nothing to do.
+func reconcileCronJob(cron *batchv1.CronJob, holder string, suspend bool) bool {
+	if cron.Annotations == nil {
+		cron.Annotations = make(map[string]string)
 	}
-
-	// Filter namespaces by selector
-	matchingNS := []string{}
-	for _, ns := range nsList.Items {
-		if matchesNamespaceSelector(target.NamespaceSelector, ns.Labels) {
-			matchingNS = append(matchingNS, ns.Name)
+	suspended := cron.Spec.Suspend != nil && *cron.Spec.Suspend
+
+	switch held := heldBy(cron, holder); {
+	case suspend && !held:
+		first, _ := hold.Add(cron.Annotations, holder)
+		if first {
+			// First policy to hold this CronJob - save original state
+			cron.Annotations[annotationOriginalSuspend] = strconv.FormatBool(suspended)
 		}
-	}
+		cron.Spec.Suspend = ptr.To(true)
+		return true
 
-	// Check if CronJob is in target kinds
-	cronJobTargeted := slices.Contains(target.Kinds, freezeoperatorv1alpha1.TargetKindCronJob)
+	case suspend && held:
+		// Rewrites a legacy managed-by owner as a qualified holder.
+		_, changed := hold.Add(cron.Annotations, holder)
+		return changed
 
-	if !cronJobTargeted {
-		return nil // CronJobs not targeted by this policy
-	}
-
-	// Update CronJobs in matching namespaces
-	for _, ns := range matchingNS {
-		var cronList batchv1.CronJobList
-		if err := r.List(ctx, &cronList, client.InNamespace(ns)); err != nil {
-			return fmt.Errorf("list cronjobs in %s: %w", ns, err)
+	case !suspend && held:
+		if _, last := hold.Remove(cron.Annotations, holder); !last {
+			return true
 		}
-
-		for i := range cronList.Items {
-			cron := &cronList.Items[i]
-
-			// Check objectSelector
-			if !matchesObjectSelector(target.ObjectSelector, cron.Labels) {
-				continue
-			}
-
-			// Track original state if this is first time managing this CronJob
-			if cron.Annotations == nil {
-				cron.Annotations = make(map[string]string)
-			}
-
-			managedBy := cron.Annotations[annotationManagedBy]
-			if managedBy == "" {
-				// First time management - save original state
-				cron.Annotations[annotationOriginalSuspend] = fmt.Sprintf("%v", cron.Spec.Suspend != nil && *cron.Spec.Suspend)
-				cron.Annotations[annotationManagedBy] = policyName
-			} else if managedBy != policyName {
-				// Managed by different policy - skip to avoid conflicts
-				continue
-			}
-
-			// Update suspend field
-			suspend := shouldSuspend
-			if cron.Spec.Suspend == nil || *cron.Spec.Suspend != suspend {
-				cron.Spec.Suspend = &suspend
-				if err := r.Update(ctx, cron); err != nil {
-					return fmt.Errorf("update cronjob %s/%s: %w", ns, cron.Name, err)
-				}
-			}
+		original, err := strconv.ParseBool(cron.Annotations[annotationOriginalSuspend])
+		if err != nil {
+			original = false // safe default: resume
 		}
+		if suspended {
+			cron.Spec.Suspend = ptr.To(original)
+		}
+		delete(cron.Annotations, annotationOriginalSuspend)
+		return true
+
+	default:
+		return false
 	}
+}
 
-	return nil
+// heldBy reports whether holder holds cron suspended.
+func heldBy(cron *batchv1.CronJob, holder string) bool {
+	return hold.HeldBy(cron.Annotations, holder)
 }
 
 func matchesNamespaceSelector(selector *metav1.LabelSelector, nsLabels map[string]string) bool {
@@ -0,0 +1,312 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/hold"
+)
+
+// ---------------------------------------------------------------------------
+// helpers
+// ---------------------------------------------------------------------------
+
+const (
+	testCronNS       = "prod"
+	testHoliday      = "ChangeFreeze/holiday"
+	testWeekdays     = "MaintenanceWindow/weekdays"
+	testCronJobName  = "report"
+	testOtherCronJob = "backup"
+)
+
+func newCronNamespace() *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testCronNS, Labels: map[string]string{"env": "prod"}}}
+}
+
+func newCronJob(name string, labels map[string]string, suspend bool) *batchv1.CronJob {
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testCronNS, Labels: labels},
+		Spec:       batchv1.CronJobSpec{Schedule: "0 * * * *", Suspend: ptr.To(suspend)},
+	}
+}
+
+func cronTarget(objectSelector *metav1.LabelSelector) *freezeoperatorv1alpha1.TargetSpec {
+	return &freezeoperatorv1alpha1.TargetSpec{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+		ObjectSelector:    objectSelector,
+		Kinds:             []freezeoperatorv1alpha1.TargetKind{freezeoperatorv1alpha1.TargetKindCronJob},
+	}
+}
+
+func getCronJob(ctx context.Context, c client.Client, name string) *batchv1.CronJob {
+	cron := &batchv1.CronJob{}
+	_ = c.Get(ctx, client.ObjectKey{Name: name, Namespace: testCronNS}, cron)
+	return cron
+}
+
+func mustUpdateCronJobs(t *testing.T, c client.Client, target *freezeoperatorv1alpha1.TargetSpec, holder string, suspend bool) cronJobResult {
+	t.Helper()
+	res, err := updateCronJobsForPolicy(context.Background(), c, target, holder, suspend)
+	if err != nil {
+		t.Fatalf("updateCronJobsForPolicy(%s, suspend=%v): %v", holder, suspend, err)
+	}
+	return res
+}
+
+func isSuspended(cron *batchv1.CronJob) bool {
+	return cron.Spec.Suspend != nil && *cron.Spec.Suspend
+}
+
+// ---------------------------------------------------------------------------
+// state machine tests
+// ---------------------------------------------------------------------------
+
+func TestUpdateCronJobs_Suspend(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeClient(newCronNamespace(), newCronJob(testCronJobName, nil, false))
+
+	res := mustUpdateCronJobs(t, c, cronTarget(nil), testHoliday, true)
+	if res.Changed != 1 || res.Suspended[testCronNS] != 1 {
+		t.Errorf("expected 1 changed and 1 suspended, got %+v", res)
+	}
+
+	cron := getCronJob(ctx, c, testCronJobName)
+	if !isSuspended(cron) {
+		t.Error("expected CronJob to be suspended")
+	}
+	if cron.Annotations[hold.AnnotationHeldBy] != testHoliday {
+		t.Errorf("expected held-by %q, got %q", testHoliday, cron.Annotations[hold.AnnotationHeldBy])
+	}
+	if cron.Annotations[annotationOriginalSuspend] != "false" {
+		t.Errorf("expected original-suspend %q, got %q", "false", cron.Annotations[annotationOriginalSuspend])
+	}
+}
+
+func TestUpdateCronJobs_Restore(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeClient(newCronNamespace(), newCronJob(testCronJobName, nil, false))
+
+	mustUpdateCronJobs(t, c, cronTarget(nil), testHoliday, true)
+	res := mustUpdateCronJobs(t, c, cronTarget(nil), testHoliday, false)
+	if res.Changed != 1 || res.Suspended[testCronNS] != 0 {
+		t.Errorf("expected 1 changed and 0 suspended, got %+v", res)
+	}
+
+	cron := getCronJob(ctx, c, testCronJobName)
+	if isSuspended(cron) {
+		t.Error("expected CronJob to be resumed")
+	}
+	for _, a := range []string{hold.AnnotationHeldBy, annotationOriginalSuspend, hold.AnnotationManagedBy} {
+		if _, ok := cron.Annotations[a]; ok {
+			t.Errorf("annotation %s should be removed on restore", a)
+		}
+	}
+}
+
+func TestUpdateCronJobs_AlreadySuspended_PreservesOriginal(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeClient(newCronNamespace(), newCronJob(testCronJobName, nil, true))
+
+	mustUpdateCronJobs(t, c, cronTarget(nil), testHoliday, true)
+	if got := getCronJob(ctx, c, testCronJobName).Annotations[annotationOriginalSuspend]; got != "true" {
+		t.Errorf("expected original-suspend %q, got %q", "true", got)
+	}
+
+	mustUpdateCronJobs(t, c, cronTarget(nil), testHoliday, false)
+	if !isSuspended(getCronJob(ctx, c, testCronJobName)) {
+		t.Error("a CronJob suspended before the freeze must stay suspended")
+	}
+}
+
+func TestUpdateCronJobs_Idempotent(t *testing.T) {
+	c := newFakeClient(newCronNamespace(), newCronJob(testCronJobName, nil, false))
+
+	mustUpdateCronJobs(t, c, cronTarget(nil), testHoliday, true)
+	if res := mustUpdateCronJobs(t, c, cronTarget(nil), testHoliday, true); res.Changed != 0 {
+		t.Errorf("expected no changes on a second suspend, got %d", res.Changed)
+	}
+
+	mustUpdateCronJobs(t, c, cronTarget(nil), testHoliday, false)
+	if res := mustUpdateCronJobs(t, c, cronTarget(nil), testHoliday, false); res.Changed != 0 {
+		t.Errorf("expected no changes on a second restore, got %d", res.Changed)
+	}
+}
+
+func TestUpdateCronJobs_ReleaseDoesNotTouchUnheld(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeClient(newCronNamespace(), newCronJob(testCronJobName, nil, true))
+
+	if res := mustUpdateCronJobs(t, c, cronTarget(nil), testHoliday, false); res.Changed != 0 {
+		t.Errorf("expected no changes, got %d", res.Changed)
+	}
+	cron := getCronJob(ctx, c, testCronJobName)
+	if !isSuspended(cron) || len(cron.Annotations) != 0 {
+		t.Errorf("CronJob not held by the policy must not be touched, got suspend=%v annotations=%v", isSuspended(cron), cron.Annotations)
+	}
+}
+
+func TestUpdateCronJobs_ManualResume_LeftAlone(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeClient(newCronNamespace(), newCronJob(testCronJobName, nil, true))
+
+	mustUpdateCronJobs(t, c, cronTarget(nil), testHoliday, true)
+
+	// Someone resumes the CronJob during the freeze.
+	cron := getCronJob(ctx, c, testCronJobName)
+	cron.Spec.Suspend = ptr.To(false)
+	if err := c.Update(ctx, cron); err != nil {
+		t.Fatal(err)
+	}
+
+	if res := mustUpdateCronJobs(t, c, cronTarget(nil), testHoliday, true); res.Changed != 0 {
+		t.Errorf("a held CronJob must not be suspended again, got %d changes", res.Changed)
+	}
+
+	mustUpdateCronJobs(t, c, cronTarget(nil), testHoliday, false)
+	cron = getCronJob(ctx, c, testCronJobName)
+	if isSuspended(cron) {
+		t.Error("a manual resume must not be overwritten by the original suspend state")
+	}
+	if _, ok := cron.Annotations[hold.AnnotationHeldBy]; ok {
+		t.Error("held-by should be removed on release")
+	}
+}
+
+func TestUpdateCronJobs_SelectorFiltering(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeClient(newCronNamespace(),
+		newCronJob(testCronJobName, map[string]string{"team": "a"}, false),
+		newCronJob(testOtherCronJob, map[string]string{"team": "b"}, false),
+	)
+
+	mustUpdateCronJobs(t, c, cronTarget(&metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}), testHoliday, true)
+	if !isSuspended(getCronJob(ctx, c, testCronJobName)) {
+		t.Error("selected CronJob should be suspended")
+	}
+	if isSuspended(getCronJob(ctx, c, testOtherCronJob)) {
+		t.Error("CronJob outside the objectSelector should not be suspended")
+	}
+}
+
+func TestUpdateCronJobs_NoLongerSelected_Released(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeClient(newCronNamespace(), newCronJob(testCronJobName, map[string]string{"team": "a"}, false))
+
+	mustUpdateCronJobs(t, c, cronTarget(nil), testHoliday, true)
+
+	// The policy is narrowed so that it no longer selects the CronJob.
+	narrowed := cronTarget(&metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}})
+	mustUpdateCronJobs(t, c, narrowed, testHoliday, true)
+	cron := getCronJob(ctx, c, testCronJobName)
+	if isSuspended(cron) || cron.Annotations[hold.AnnotationHeldBy] != "" {
+		t.Errorf("CronJob the policy no longer selects should be released, got suspend=%v annotations=%v", isSuspended(cron), cron.Annotations)
+	}
+
+	// Dropping CronJob from the target kinds releases it as well.
+	mustUpdateCronJobs(t, c, cronTarget(nil), testHoliday, true)
+	kinds := cronTarget(nil)
+	kinds.Kinds = []freezeoperatorv1alpha1.TargetKind{freezeoperatorv1alpha1.TargetKindDeployment}
+	mustUpdateCronJobs(t, c, kinds, testHoliday, true)
+	if isSuspended(getCronJob(ctx, c, testCronJobName)) {
+		t.Error("CronJob should be released when CronJob is no longer a target kind")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// multi-policy tests
+// ---------------------------------------------------------------------------
+
+func TestUpdateCronJobs_OverlappingPolicies(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeClient(newCronNamespace(), newCronJob(testCronJobName, nil, false))
+
+	mustUpdateCronJobs(t, c, cronTarget(nil), testHoliday, true)
+	mustUpdateCronJobs(t, c, cronTarget(nil), testWeekdays, true)
+	cron := getCronJob(ctx, c, testCronJobName)
+	if cron.Annotations[hold.AnnotationHeldBy] != testHoliday+","+testWeekdays {
+		t.Errorf("expected both holders, got %q", cron.Annotations[hold.AnnotationHeldBy])
+	}
+	if cron.Annotations[annotationOriginalSuspend] != "false" {
+		t.Errorf("the second holder must keep the original state, got %q", cron.Annotations[annotationOriginalSuspend])
+	}
+
+	// The ChangeFreeze ends while the MaintenanceWindow is still closed.
+	mustUpdateCronJobs(t, c, cronTarget(nil), testHoliday, false)
+	cron = getCronJob(ctx, c, testCronJobName)
+	if !isSuspended(cron) {
+		t.Error("CronJob must stay suspended while another policy holds it")
+	}
+	if cron.Annotations[hold.AnnotationHeldBy] != testWeekdays {
+		t.Errorf("expected held-by %q, got %q", testWeekdays, cron.Annotations[hold.AnnotationHeldBy])
+	}
+
+	// The MaintenanceWindow opens.
+	mustUpdateCronJobs(t, c, cronTarget(nil), testWeekdays, false)
+	if isSuspended(getCronJob(ctx, c, testCronJobName)) {
+		t.Error("CronJob should resume when the last holder releases it")
+	}
+}
+
+func TestUpdateCronJobs_SameNameDifferentKind(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeClient(newCronNamespace(), newCronJob(testCronJobName, nil, false))
+
+	mustUpdateCronJobs(t, c, cronTarget(nil), "ChangeFreeze/x", true)
+	if res := mustUpdateCronJobs(t, c, cronTarget(nil), "MaintenanceWindow/x", false); res.Changed != 0 {
+		t.Errorf("a policy of another kind with the same name must not release the CronJob, got %d changes", res.Changed)
+	}
+	if !isSuspended(getCronJob(ctx, c, testCronJobName)) {
+		t.Error("CronJob should stay suspended")
+	}
+}
+
+func TestUpdateCronJobs_LegacyManagedBy(t *testing.T) {
+	ctx := context.Background()
+	cron := newCronJob(testCronJobName, nil, true)
+	cron.Annotations = map[string]string{
+		hold.AnnotationManagedBy:  "holiday",
+		annotationOriginalSuspend: "false",
+	}
+	c := newFakeClient(newCronNamespace(), cron)
+
+	mustUpdateCronJobs(t, c, cronTarget(nil), testWeekdays, true)
+	cron = getCronJob(ctx, c, testCronJobName)
+	if _, ok := cron.Annotations[hold.AnnotationManagedBy]; ok {
+		t.Error("legacy managed-by should be rewritten as held-by")
+	}
+	if cron.Annotations[hold.AnnotationHeldBy] != "MaintenanceWindow/weekdays,holiday" {
+		t.Errorf("unexpected held-by %q", cron.Annotations[hold.AnnotationHeldBy])
+	}
+
+	mustUpdateCronJobs(t, c, cronTarget(nil), testHoliday, false)
+	if !isSuspended(getCronJob(ctx, c, testCronJobName)) {
+		t.Error("CronJob should stay suspended while the MaintenanceWindow holds it")
+	}
+	mustUpdateCronJobs(t, c, cronTarget(nil), testWeekdays, false)
+	if isSuspended(getCronJob(ctx, c, testCronJobName)) {
+		t.Error("CronJob should resume to its original state")
+	}
+}
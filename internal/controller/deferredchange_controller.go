@@ -0,0 +1,371 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/diff"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/metrics"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/policy"
+)
+
+const (
+	conditionTypeApplied = "Applied"
+
+	reasonDeferredPending    = "Pending"
+	reasonDeferredApplied    = "DeferredChangeApplied"
+	reasonDeferredConflict   = "DeferredChangeConflict"
+	reasonDeferredSuperseded = "DeferredChangeSuperseded"
+	reasonDeferredFailed     = "DeferredChangeFailed"
+
+	// deferredChangeFieldOwner is the field manager of replayed deferred changes.
+	deferredChangeFieldOwner = "freeze-operator-deferred-change"
+
+	// deferredChangeRecheck bounds how long a pending change waits before the
+	// policies are evaluated again.
+	deferredChangeRecheck = 5 * time.Minute
+)
+
+// DeferredChangeReconciler applies DeferredChanges once the policies allow them.
+type DeferredChangeReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// APIReader reads the target workload for conflict detection; the cached
+	// client is used when nil.
+	APIReader client.Reader
+
+	// RequireApprovedExceptions and MinApprovals are passed to the policy evaluator.
+	RequireApprovedExceptions bool
+	MinApprovals              int32
+}
+
+// +kubebuilder:rbac:groups=freeze-operator.io,resources=deferredchanges,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=freeze-operator.io,resources=deferredchanges/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=freeze-operator.io,resources=freezeexceptions/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets;daemonsets,verbs=get;create;patch
+// +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;create;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile replays a pending DeferredChange once the policy evaluator allows it for
+// its requester, unless the fields it touches changed in the meantime. An UPDATE is
+// replayed as a merge patch of the fields it changed; uses of FreezeExceptions are
+// counted as they are for admitted requests.
+func (r *DeferredChangeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	startTime := time.Now()
+	defer func() {
+		metrics.ReconciliationDuration.WithLabelValues("deferredchange").Observe(time.Since(startTime).Seconds())
+	}()
+
+	logger := log.FromContext(ctx)
+
+	dc := &freezeoperatorv1alpha1.DeferredChange{}
+	if err := r.Get(ctx, req.NamespacedName, dc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if dc.Status.Phase != "" && dc.Status.Phase != freezeoperatorv1alpha1.DeferredChangePhasePending {
+		return ctrl.Result{}, nil
+	}
+
+	newer, err := r.newerChange(ctx, dc)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if newer != "" {
+		return ctrl.Result{}, r.finish(ctx, dc, freezeoperatorv1alpha1.DeferredChangePhaseSuperseded, reasonDeferredSuperseded,
+			fmt.Sprintf("Superseded by DeferredChange %s", newer))
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(dc.Spec.Object.Raw); err != nil {
+		return ctrl.Result{}, r.finish(ctx, dc, freezeoperatorv1alpha1.DeferredChangePhaseFailed, reasonDeferredFailed,
+			fmt.Sprintf("Invalid object: %v", err))
+	}
+	obj.SetNamespace(dc.Namespace)
+	if dc.Spec.Name != "" {
+		obj.SetName(dc.Spec.Name)
+	}
+
+	live, conflict, err := r.liveObject(ctx, dc, obj)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	var patch []byte
+	if live != nil && conflict == "" {
+		patch, conflict, err = replayPatch(dc, live)
+		if err != nil {
+			return ctrl.Result{}, r.finish(ctx, dc, freezeoperatorv1alpha1.DeferredChangePhaseFailed, reasonDeferredFailed,
+				fmt.Sprintf("Invalid object: %v", err))
+		}
+	}
+	if conflict != "" {
+		return ctrl.Result{}, r.finish(ctx, dc, freezeoperatorv1alpha1.DeferredChangePhaseConflict, reasonDeferredConflict, conflict)
+	}
+
+	in := policy.Input{
+		Now:          time.Now().UTC(),
+		Namespace:    dc.Namespace,
+		Kind:         dc.Spec.Kind,
+		Action:       dc.Spec.Action,
+		Name:         dc.Spec.Name,
+		ObjectLabels: obj.GetLabels(),
+		Username:     dc.Spec.Requester.Username,
+		Groups:       dc.Spec.Requester.Groups,
+	}
+	if live != nil {
+		in.OldObjectLabels = live.GetLabels()
+		if in.OldObjectLabels == nil {
+			in.OldObjectLabels = map[string]string{}
+		}
+	}
+	ev := &policy.Evaluator{Client: r.Client, RequireApprovedExceptions: r.RequireApprovedExceptions, MinApprovals: r.MinApprovals}
+	dec, err := ev.Evaluate(ctx, in)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !dec.Allowed || len(dec.Budgets) > 0 {
+		return r.wait(ctx, dc, dec)
+	}
+
+	if dec.MatchedOverride != nil {
+		object := fmt.Sprintf("%s %s/%s", dc.Spec.Kind, dc.Namespace, dc.Spec.Name)
+		limited, err := policy.RecordExceptionUse(ctx, r.Client, r.apiReader(), dec.MatchedOverride.Name,
+			dc.Spec.Requester.Username, object, dec.EvaluationTime)
+		switch {
+		case errors.Is(err, policy.ErrExceptionExhausted):
+			// Another request spent the last use; evaluate the change again.
+			return ctrl.Result{RequeueAfter: time.Second}, nil
+		case err != nil && limited:
+			return ctrl.Result{}, fmt.Errorf("record use of FreezeException %q: %w", dec.MatchedOverride.Name, err)
+		case err != nil:
+			logger.Error(err, "record exception use", "exception", dec.MatchedOverride.Name)
+		}
+	}
+
+	if live == nil {
+		err = r.Create(ctx, obj, client.FieldOwner(deferredChangeFieldOwner))
+	} else {
+		err = r.Patch(ctx, live, client.RawPatch(types.MergePatchType, patch), client.FieldOwner(deferredChangeFieldOwner))
+	}
+	if err != nil {
+		if apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err) {
+			// The workload changed after the conflict check; check again.
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, r.finish(ctx, dc, freezeoperatorv1alpha1.DeferredChangePhaseFailed, reasonDeferredFailed,
+			fmt.Sprintf("Apply failed: %v", err))
+	}
+
+	logger.Info("applied deferred change", "kind", dc.Spec.Kind, "name", obj.GetName(), "requester", dc.Spec.Requester.Username)
+	now := metav1.Now()
+	dc.Status.AppliedTime = &now
+	return ctrl.Result{}, r.finish(ctx, dc, freezeoperatorv1alpha1.DeferredChangePhaseApplied, reasonDeferredApplied,
+		fmt.Sprintf("Applied %s %s/%s on behalf of %s", dc.Spec.Kind, dc.Namespace, obj.GetName(), dc.Spec.Requester.Username))
+}
+
+// newerChange returns the name of a later unfinished DeferredChange for the same
+// workload, if any.
+func (r *DeferredChangeReconciler) newerChange(ctx context.Context, dc *freezeoperatorv1alpha1.DeferredChange) (string, error) {
+	if dc.Spec.Name == "" {
+		// Each CREATE with generateName makes a different workload.
+		return "", nil
+	}
+	var list freezeoperatorv1alpha1.DeferredChangeList
+	if err := r.List(ctx, &list, client.InNamespace(dc.Namespace)); err != nil {
+		return "", fmt.Errorf("list DeferredChanges: %w", err)
+	}
+	for i := range list.Items {
+		other := &list.Items[i]
+		if other.Name == dc.Name || other.Spec.Kind != dc.Spec.Kind || other.Spec.Name != dc.Spec.Name {
+			continue
+		}
+		if other.Status.Phase != "" && other.Status.Phase != freezeoperatorv1alpha1.DeferredChangePhasePending {
+			continue
+		}
+		ot, t := other.CreationTimestamp.Time, dc.CreationTimestamp.Time
+		if ot.After(t) || (ot.Equal(t) && other.Name > dc.Name) {
+			return other.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// liveObject reads the current workload and reports why the change conflicts with
+// it, if it does.
+func (r *DeferredChangeReconciler) liveObject(ctx context.Context, dc *freezeoperatorv1alpha1.DeferredChange, obj *unstructured.Unstructured) (*unstructured.Unstructured, string, error) {
+	if obj.GetName() == "" {
+		// A CREATE with generateName cannot collide with an existing object.
+		return nil, "", nil
+	}
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(obj.GroupVersionKind())
+	err := r.apiReader().Get(ctx, types.NamespacedName{Namespace: dc.Namespace, Name: dc.Spec.Name}, live)
+	exists := err == nil
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, "", err
+	}
+
+	switch dc.Spec.Operation {
+	case freezeoperatorv1alpha1.DeferredOperationCreate:
+		if exists {
+			return nil, fmt.Sprintf("%s %s/%s was created in the meantime", dc.Spec.Kind, dc.Namespace, dc.Spec.Name), nil
+		}
+		return nil, "", nil
+	default:
+		if !exists {
+			return nil, fmt.Sprintf("%s %s/%s was deleted in the meantime", dc.Spec.Kind, dc.Namespace, dc.Spec.Name), nil
+		}
+		if dc.Spec.BaseObject == nil && live.GetGeneration() != dc.Spec.BaseGeneration {
+			return nil, fmt.Sprintf("%s %s/%s changed in the meantime (generation %d, change was made against %d)",
+				dc.Spec.Kind, dc.Namespace, dc.Spec.Name, live.GetGeneration(), dc.Spec.BaseGeneration), nil
+		}
+		return live, "", nil
+	}
+}
+
+// replayPatch returns the merge patch that replays an UPDATE on live, or why it
+// conflicts with live. Only the fields the change touched are patched, so changes
+// others made since, such as an autoscaler setting replicas, are kept. A change
+// recorded without its base object replaces all fields.
+func replayPatch(dc *freezeoperatorv1alpha1.DeferredChange, live *unstructured.Unstructured) ([]byte, string, error) {
+	current, err := live.MarshalJSON()
+	if err != nil {
+		return nil, "", err
+	}
+	base := current
+	if dc.Spec.BaseObject != nil {
+		base = dc.Spec.BaseObject.Raw
+	}
+	patch, field, err := diff.ReplayPatch(base, dc.Spec.Object.Raw, current)
+	if err != nil || field != "" {
+		return nil, conflictMessage(dc, field), err
+	}
+
+	// Fail the patch if the object changes between the conflict check and the patch.
+	var m map[string]any
+	if err := json.Unmarshal(patch, &m); err != nil {
+		return nil, "", err
+	}
+	if err := unstructured.SetNestedField(m, live.GetResourceVersion(), "metadata", "resourceVersion"); err != nil {
+		return nil, "", err
+	}
+	patch, err = json.Marshal(m)
+	return patch, "", err
+}
+
+func conflictMessage(dc *freezeoperatorv1alpha1.DeferredChange, field string) string {
+	if field == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s %s/%s changed in the meantime (%s was changed since the change was made)",
+		dc.Spec.Kind, dc.Namespace, dc.Spec.Name, field)
+}
+
+func (r *DeferredChangeReconciler) apiReader() client.Reader {
+	if r.APIReader != nil {
+		return r.APIReader
+	}
+	return r.Client
+}
+
+// wait keeps dc pending and requeues it for when the policies may allow it.
+func (r *DeferredChangeReconciler) wait(ctx context.Context, dc *freezeoperatorv1alpha1.DeferredChange, dec policy.Decision) (ctrl.Result, error) {
+	msg := "Waiting for a rate limit to stop applying"
+	if !dec.Allowed && dec.MatchedPolicy != nil {
+		msg = fmt.Sprintf("Waiting for %s/%s: %s", dec.MatchedPolicy.Kind, dec.MatchedPolicy.Name, dec.Reason)
+	}
+	if err := r.setStatus(ctx, dc, freezeoperatorv1alpha1.DeferredChangePhasePending, metav1.ConditionFalse, reasonDeferredPending, msg); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	requeue := deferredChangeRecheck
+	if dec.NextAllowedTime != nil {
+		if d := time.Until(*dec.NextAllowedTime) + time.Second; d > 0 && d < requeue {
+			requeue = d
+		}
+	}
+	return ctrl.Result{RequeueAfter: requeue}, nil
+}
+
+// finish moves dc to a final phase and records an Event.
+func (r *DeferredChangeReconciler) finish(ctx context.Context, dc *freezeoperatorv1alpha1.DeferredChange, phase freezeoperatorv1alpha1.DeferredChangePhase, reason, msg string) error {
+	status := metav1.ConditionFalse
+	eventType := corev1.EventTypeWarning
+	if phase == freezeoperatorv1alpha1.DeferredChangePhaseApplied {
+		status = metav1.ConditionTrue
+		eventType = corev1.EventTypeNormal
+	}
+	if err := r.setStatus(ctx, dc, phase, status, reason, msg); err != nil {
+		return err
+	}
+	metrics.DeferredChanges.WithLabelValues(string(phase), dc.Namespace, string(dc.Spec.Kind)).Inc()
+	if r.Recorder != nil {
+		r.Recorder.Event(dc, eventType, reason, msg)
+	}
+	return nil
+}
+
+func (r *DeferredChangeReconciler) setStatus(ctx context.Context, dc *freezeoperatorv1alpha1.DeferredChange, phase freezeoperatorv1alpha1.DeferredChangePhase, status metav1.ConditionStatus, reason, msg string) error {
+	changed := meta.SetStatusCondition(&dc.Status.Conditions, metav1.Condition{
+		Type:               conditionTypeApplied,
+		Status:             status,
+		Reason:             reason,
+		Message:            msg,
+		ObservedGeneration: dc.Generation,
+	})
+	if !changed && dc.Status.Phase == phase && dc.Status.Message == msg {
+		return nil
+	}
+	dc.Status.Phase = phase
+	dc.Status.Message = msg
+	if err := r.Status().Update(ctx, dc); err != nil {
+		return fmt.Errorf("update DeferredChange status: %w", err)
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DeferredChangeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&freezeoperatorv1alpha1.DeferredChange{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Named("deferredchange").
+		Complete(r)
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+)
+
+var _ = Describe("DeferredChange Controller", func() {
+	Context("When reconciling a resource", func() {
+		const workloadName = "deferred-app"
+
+		ctx := context.Background()
+
+		deployment := func(image string) *appsv1.Deployment {
+			return &appsv1.Deployment{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+				ObjectMeta: metav1.ObjectMeta{Name: workloadName, Namespace: "default"},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: ptr.To[int32](1),
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": workloadName}},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": workloadName}},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "app", Image: image}},
+						},
+					},
+				},
+			}
+		}
+
+		deferredChange := func(name string, baseGeneration int64) *freezeoperatorv1alpha1.DeferredChange {
+			raw, err := json.Marshal(deployment("nginx:1.27"))
+			Expect(err).NotTo(HaveOccurred())
+			return &freezeoperatorv1alpha1.DeferredChange{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: freezeoperatorv1alpha1.DeferredChangeSpec{
+					Kind:           freezeoperatorv1alpha1.TargetKindDeployment,
+					Name:           workloadName,
+					Operation:      freezeoperatorv1alpha1.DeferredOperationUpdate,
+					Action:         freezeoperatorv1alpha1.ActionRollout,
+					Object:         runtime.RawExtension{Raw: raw},
+					BaseGeneration: baseGeneration,
+					Requester:      freezeoperatorv1alpha1.DeferredChangeRequester{Username: "alice"},
+					BlockingPolicy: freezeoperatorv1alpha1.DeferredChangePolicyRef{Kind: "ChangeFreeze", Name: "ended-freeze"},
+				},
+			}
+		}
+
+		reconcileChange := func(dc *freezeoperatorv1alpha1.DeferredChange) *freezeoperatorv1alpha1.DeferredChange {
+			controllerReconciler := &DeferredChangeReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			key := types.NamespacedName{Namespace: dc.Namespace, Name: dc.Name}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := &freezeoperatorv1alpha1.DeferredChange{}
+			Expect(k8sClient.Get(ctx, key, updated)).To(Succeed())
+			return updated
+		}
+
+		BeforeEach(func() {
+			By("creating the target Deployment")
+			Expect(k8sClient.Create(ctx, deployment("nginx:1.26"))).To(Succeed())
+		})
+
+		AfterEach(func() {
+			By("Cleanup the target Deployment")
+			Expect(k8sClient.Delete(ctx, deployment(""))).To(Succeed())
+		})
+
+		It("should apply the change when no policy denies it", func() {
+			live := &appsv1.Deployment{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: workloadName}, live)).To(Succeed())
+
+			dc := deferredChange("deferred-apply", live.Generation)
+			Expect(k8sClient.Create(ctx, dc)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(ctx, dc)).To(Succeed()) })
+
+			updated := reconcileChange(dc)
+			Expect(updated.Status.Phase).To(Equal(freezeoperatorv1alpha1.DeferredChangePhaseApplied))
+			Expect(updated.Status.AppliedTime).NotTo(BeNil())
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: workloadName}, live)).To(Succeed())
+			Expect(live.Spec.Template.Spec.Containers[0].Image).To(Equal("nginx:1.27"))
+		})
+
+		It("should report a conflict when the Deployment changed in the meantime", func() {
+			dc := deferredChange("deferred-conflict", 42)
+			Expect(k8sClient.Create(ctx, dc)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(ctx, dc)).To(Succeed()) })
+
+			updated := reconcileChange(dc)
+			Expect(updated.Status.Phase).To(Equal(freezeoperatorv1alpha1.DeferredChangePhaseConflict))
+			Expect(updated.Status.Message).To(ContainSubstring("changed in the meantime"))
+
+			live := &appsv1.Deployment{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: workloadName}, live)).To(Succeed())
+			Expect(live.Spec.Template.Spec.Containers[0].Image).To(Equal("nginx:1.26"))
+		})
+	})
+})
@@ -0,0 +1,192 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+)
+
+func newReplayDeployment(image string, replicas int32, labels map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: testCronNS, Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(replicas),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "api"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "api", Image: image}}},
+			},
+		},
+	}
+}
+
+// newReplayChange defers the update of base to desired.
+func newReplayChange(t *testing.T, name string, base, desired *appsv1.Deployment) *freezeoperatorv1alpha1.DeferredChange {
+	t.Helper()
+	raw := func(d *appsv1.Deployment) []byte {
+		b, err := json.Marshal(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	return &freezeoperatorv1alpha1.DeferredChange{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testCronNS, CreationTimestamp: metav1.Now()},
+		Spec: freezeoperatorv1alpha1.DeferredChangeSpec{
+			Kind:           freezeoperatorv1alpha1.TargetKindDeployment,
+			Name:           "api",
+			Operation:      freezeoperatorv1alpha1.DeferredOperationUpdate,
+			Action:         freezeoperatorv1alpha1.ActionRollout,
+			Object:         runtime.RawExtension{Raw: raw(desired)},
+			BaseObject:     &runtime.RawExtension{Raw: raw(base)},
+			Requester:      freezeoperatorv1alpha1.DeferredChangeRequester{Username: "alice"},
+			BlockingPolicy: freezeoperatorv1alpha1.DeferredChangePolicyRef{Kind: "ChangeFreeze", Name: "ended"},
+		},
+	}
+}
+
+func reconcileReplay(t *testing.T, c client.Client, dc *freezeoperatorv1alpha1.DeferredChange) *freezeoperatorv1alpha1.DeferredChange {
+	t.Helper()
+	ctx := context.Background()
+	r := &DeferredChangeReconciler{Client: c, Scheme: c.Scheme()}
+	if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dc)}); err != nil {
+		t.Fatal(err)
+	}
+	got := &freezeoperatorv1alpha1.DeferredChange{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(dc), got); err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+func TestDeferredChange_ReplaysOverAutoscaledDeployment(t *testing.T) {
+	ctx := context.Background()
+	base := newReplayDeployment("api:1.0", 2, map[string]string{"team": "a", "tier": "web"})
+	desired := newReplayDeployment("api:1.1", 2, map[string]string{"team": "a"})
+	// An autoscaler scaled the Deployment after the change was deferred.
+	live := newReplayDeployment("api:1.0", 6, map[string]string{"team": "a", "tier": "web"})
+	live.Generation = 7
+	dc := newReplayChange(t, "api-1", base, desired)
+	dc.Spec.BaseGeneration = 5
+	c := newFakeClient(newCronNamespace(), live, dc)
+
+	got := reconcileReplay(t, c, dc)
+	if got.Status.Phase != freezeoperatorv1alpha1.DeferredChangePhaseApplied {
+		t.Fatalf("expected Applied, got %s: %s", got.Status.Phase, got.Status.Message)
+	}
+
+	if err := c.Get(ctx, client.ObjectKeyFromObject(live), live); err != nil {
+		t.Fatal(err)
+	}
+	if img := live.Spec.Template.Spec.Containers[0].Image; img != "api:1.1" {
+		t.Errorf("expected the new image, got %s", img)
+	}
+	if *live.Spec.Replicas != 6 {
+		t.Errorf("the replicas the autoscaler set should be kept, got %d", *live.Spec.Replicas)
+	}
+	if _, ok := live.Labels["tier"]; ok {
+		t.Errorf("the label the change removed should be removed, got %v", live.Labels)
+	}
+}
+
+func TestDeferredChange_ConflictOnTouchedField(t *testing.T) {
+	ctx := context.Background()
+	base := newReplayDeployment("api:1.0", 2, nil)
+	desired := newReplayDeployment("api:1.1", 2, nil)
+	live := newReplayDeployment("api:1.2", 2, nil)
+	dc := newReplayChange(t, "api-1", base, desired)
+	c := newFakeClient(newCronNamespace(), live, dc)
+
+	got := reconcileReplay(t, c, dc)
+	if got.Status.Phase != freezeoperatorv1alpha1.DeferredChangePhaseConflict {
+		t.Fatalf("expected Conflict, got %s: %s", got.Status.Phase, got.Status.Message)
+	}
+	if !strings.Contains(got.Status.Message, ".spec.template.spec.containers") {
+		t.Errorf("expected the conflicting field in the message, got %q", got.Status.Message)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(live), live); err != nil {
+		t.Fatal(err)
+	}
+	if img := live.Spec.Template.Spec.Containers[0].Image; img != "api:1.2" {
+		t.Errorf("the Deployment should not change, got %s", img)
+	}
+}
+
+func TestDeferredChange_RecordsExceptionUse(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	cf := &freezeoperatorv1alpha1.ChangeFreeze{
+		ObjectMeta: metav1.ObjectMeta{Name: "holiday"},
+		Spec: freezeoperatorv1alpha1.ChangeFreezeSpec{
+			StartTime: metav1.NewTime(now.Add(-time.Hour)),
+			EndTime:   metav1.NewTime(now.Add(time.Hour)),
+			Target:    *cronTarget(nil),
+			Rules:     freezeoperatorv1alpha1.PolicyRulesSpec{Deny: []freezeoperatorv1alpha1.Action{freezeoperatorv1alpha1.ActionRollout}},
+		},
+	}
+	cf.Spec.Target.Kinds = []freezeoperatorv1alpha1.TargetKind{freezeoperatorv1alpha1.TargetKindDeployment}
+	ex := &freezeoperatorv1alpha1.FreezeException{
+		ObjectMeta: metav1.ObjectMeta{Name: "hotfix"},
+		Spec: freezeoperatorv1alpha1.FreezeExceptionSpec{
+			ActiveFrom: metav1.NewTime(now.Add(-time.Hour)),
+			ActiveTo:   metav1.NewTime(now.Add(time.Hour)),
+			Target:     cf.Spec.Target.DeepCopy(),
+			Allow:      []freezeoperatorv1alpha1.Action{freezeoperatorv1alpha1.ActionRollout},
+			Reason:     "hotfix",
+			MaxUses:    1,
+		},
+	}
+	base := newReplayDeployment("api:1.0", 2, nil)
+	first := newReplayChange(t, "api-1", base, newReplayDeployment("api:1.1", 2, nil))
+	c := newFakeClient(newCronNamespace(), base.DeepCopy(), cf, ex, first)
+
+	got := reconcileReplay(t, c, first)
+	if got.Status.Phase != freezeoperatorv1alpha1.DeferredChangePhaseApplied {
+		t.Fatalf("expected Applied, got %s: %s", got.Status.Phase, got.Status.Message)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(ex), ex); err != nil {
+		t.Fatal(err)
+	}
+	if ex.Status.Usage.Count != 1 || ex.Status.Usage.LastUser != "alice" {
+		t.Fatalf("expected one use by alice, got %+v", ex.Status.Usage)
+	}
+
+	// The exception is spent, so a second change waits for the freeze to end.
+	second := newReplayChange(t, "api-2", newReplayDeployment("api:1.1", 2, nil), newReplayDeployment("api:1.2", 2, nil))
+	if err := c.Create(ctx, second); err != nil {
+		t.Fatal(err)
+	}
+	got = reconcileReplay(t, c, second)
+	if got.Status.Phase != freezeoperatorv1alpha1.DeferredChangePhasePending {
+		t.Errorf("expected Pending, got %s: %s", got.Status.Phase, got.Status.Message)
+	}
+}
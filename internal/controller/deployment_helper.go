@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/hold"
+)
+
+const (
+	// Annotation to track the original paused state before operator interference
+	annotationOriginalPaused = "freeze-operator.io/original-paused"
+
+	reasonDeploymentsUpdated   = "DeploymentsUpdated"
+	reasonDeploymentUpdateFail = "DeploymentUpdateFailed"
+)
+
+// updateDeploymentsForPolicy moves every Deployment towards the state the policy
+// wants: held paused by holder when shouldPause is set and the Deployment is
+// selected by target, released otherwise. Deployments the policy holds but no
+// longer selects are released too, as CronJobs are. It returns the number of
+// Deployments updated.
+func updateDeploymentsForPolicy(ctx context.Context, c client.Client, target *freezeoperatorv1alpha1.TargetSpec, holder string, shouldPause bool) (int, error) {
+	var nsList corev1.NamespaceList
+	if err := c.List(ctx, &nsList); err != nil {
+		return 0, fmt.Errorf("list namespaces: %w", err)
+	}
+	nsLabels := make(map[string]map[string]string, len(nsList.Items))
+	for _, ns := range nsList.Items {
+		nsLabels[ns.Name] = ns.Labels
+	}
+
+	var depList appsv1.DeploymentList
+	if err := c.List(ctx, &depList); err != nil {
+		return 0, fmt.Errorf("list deployments: %w", err)
+	}
+
+	targeted := slices.Contains(target.Kinds, freezeoperatorv1alpha1.TargetKindDeployment)
+	changed := 0
+	var errs []error
+	for i := range depList.Items {
+		dep := &depList.Items[i]
+		lbls, nsExists := nsLabels[dep.Namespace]
+		selected := targeted && nsExists &&
+			matchesNamespaceSelector(target.NamespaceSelector, lbls) &&
+			matchesObjectSelector(target.ObjectSelector, dep.Labels)
+
+		if !reconcileDeployment(dep, holder, shouldPause && selected) {
+			continue
+		}
+		if err := c.Update(ctx, dep); err != nil {
+			errs = append(errs, fmt.Errorf("update deployment %s/%s: %w", dep.Namespace, dep.Name, err))
+			continue
+		}
+		changed++
+	}
+
+	return changed, errors.Join(errs...)
+}
+
+// reconcileDeployment is the pause/restore state machine of a single Deployment,
+// following the rules of reconcileCronJob. It reports whether dep changed.
+//
+//   - pause, not yet held by holder: holder joins the holders. The first holder saves
+//     the original paused state. The Deployment is paused.
+//   - pause, already held by holder: nothing to do. A manual unpause during the
+//     freeze is left alone.
+//   - release, held by holder: holder leaves the holders. The last holder restores
+//     the original paused state and removes the annotations. If the Deployment is
+//     no longer paused, someone unpaused it manually and it is left as it is.
+//   - release, not held by holder: nothing to do.
+func reconcileDeployment(dep *appsv1.Deployment, holder string, pause bool) bool {
+	if dep.Annotations == nil {
+		dep.Annotations = make(map[string]string)
+	}
+
+	held := hold.HeldBy(dep.Annotations, holder)
+	switch {
+	case pause && !held:
+		first, _ := hold.Add(dep.Annotations, holder)
+		if first {
+			// First policy to hold this Deployment - save original state
+			dep.Annotations[annotationOriginalPaused] = strconv.FormatBool(dep.Spec.Paused)
+		}
+		dep.Spec.Paused = true
+		return true
+
+	case pause && held:
+		// Rewrites a legacy managed-by owner as a qualified holder.
+		_, changed := hold.Add(dep.Annotations, holder)
+		return changed
+
+	case !pause && held:
+		if _, last := hold.Remove(dep.Annotations, holder); !last {
+			return true
+		}
+		original, _ := strconv.ParseBool(dep.Annotations[annotationOriginalPaused])
+		if dep.Spec.Paused {
+			dep.Spec.Paused = original
+		}
+		delete(dep.Annotations, annotationOriginalPaused)
+		return true
+
+	default:
+		return false
+	}
+}
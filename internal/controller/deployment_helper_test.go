@@ -0,0 +1,218 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/hold"
+)
+
+// ---------------------------------------------------------------------------
+// helpers
+// ---------------------------------------------------------------------------
+
+const testDeploymentName = "app"
+
+func newDeployment(paused bool, labels, annotations map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: testDeploymentName, Namespace: testCronNS, Labels: labels, Annotations: annotations},
+		Spec:       appsv1.DeploymentSpec{Paused: paused},
+	}
+}
+
+func deploymentTarget(objectSelector *metav1.LabelSelector) *freezeoperatorv1alpha1.TargetSpec {
+	target := cronTarget(objectSelector)
+	target.Kinds = []freezeoperatorv1alpha1.TargetKind{freezeoperatorv1alpha1.TargetKindDeployment}
+	return target
+}
+
+func getDeployment(ctx context.Context, c client.Client) *appsv1.Deployment {
+	dep := &appsv1.Deployment{}
+	_ = c.Get(ctx, client.ObjectKey{Name: testDeploymentName, Namespace: testCronNS}, dep)
+	return dep
+}
+
+func mustUpdateDeployments(t *testing.T, c client.Client, target *freezeoperatorv1alpha1.TargetSpec, holder string, pause bool) int {
+	t.Helper()
+	changed, err := updateDeploymentsForPolicy(context.Background(), c, target, holder, pause)
+	if err != nil {
+		t.Fatalf("updateDeploymentsForPolicy(%s, pause=%v): %v", holder, pause, err)
+	}
+	return changed
+}
+
+// ---------------------------------------------------------------------------
+// state machine tests
+// ---------------------------------------------------------------------------
+
+func TestReconcileDeployment_PauseAndRestore(t *testing.T) {
+	dep := newDeployment(false, nil, nil)
+	if !reconcileDeployment(dep, testHoliday, true) || !dep.Spec.Paused {
+		t.Fatal("expected the Deployment to be paused")
+	}
+	if dep.Annotations[annotationOriginalPaused] != "false" || dep.Annotations[hold.AnnotationHeldBy] != testHoliday {
+		t.Errorf("unexpected annotations %v", dep.Annotations)
+	}
+	if reconcileDeployment(dep, testHoliday, true) {
+		t.Error("pausing again should not change the Deployment")
+	}
+
+	if !reconcileDeployment(dep, testHoliday, false) || dep.Spec.Paused {
+		t.Fatal("expected the Deployment to be unpaused")
+	}
+	for _, a := range []string{hold.AnnotationHeldBy, annotationOriginalPaused} {
+		if _, ok := dep.Annotations[a]; ok {
+			t.Errorf("annotation %s should be removed on restore", a)
+		}
+	}
+}
+
+func TestReconcileDeployment_PausedBeforeFreeze(t *testing.T) {
+	dep := newDeployment(true, nil, nil)
+	reconcileDeployment(dep, testHoliday, true)
+	reconcileDeployment(dep, testHoliday, false)
+	if !dep.Spec.Paused {
+		t.Error("a Deployment paused before the freeze must stay paused")
+	}
+}
+
+func TestReconcileDeployment_OverlappingPolicies(t *testing.T) {
+	dep := newDeployment(false, nil, nil)
+	reconcileDeployment(dep, testHoliday, true)
+	reconcileDeployment(dep, testWeekdays, true)
+	if dep.Annotations[hold.AnnotationHeldBy] != testHoliday+","+testWeekdays {
+		t.Errorf("expected both holders, got %q", dep.Annotations[hold.AnnotationHeldBy])
+	}
+
+	reconcileDeployment(dep, testHoliday, false)
+	if !dep.Spec.Paused || dep.Annotations[hold.AnnotationHeldBy] != testWeekdays {
+		t.Errorf("the Deployment must stay paused while another policy holds it, got %v", dep.Annotations)
+	}
+	reconcileDeployment(dep, testWeekdays, false)
+	if dep.Spec.Paused {
+		t.Error("the Deployment should be unpaused when the last holder releases it")
+	}
+}
+
+func TestReconcileDeployment_ManualUnpauseLeftAlone(t *testing.T) {
+	dep := newDeployment(false, nil, nil)
+	reconcileDeployment(dep, testHoliday, true)
+	dep.Spec.Paused = false
+
+	if reconcileDeployment(dep, testHoliday, true) || dep.Spec.Paused {
+		t.Error("a Deployment unpaused during the freeze must not be paused again")
+	}
+	if !reconcileDeployment(dep, testHoliday, false) || dep.Spec.Paused {
+		t.Error("releasing should only remove the annotations")
+	}
+	if _, ok := dep.Annotations[hold.AnnotationHeldBy]; ok {
+		t.Error("held-by should be removed on release")
+	}
+}
+
+func TestReconcileDeployment_NotHeld(t *testing.T) {
+	dep := newDeployment(true, nil, nil)
+	if reconcileDeployment(dep, testHoliday, false) || !dep.Spec.Paused {
+		t.Error("a Deployment the policy never paused must not change")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// updateDeploymentsForPolicy
+// ---------------------------------------------------------------------------
+
+func TestUpdateDeployments_CountsChanges(t *testing.T) {
+	c := newFakeClient(newCronNamespace(), newDeployment(false, nil, nil))
+
+	if n := mustUpdateDeployments(t, c, deploymentTarget(nil), testHoliday, true); n != 1 {
+		t.Errorf("expected 1 change, got %d", n)
+	}
+	if n := mustUpdateDeployments(t, c, deploymentTarget(nil), testHoliday, true); n != 0 {
+		t.Errorf("expected no change on the second pass, got %d", n)
+	}
+	if !getDeployment(context.Background(), c).Spec.Paused {
+		t.Error("expected the Deployment to be paused")
+	}
+}
+
+func TestUpdateDeployments_ReleasesUnselected(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeClient(newCronNamespace(), newDeployment(false, map[string]string{"tier": "web"}, nil))
+	web := deploymentTarget(&metav1.LabelSelector{MatchLabels: map[string]string{"tier": "web"}})
+	mustUpdateDeployments(t, c, web, testHoliday, true)
+
+	// The Deployment is relabelled out of the policy's scope.
+	dep := getDeployment(ctx, c)
+	dep.Labels = map[string]string{"tier": "batch"}
+	if err := c.Update(ctx, dep); err != nil {
+		t.Fatal(err)
+	}
+	if n := mustUpdateDeployments(t, c, web, testHoliday, true); n != 1 {
+		t.Errorf("expected the held Deployment to be released, got %d changes", n)
+	}
+	if getDeployment(ctx, c).Spec.Paused {
+		t.Error("a Deployment the policy no longer selects should be unpaused")
+	}
+}
+
+func TestChangeFreeze_PauseDeploymentsDisabledMidFreeze(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	cf := &freezeoperatorv1alpha1.ChangeFreeze{
+		ObjectMeta: metav1.ObjectMeta{Name: "holiday"},
+		Spec: freezeoperatorv1alpha1.ChangeFreezeSpec{
+			StartTime: metav1.NewTime(now.Add(-time.Hour)),
+			EndTime:   metav1.NewTime(now.Add(time.Hour)),
+			Target:    *deploymentTarget(nil),
+			// pauseDeployments was turned off after the Deployment was paused.
+		},
+	}
+	held := newDeployment(true, nil, map[string]string{hold.AnnotationHeldBy: testHoliday, annotationOriginalPaused: "false"})
+	c := newFakeClient(newCronNamespace(), cf, held)
+	recorder := record.NewFakeRecorder(50)
+	r := &ChangeFreezeReconciler{Client: c, Scheme: c.Scheme(), Recorder: recorder}
+
+	for range 2 {
+		if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKey{Name: "holiday"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if getDeployment(ctx, c).Spec.Paused {
+		t.Error("expected the Deployment to be unpaused")
+	}
+
+	updated := 0
+	for len(recorder.Events) > 0 {
+		if strings.Contains(<-recorder.Events, reasonDeploymentsUpdated) {
+			updated++
+		}
+	}
+	if updated != 1 {
+		t.Errorf("expected one %s Event, got %d", reasonDeploymentsUpdated, updated)
+	}
+}
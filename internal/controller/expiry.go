@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+)
+
+const reasonTTLExpired = "TTLExpired"
+
+// policyPhase returns the phase of a policy in effect from start until end.
+// timeUntilStartRefresh bounds the requeue of scheduled policies so that
+// status.timeUntilStart, printed by kubectl get, stays current.
+const timeUntilStartRefresh = time.Minute
+
+func policyPhase(now, start, end time.Time) freezeoperatorv1alpha1.PolicyPhase {
+	switch {
+	case now.Before(start):
+		return freezeoperatorv1alpha1.PolicyPhaseScheduled
+	case now.Before(end):
+		return freezeoperatorv1alpha1.PolicyPhaseActive
+	default:
+		return freezeoperatorv1alpha1.PolicyPhaseExpired
+	}
+}
+
+// setPolicyPhase records the phase of a policy in effect from start until end,
+// and the time until it starts while it is scheduled. lastTransitionTime only
+// moves when the phase changes; activation and expiry are dated from start and
+// end rather than from when the change was observed.
+func setPolicyPhase(
+	phase *freezeoperatorv1alpha1.PolicyPhase,
+	timeUntilStart **metav1.Duration,
+	lastTransitionTime **metav1.Time,
+	now, start, end time.Time,
+) {
+	next := policyPhase(now, start, end)
+	if *phase != next || *lastTransitionTime == nil {
+		at := now
+		switch next {
+		case freezeoperatorv1alpha1.PolicyPhaseActive:
+			at = start
+		case freezeoperatorv1alpha1.PolicyPhaseExpired:
+			at = end
+		}
+		t := metav1.NewTime(at)
+		*lastTransitionTime = &t
+	}
+	*phase = next
+
+	*timeUntilStart = nil
+	if next == freezeoperatorv1alpha1.PolicyPhaseScheduled {
+		*timeUntilStart = &metav1.Duration{Duration: start.Sub(now).Round(time.Second)}
+	}
+}
+
+// collectExpired deletes obj, which expired at end, once ttl has passed since.
+// Otherwise it returns how long until then; it returns zero when ttl is unset.
+// dependents, if set, is called first to delete the objects that refer to obj
+// but are not owned by it.
+func collectExpired(
+	ctx context.Context,
+	c client.Client,
+	recorder record.EventRecorder,
+	obj client.Object,
+	ttl *metav1.Duration,
+	end, now time.Time,
+	dependents func(context.Context) error,
+) (time.Duration, error) {
+	if ttl == nil {
+		return 0, nil
+	}
+	if remaining := end.Add(max(ttl.Duration, 0)).Sub(now); remaining > 0 {
+		return remaining + time.Second, nil
+	}
+
+	if dependents != nil {
+		if err := dependents(ctx); err != nil {
+			return 0, fmt.Errorf("delete dependents of expired %s: %w", obj.GetName(), err)
+		}
+	}
+
+	if recorder != nil {
+		recorder.Event(obj, corev1.EventTypeNormal, reasonTTLExpired,
+			fmt.Sprintf("Deleting, expired at %s and ttlAfterExpired is %s", end.UTC().Format(time.RFC3339), ttl.Duration))
+	}
+	if err := c.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+		return 0, fmt.Errorf("delete expired %s: %w", obj.GetName(), err)
+	}
+	return 0, nil
+}
+
+// hooksRunning reports whether a hook Job launched for the policy is still running.
+func hooksRunning(statuses []freezeoperatorv1alpha1.HookStatus) bool {
+	return slices.ContainsFunc(statuses, func(st freezeoperatorv1alpha1.HookStatus) bool {
+		return st.Phase == freezeoperatorv1alpha1.HookPhaseRunning
+	})
+}
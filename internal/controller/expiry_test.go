@@ -0,0 +1,213 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+)
+
+func TestSetPolicyPhase(t *testing.T) {
+	start := time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC)
+	end := start.Add(72 * time.Hour)
+
+	var (
+		phase          freezeoperatorv1alpha1.PolicyPhase
+		timeUntilStart *metav1.Duration
+		lastTransition *metav1.Time
+	)
+
+	created := start.Add(-2 * time.Hour)
+	setPolicyPhase(&phase, &timeUntilStart, &lastTransition, created, start, end)
+	if phase != freezeoperatorv1alpha1.PolicyPhaseScheduled || timeUntilStart == nil || timeUntilStart.Duration != 2*time.Hour {
+		t.Fatalf("expected Scheduled 2h before start, got %s %v", phase, timeUntilStart)
+	}
+	if !lastTransition.Time.Equal(created) {
+		t.Errorf("expected the first evaluation to set lastTransitionTime, got %v", lastTransition)
+	}
+
+	setPolicyPhase(&phase, &timeUntilStart, &lastTransition, start.Add(-time.Hour), start, end)
+	if !lastTransition.Time.Equal(created) {
+		t.Errorf("expected lastTransitionTime to stay while Scheduled, got %v", lastTransition)
+	}
+
+	setPolicyPhase(&phase, &timeUntilStart, &lastTransition, start.Add(time.Minute), start, end)
+	if phase != freezeoperatorv1alpha1.PolicyPhaseActive || timeUntilStart != nil || !lastTransition.Time.Equal(start) {
+		t.Errorf("expected Active since start, got %s %v %v", phase, timeUntilStart, lastTransition)
+	}
+
+	setPolicyPhase(&phase, &timeUntilStart, &lastTransition, end.Add(time.Hour), start, end)
+	if phase != freezeoperatorv1alpha1.PolicyPhaseExpired || !lastTransition.Time.Equal(end) {
+		t.Errorf("expected Expired since end, got %s %v", phase, lastTransition)
+	}
+}
+
+func TestChangeFreeze_ScheduledRefreshesTimeUntilStart(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	cf := &freezeoperatorv1alpha1.ChangeFreeze{
+		ObjectMeta: metav1.ObjectMeta{Name: "holiday", Finalizers: []string{policyFinalizer}},
+		Spec: freezeoperatorv1alpha1.ChangeFreezeSpec{
+			StartTime: metav1.NewTime(now.Add(3 * time.Hour)),
+			EndTime:   metav1.NewTime(now.Add(5 * time.Hour)),
+		},
+	}
+	c := newFakeClient(cf)
+	r := &ChangeFreezeReconciler{Client: c, Scheme: c.Scheme(), Recorder: record.NewFakeRecorder(50)}
+	req := reconcile.Request{NamespacedName: client.ObjectKey{Name: "holiday"}}
+
+	res, err := r.Reconcile(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.RequeueAfter <= 0 || res.RequeueAfter > timeUntilStartRefresh {
+		t.Errorf("expected a requeue within %s to refresh timeUntilStart, got %s", timeUntilStartRefresh, res.RequeueAfter)
+	}
+	if err := c.Get(ctx, req.NamespacedName, cf); err != nil {
+		t.Fatal(err)
+	}
+	if d := cf.Status.TimeUntilStart; d == nil || d.Duration <= 2*time.Hour || d.Duration > 3*time.Hour {
+		t.Errorf("expected about 3h until start, got %v", d)
+	}
+}
+
+func newExpiredChangeFreeze(ttl time.Duration) *freezeoperatorv1alpha1.ChangeFreeze {
+	now := time.Now()
+	return &freezeoperatorv1alpha1.ChangeFreeze{
+		ObjectMeta: metav1.ObjectMeta{Name: "holiday", Finalizers: []string{policyFinalizer}},
+		Spec: freezeoperatorv1alpha1.ChangeFreezeSpec{
+			StartTime:       metav1.NewTime(now.Add(-3 * time.Hour)),
+			EndTime:         metav1.NewTime(now.Add(-time.Hour)),
+			TTLAfterExpired: &metav1.Duration{Duration: ttl},
+		},
+	}
+}
+
+func TestChangeFreeze_TTLAfterExpired(t *testing.T) {
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: client.ObjectKey{Name: "holiday"}}
+
+	// Expired for an hour with a day to live: kept, and requeued for the deadline
+	c := newFakeClient(newExpiredChangeFreeze(24 * time.Hour))
+	r := &ChangeFreezeReconciler{Client: c, Scheme: c.Scheme(), Recorder: record.NewFakeRecorder(50)}
+	res, err := r.Reconcile(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cf freezeoperatorv1alpha1.ChangeFreeze
+	if err := c.Get(ctx, req.NamespacedName, &cf); err != nil {
+		t.Fatal(err)
+	}
+	if !cf.DeletionTimestamp.IsZero() {
+		t.Error("expected the freeze to be kept until its TTL passed")
+	}
+	if cf.Status.Phase != freezeoperatorv1alpha1.PolicyPhaseExpired {
+		t.Errorf("expected phase Expired, got %q", cf.Status.Phase)
+	}
+	if res.RequeueAfter > 10*time.Minute {
+		t.Errorf("expected the usual requeue, got %s", res.RequeueAfter)
+	}
+
+	// Past its TTL: deleted
+	c = newFakeClient(newExpiredChangeFreeze(time.Minute))
+	r = &ChangeFreezeReconciler{Client: c, Scheme: c.Scheme(), Recorder: record.NewFakeRecorder(50)}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, &cf); err != nil {
+		t.Fatal(err)
+	}
+	if cf.DeletionTimestamp.IsZero() {
+		t.Error("expected the freeze to be deleted once its TTL passed")
+	}
+}
+
+func TestChangeFreeze_TTLWaitsForHooks(t *testing.T) {
+	ctx := context.Background()
+	cf := newExpiredChangeFreeze(0)
+	started := metav1.Now()
+	cf.Status.ObservedGeneration = 1
+	cf.Status.Hooks = []freezeoperatorv1alpha1.HookStatus{{
+		Type:           freezeoperatorv1alpha1.HookTypeOnDeactivate,
+		TransitionTime: cf.Spec.EndTime,
+		JobNamespace:   testHookNS,
+		JobName:        "holiday-deactivate-00000000",
+		StartTime:      &started,
+		Phase:          freezeoperatorv1alpha1.HookPhaseRunning,
+	}}
+	c := newFakeClient(cf)
+	r := &ChangeFreezeReconciler{Client: c, Scheme: c.Scheme(), Recorder: record.NewFakeRecorder(50)}
+	req := reconcile.Request{NamespacedName: client.ObjectKey{Name: "holiday"}}
+
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, cf); err != nil {
+		t.Fatal(err)
+	}
+	if !cf.DeletionTimestamp.IsZero() {
+		t.Error("expected the freeze to be kept while its hook Job runs")
+	}
+}
+
+func TestFreezeException_TTLAfterExpired(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	ex := &freezeoperatorv1alpha1.FreezeException{
+		ObjectMeta: metav1.ObjectMeta{Name: "hotfix"},
+		Spec: freezeoperatorv1alpha1.FreezeExceptionSpec{
+			ActiveFrom:      metav1.NewTime(now.Add(-2 * time.Hour)),
+			ActiveTo:        metav1.NewTime(now.Add(-time.Hour)),
+			Allow:           []freezeoperatorv1alpha1.Action{freezeoperatorv1alpha1.ActionRollout},
+			Reason:          "hotfix",
+			TTLAfterExpired: &metav1.Duration{Duration: 30 * time.Minute},
+		},
+	}
+	approval := func(name, exception string) *freezeoperatorv1alpha1.FreezeExceptionApproval {
+		return &freezeoperatorv1alpha1.FreezeExceptionApproval{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: freezeoperatorv1alpha1.FreezeExceptionApprovalSpec{
+				ExceptionName: exception, ExceptionGeneration: 1, Approver: "alice",
+			},
+		}
+	}
+	c := newFakeClient(ex, approval("hotfix-alice", "hotfix"), approval("other-alice", "other"))
+	r := &FreezeExceptionReconciler{Client: c, Scheme: c.Scheme(), Recorder: record.NewFakeRecorder(50)}
+
+	if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKey{Name: "hotfix"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Get(ctx, client.ObjectKey{Name: "hotfix"}, ex); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the expired exception to be deleted, got phase %q", ex.Status.Phase)
+	}
+	var approvals freezeoperatorv1alpha1.FreezeExceptionApprovalList
+	if err := c.List(ctx, &approvals); err != nil {
+		t.Fatal(err)
+	}
+	if len(approvals.Items) != 1 || approvals.Items[0].Name != "other-alice" {
+		t.Errorf("expected only the approvals of the deleted exception to be deleted, got %+v", approvals.Items)
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+)
+
+var (
+	testFluxKustomizationGVK = schema.GroupVersionKind{Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Kind: "Kustomization"}
+	testScaledObjectGVK      = schema.GroupVersionKind{Group: "keda.sh", Version: "v1alpha1", Kind: "ScaledObject"}
+)
+
+// newTestScheme returns a scheme with the built-in kinds the controllers manage,
+// the freeze-operator API, Flux Kustomizations and KEDA ScaledObjects.
+func newTestScheme() *runtime.Scheme {
+	s := runtime.NewScheme()
+	_ = corev1.AddToScheme(s)
+	_ = appsv1.AddToScheme(s)
+	_ = batchv1.AddToScheme(s)
+	_ = autoscalingv2.AddToScheme(s)
+	_ = freezeoperatorv1alpha1.AddToScheme(s)
+	s.AddKnownTypeWithName(testFluxKustomizationGVK.GroupVersion().WithKind("KustomizationList"), &unstructured.UnstructuredList{})
+	s.AddKnownTypeWithName(testScaledObjectGVK.GroupVersion().WithKind("ScaledObjectList"), &unstructured.UnstructuredList{})
+	return s
+}
+
+// newFakeClientBuilder returns a fake client builder holding objs. As in the API
+// server, status is a subresource of the freeze-operator kinds that have one.
+func newFakeClientBuilder(objs ...client.Object) *fake.ClientBuilder {
+	return fake.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(objs...).
+		WithStatusSubresource(
+			&freezeoperatorv1alpha1.ChangeFreeze{},
+			&freezeoperatorv1alpha1.MaintenanceWindow{},
+			&freezeoperatorv1alpha1.FreezeException{},
+			&freezeoperatorv1alpha1.FreezeReport{},
+			&freezeoperatorv1alpha1.DeferredChange{},
+		)
+}
+
+// newFakeClient returns a fake client holding objs.
+func newFakeClient(objs ...client.Object) client.Client {
+	return newFakeClientBuilder(objs...).Build()
+}
@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/autoscaling"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/gitops"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/metrics"
+)
+
+const (
+	// policyFinalizer keeps a deleted policy around until everything it suspended
+	// or paused has been restored.
+	policyFinalizer = "freeze-operator.io/release-side-effects"
+
+	conditionTypeTerminating = "Terminating"
+
+	reasonReleasingSideEffects = "ReleasingSideEffects"
+	reasonSideEffectsReleased  = "SideEffectsReleased"
+	reasonReleaseFailed        = "ReleaseFailed"
+)
+
+var (
+	// releaseTarget selects every object of the kinds a policy may have changed, so
+	// that releasing with it also reaches objects the policy no longer selects.
+	// Only objects held by the releasing policy are touched.
+	releaseTarget = freezeoperatorv1alpha1.TargetSpec{
+		Kinds: []freezeoperatorv1alpha1.TargetKind{
+			freezeoperatorv1alpha1.TargetKindDeployment,
+			freezeoperatorv1alpha1.TargetKindStatefulSet,
+			freezeoperatorv1alpha1.TargetKindCronJob,
+		},
+	}
+
+	// releaseGitOps selects every Argo CD and Flux object in the same way.
+	releaseGitOps = freezeoperatorv1alpha1.GitOpsSpec{
+		Enabled: true,
+		Providers: []freezeoperatorv1alpha1.GitOpsProvider{
+			freezeoperatorv1alpha1.GitOpsProviderArgoCD,
+			freezeoperatorv1alpha1.GitOpsProviderFlux,
+		},
+		ArgoCD: &freezeoperatorv1alpha1.GitOpsArgoCDSpec{},
+		Flux:   &freezeoperatorv1alpha1.GitOpsFluxSpec{},
+	}
+)
+
+// releasePolicy restores every CronJob, Deployment, autoscaler and GitOps object
+// the policy holds. It returns the number of CronJobs it changed.
+func releasePolicy(ctx context.Context, c client.Client, holder string) (int, error) {
+	var errs []error
+
+	cronResult, err := updateCronJobsForPolicy(ctx, c, &releaseTarget, holder, false)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("cronjobs: %w", err))
+	}
+	if _, err := updateDeploymentsForPolicy(ctx, c, &releaseTarget, holder, false); err != nil {
+		errs = append(errs, fmt.Errorf("deployments: %w", err))
+	}
+	ar := &autoscaling.Reconciler{Client: c}
+	if _, err := ar.Reconcile(ctx, &releaseTarget, holder, false); err != nil {
+		errs = append(errs, fmt.Errorf("autoscalers: %w", err))
+	}
+	gr := &gitops.Reconciler{Client: c}
+	if _, err := gr.Reconcile(ctx, &releaseGitOps, holder, false); err != nil {
+		errs = append(errs, fmt.Errorf("gitops: %w", err))
+	}
+
+	return cronResult.Changed, errors.Join(errs...)
+}
+
+// finalizePolicy handles a policy being deleted. It reports progress through a
+// Terminating condition and Events, releases the policy's side effects and
+// removes the finalizer once they are all restored. On error the finalizer is
+// kept and the caller should retry.
+func finalizePolicy(
+	ctx context.Context,
+	c client.Client,
+	recorder record.EventRecorder,
+	obj client.Object,
+	conditions *[]metav1.Condition,
+	policyType, holder string,
+) error {
+	if !controllerutil.ContainsFinalizer(obj, policyFinalizer) {
+		return nil
+	}
+
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	if meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               conditionTypeTerminating,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: obj.GetGeneration(),
+		Reason:             reasonReleasingSideEffects,
+		Message:            "Restoring CronJobs, Deployments, autoscalers and GitOps objects held by this policy",
+	}) {
+		if err := c.Status().Patch(ctx, obj, patch); err != nil {
+			return err
+		}
+		if recorder != nil {
+			recorder.Event(obj, corev1.EventTypeNormal, reasonReleasingSideEffects,
+				"Policy deleted, restoring the objects it suspended or paused")
+		}
+	}
+
+	cronJobs, err := releasePolicy(ctx, c, holder)
+	if err != nil {
+		patch = client.MergeFrom(obj.DeepCopyObject().(client.Object))
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               conditionTypeTerminating,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: obj.GetGeneration(),
+			Reason:             reasonReleaseFailed,
+			Message:            err.Error(),
+		})
+		if patchErr := c.Status().Patch(ctx, obj, patch); patchErr != nil {
+			err = errors.Join(err, patchErr)
+		}
+		if recorder != nil {
+			recorder.Event(obj, corev1.EventTypeWarning, reasonReleaseFailed, err.Error())
+		}
+		return err
+	}
+
+	if recorder != nil {
+		recorder.Event(obj, corev1.EventTypeNormal, reasonSideEffectsReleased,
+			fmt.Sprintf("Restored everything held by this policy (cronjobs updated=%d)", cronJobs))
+	}
+
+	labels := prometheus.Labels{"policy_type": policyType, "policy_name": obj.GetName()}
+	metrics.CronJobSuspensions.DeletePartialMatch(labels)
+	metrics.ActiveFreezePolicies.DeletePartialMatch(labels)
+
+	controllerutil.RemoveFinalizer(obj, policyFinalizer)
+	return c.Update(ctx, obj)
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/gitops"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/hold"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/policy"
+)
+
+func TestChangeFreeze_AddsFinalizer(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	cf := &freezeoperatorv1alpha1.ChangeFreeze{
+		ObjectMeta: metav1.ObjectMeta{Name: "holiday"},
+		Spec: freezeoperatorv1alpha1.ChangeFreezeSpec{
+			StartTime: metav1.NewTime(now.Add(-time.Hour)),
+			EndTime:   metav1.NewTime(now.Add(time.Hour)),
+		},
+	}
+	c := newFakeClient(cf)
+
+	r := &ChangeFreezeReconciler{Client: c, Scheme: c.Scheme()}
+	if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKey{Name: "holiday"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Get(ctx, client.ObjectKey{Name: "holiday"}, cf); err != nil {
+		t.Fatal(err)
+	}
+	if len(cf.Finalizers) != 1 || cf.Finalizers[0] != policyFinalizer {
+		t.Errorf("expected finalizer %q, got %v", policyFinalizer, cf.Finalizers)
+	}
+}
+
+func TestChangeFreeze_DeletionReleasesCronJobs(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	cf := &freezeoperatorv1alpha1.ChangeFreeze{
+		ObjectMeta: metav1.ObjectMeta{Name: "holiday", Finalizers: []string{policyFinalizer}},
+		Spec: freezeoperatorv1alpha1.ChangeFreezeSpec{
+			StartTime: metav1.NewTime(now.Add(-time.Hour)),
+			EndTime:   metav1.NewTime(now.Add(time.Hour)),
+			// The policy no longer selects the CronJob it suspended.
+			Target: freezeoperatorv1alpha1.TargetSpec{
+				Kinds: []freezeoperatorv1alpha1.TargetKind{freezeoperatorv1alpha1.TargetKindDeployment},
+			},
+		},
+	}
+	held := newCronJob(testCronJobName, nil, true)
+	held.Annotations = map[string]string{hold.AnnotationHeldBy: testHoliday, annotationOriginalSuspend: "false"}
+	shared := newCronJob(testOtherCronJob, nil, true)
+	shared.Annotations = map[string]string{hold.AnnotationHeldBy: testHoliday + "," + testWeekdays, annotationOriginalSuspend: "false"}
+	c := newFakeClient(newCronNamespace(), cf, held, shared)
+	if err := c.Delete(ctx, cf); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	r := &ChangeFreezeReconciler{Client: c, Scheme: c.Scheme(), Recorder: recorder}
+	if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKey{Name: "holiday"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if isSuspended(getCronJob(ctx, c, testCronJobName)) {
+		t.Error("CronJob held only by the deleted policy should be resumed")
+	}
+	cron := getCronJob(ctx, c, testOtherCronJob)
+	if !isSuspended(cron) || cron.Annotations[hold.AnnotationHeldBy] != testWeekdays {
+		t.Errorf("CronJob also held by another policy should stay suspended, got suspend=%v held-by=%q",
+			isSuspended(cron), cron.Annotations[hold.AnnotationHeldBy])
+	}
+	if err := c.Get(ctx, client.ObjectKey{Name: "holiday"}, cf); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the ChangeFreeze to be gone once the finalizer is removed, got %v", err)
+	}
+
+	var events []string
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	joined := strings.Join(events, "\n")
+	for _, reason := range []string{reasonReleasingSideEffects, reasonSideEffectsReleased} {
+		if !strings.Contains(joined, reason) {
+			t.Errorf("expected a %s event, got %v", reason, events)
+		}
+	}
+}
+
+func TestReleasePolicy_ReleasesByPolicyReference(t *testing.T) {
+	ctx := context.Background()
+	held := newPausedKustomization(testHoliday)
+	// A MaintenanceWindow sharing the name holds its own objects.
+	other := newPausedKustomization(hold.Policy(string(policy.PolicyKindMaintenanceWindow), "holiday"))
+	other.SetName("apps")
+	fluxSystem := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "flux-system"}}
+	c := newFakeClient(fluxSystem, held, other)
+
+	if _, err := releasePolicy(ctx, c, testHoliday); err != nil {
+		t.Fatal(err)
+	}
+	if obj := getKustomization(ctx, c); obj.GetAnnotations()[gitops.AnnotationManaged] != "" {
+		t.Errorf("Kustomization held by the released policy should be resumed, got %v", obj.GetAnnotations())
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(testFluxKustomizationGVK)
+	if err := c.Get(ctx, client.ObjectKey{Name: "apps", Namespace: "flux-system"}, obj); err != nil {
+		t.Fatal(err)
+	}
+	if obj.GetAnnotations()[gitops.AnnotationManaged] != "true" {
+		t.Error("Kustomization held by a MaintenanceWindow of the same name should stay suspended")
+	}
+}
+
+func TestFinalizePolicy_KeepsFinalizerOnError(t *testing.T) {
+	ctx := context.Background()
+	mw := &freezeoperatorv1alpha1.MaintenanceWindow{
+		ObjectMeta: metav1.ObjectMeta{Name: "weekdays", Finalizers: []string{policyFinalizer}},
+	}
+	c := newFakeClientBuilder(mw).WithInterceptorFuncs(interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if _, ok := list.(*corev1.NamespaceList); ok {
+				return errors.New("namespaces unavailable")
+			}
+			return c.List(ctx, list, opts...)
+		},
+	}).Build()
+	if err := c.Delete(ctx, mw); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Get(ctx, client.ObjectKey{Name: "weekdays"}, mw); err != nil {
+		t.Fatal(err)
+	}
+
+	err := finalizePolicy(ctx, c, nil, mw, &mw.Status.Conditions, "maintenancewindow", maintenanceWindowHolder(mw))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if err := c.Get(ctx, client.ObjectKey{Name: "weekdays"}, mw); err != nil {
+		t.Fatalf("MaintenanceWindow should be kept while its side effects are not released: %v", err)
+	}
+	cond := meta.FindStatusCondition(mw.Status.Conditions, conditionTypeTerminating)
+	if cond == nil || cond.Reason != reasonReleaseFailed {
+		t.Errorf("expected Terminating condition with reason %s, got %+v", reasonReleaseFailed, cond)
+	}
+}
@@ -0,0 +1,217 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/policy"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/report"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/snapshot"
+)
+
+const (
+	reasonSnapshotTaken     = "SnapshotTaken"
+	reasonSnapshotTruncated = "SnapshotTruncated"
+	reasonSnapshotFailed    = "SnapshotFailed"
+	reasonReportCreated     = "FreezeReportCreated"
+	reasonDriftDetected     = "DriftDetected"
+
+	reasonSummaryRecorded = "FreezeSummaryRecorded"
+	reasonSummaryFailed   = "FreezeSummaryFailed"
+)
+
+// reportName returns the name of the FreezeReport of a policy's freeze
+// occurrence starting at start.
+func reportName(policyName string, start time.Time) string {
+	suffix := "-" + start.UTC().Format("20060102-1504")
+	if limit := 253 - len(suffix); len(policyName) > limit {
+		policyName = strings.TrimRight(policyName[:limit], "-.")
+	}
+	return policyName + suffix
+}
+
+// apiReader returns the reader for freeze snapshots and reports.
+func (r *ChangeFreezeReconciler) apiReader() client.Reader {
+	if r.APIReader == nil {
+		return r.Client
+	}
+	return r.APIReader
+}
+
+// reconcileSnapshot captures the workloads cf targets in a FreezeSnapshot when
+// the freeze activates. Once the freeze has ended, it compares them with the
+// snapshot, records the drift in a FreezeReport and deletes the snapshot.
+func (r *ChangeFreezeReconciler) reconcileSnapshot(ctx context.Context, cf *freezeoperatorv1alpha1.ChangeFreeze, active bool, now time.Time) error {
+	reader := r.apiReader()
+	start := cf.Spec.StartTime
+
+	snap := &freezeoperatorv1alpha1.FreezeSnapshot{}
+	err := r.Get(ctx, client.ObjectKey{Name: cf.Name}, snap)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("get snapshot: %w", err)
+	}
+	exists := err == nil
+	found := exists && snap.Spec.FreezeStartTime.Equal(&start)
+
+	switch {
+	case active && !found:
+		capture, err := snapshot.Take(ctx, reader, &cf.Spec.Target, time.Time{})
+		if err != nil {
+			return fmt.Errorf("take snapshot: %w", err)
+		}
+		targeted := len(capture.Workloads)
+		truncated := capture.Truncate(snapshot.SnapshotSize)
+		snap.Name = cf.Name
+		snap.Spec = freezeoperatorv1alpha1.FreezeSnapshotSpec{
+			Policy:          freezeoperatorv1alpha1.PolicyReference{Kind: string(policy.PolicyKindChangeFreeze), Name: cf.Name},
+			FreezeStartTime: start,
+			TakenAt:         metav1.NewTime(now),
+			Workloads:       capture.Workloads,
+			Truncated:       truncated,
+		}
+		if exists {
+			// The freeze was rescheduled; the old snapshot no longer applies
+			err = r.Update(ctx, snap)
+		} else {
+			if err := controllerutil.SetControllerReference(cf, snap, r.Scheme); err != nil {
+				return err
+			}
+			if err = r.Create(ctx, snap); apierrors.IsAlreadyExists(err) {
+				// Taken by an earlier reconcile the cache has not caught up with
+				return nil
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("save snapshot: %w", err)
+		}
+		if r.Recorder != nil {
+			if truncated {
+				r.Recorder.Event(cf, corev1.EventTypeWarning, reasonSnapshotTruncated,
+					fmt.Sprintf("Captured %d of %d workloads at freeze start; drift is not reported for the rest", len(capture.Workloads), targeted))
+			} else {
+				r.Recorder.Event(cf, corev1.EventTypeNormal, reasonSnapshotTaken,
+					fmt.Sprintf("Captured %d workloads at freeze start", len(capture.Workloads)))
+			}
+		}
+
+	case !active && found && !now.Before(cf.Spec.EndTime.Time):
+		capture, err := snapshot.Take(ctx, reader, &cf.Spec.Target, start.Time)
+		if err != nil {
+			return fmt.Errorf("take snapshot: %w", err)
+		}
+		if snap.Spec.Truncated {
+			capture.Within(snap.Spec.Workloads)
+		}
+		drift := snapshot.Diff(snap.Spec.Workloads, capture)
+
+		compared := metav1.NewTime(now)
+		spec := freezeoperatorv1alpha1.FreezeReportSpec{Policy: snap.Spec.Policy, StartTime: start, EndTime: cf.Spec.EndTime}
+		name := reportName(cf.Name, start.Time)
+		if err := saveFreezeReport(ctx, r.Client, reader, name, spec, func(st *freezeoperatorv1alpha1.FreezeReportStatus) {
+			st.SnapshotTime = &snap.Spec.TakenAt
+			st.ComparedTime = &compared
+			st.Workloads = len(snap.Spec.Workloads)
+			st.SnapshotTruncated = snap.Spec.Truncated
+			st.Drifted = len(drift)
+			st.Drift = drift[:min(len(drift), snapshot.DriftSize)]
+		}); err != nil {
+			return err
+		}
+
+		if err := r.Delete(ctx, snap); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("delete snapshot: %w", err)
+		}
+
+		if r.Recorder != nil {
+			if len(drift) > 0 {
+				r.Recorder.Event(cf, corev1.EventTypeWarning, reasonDriftDetected,
+					fmt.Sprintf("%d workloads drifted during the freeze, see FreezeReport %s", len(drift), name))
+			} else {
+				r.Recorder.Event(cf, corev1.EventTypeNormal, reasonReportCreated,
+					fmt.Sprintf("No drift during the freeze, see FreezeReport %s", name))
+			}
+		}
+	}
+	return nil
+}
+
+// saveFreezeReport creates the FreezeReport name unless it exists and applies
+// update to its status. The drift and the summary of a freeze occurrence are
+// written to the same report, each by its own update. An existing report is read
+// with reader, since it may have been created moments ago.
+func saveFreezeReport(
+	ctx context.Context,
+	c client.Client,
+	reader client.Reader,
+	name string,
+	spec freezeoperatorv1alpha1.FreezeReportSpec,
+	update func(*freezeoperatorv1alpha1.FreezeReportStatus),
+) error {
+	fr := &freezeoperatorv1alpha1.FreezeReport{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       spec,
+	}
+	if err := c.Create(ctx, fr); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("create report: %w", err)
+		}
+		if err := reader.Get(ctx, client.ObjectKeyFromObject(fr), fr); err != nil {
+			return fmt.Errorf("get report: %w", err)
+		}
+	}
+	update(&fr.Status)
+	if err := c.Status().Update(ctx, fr); err != nil {
+		return fmt.Errorf("update report: %w", err)
+	}
+	return nil
+}
+
+// reconcileSummary writes the summary the aggregator collected for a freeze
+// occurrence of ref to its FreezeReport once the policy no longer enforces, and
+// then drops the occurrence.
+func reconcileSummary(
+	ctx context.Context,
+	c client.Client,
+	reader client.Reader,
+	recorder record.EventRecorder,
+	agg *report.Aggregator,
+	owner client.Object,
+	ref freezeoperatorv1alpha1.PolicyReference,
+	end time.Time,
+) error {
+	start, ok := agg.Started(ref)
+	if !ok {
+		return nil
+	}
+	summary, _ := agg.Summary(ref)
+
+	name := reportName(ref.Name, start)
+	spec := freezeoperatorv1alpha1.FreezeReportSpec{
+		Policy:    ref,
+		StartTime: metav1.NewTime(start),
+		EndTime:   metav1.NewTime(end),
+	}
+	if err := saveFreezeReport(ctx, c, reader, name, spec, func(st *freezeoperatorv1alpha1.FreezeReportStatus) {
+		st.Summary = summary
+	}); err != nil {
+		return err
+	}
+	agg.Forget(ref)
+
+	if recorder != nil {
+		recorder.Event(owner, corev1.EventTypeNormal, reasonSummaryRecorded, fmt.Sprintf(
+			"Freeze summary recorded in FreezeReport %s (denied=%d, exceptions=%d, break-glass=%d)",
+			name, summary.Denied, len(summary.Exceptions), summary.BreakGlassOverrides))
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/report"
+)
+
+func newReportDeployment(image string) *appsv1.Deployment {
+	labels := map[string]string{"app": "api"}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: testCronNS},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "api", Image: image}}},
+			},
+		},
+	}
+}
+
+func TestChangeFreeze_SnapshotAndDriftReport(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	cf := &freezeoperatorv1alpha1.ChangeFreeze{
+		ObjectMeta: metav1.ObjectMeta{Name: "holiday", Finalizers: []string{policyFinalizer}},
+		Spec: freezeoperatorv1alpha1.ChangeFreezeSpec{
+			StartTime: metav1.NewTime(now.Add(-time.Hour)),
+			EndTime:   metav1.NewTime(now.Add(time.Hour)),
+			Target: freezeoperatorv1alpha1.TargetSpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				Kinds:             []freezeoperatorv1alpha1.TargetKind{freezeoperatorv1alpha1.TargetKindDeployment},
+			},
+		},
+	}
+	c := newFakeClient(cf, newCronNamespace(), newReportDeployment("api:1.0"))
+	recorder := record.NewFakeRecorder(50)
+	r := &ChangeFreezeReconciler{Client: c, Scheme: c.Scheme(), Recorder: recorder}
+	req := reconcile.Request{NamespacedName: client.ObjectKey{Name: "holiday"}}
+
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	var snap freezeoperatorv1alpha1.FreezeSnapshot
+	if err := c.Get(ctx, client.ObjectKey{Name: "holiday"}, &snap); err != nil {
+		t.Fatalf("expected a snapshot: %v", err)
+	}
+	if len(snap.Spec.Workloads) != 1 || snap.Spec.Policy.Kind != "ChangeFreeze" {
+		t.Errorf("unexpected snapshot %+v", snap.Spec)
+	}
+	if ref := metav1.GetControllerOf(&snap); ref == nil || ref.Name != "holiday" {
+		t.Errorf("expected the snapshot to be owned by the ChangeFreeze, got %v", ref)
+	}
+
+	// Roll out a new image during the freeze, then end it
+	dep := &appsv1.Deployment{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: testCronNS, Name: "api"}, dep); err != nil {
+		t.Fatal(err)
+	}
+	dep.Spec.Template.Spec.Containers[0].Image = "api:1.1"
+	if err := c.Update(ctx, dep); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, cf); err != nil {
+		t.Fatal(err)
+	}
+	cf.Spec.EndTime = metav1.NewTime(now.Add(-time.Minute))
+	if err := c.Update(ctx, cf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+
+	var report freezeoperatorv1alpha1.FreezeReport
+	if err := c.Get(ctx, client.ObjectKey{Name: reportName("holiday", cf.Spec.StartTime.Time)}, &report); err != nil {
+		t.Fatalf("expected a report: %v", err)
+	}
+	if report.Status.Workloads != 1 || report.Status.Drifted != 1 || len(report.Status.Drift) != 1 {
+		t.Fatalf("unexpected report status %+v", report.Status)
+	}
+	if d := report.Status.Drift[0]; d.Name != "api" || d.Type != freezeoperatorv1alpha1.DriftTypeModified {
+		t.Errorf("unexpected drift %+v", d)
+	}
+	if err := c.Get(ctx, client.ObjectKey{Name: "holiday"}, &snap); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the snapshot to be deleted, got %v", err)
+	}
+
+	var drifted bool
+	for len(recorder.Events) > 0 {
+		if strings.Contains(<-recorder.Events, reasonDriftDetected) {
+			drifted = true
+		}
+	}
+	if !drifted {
+		t.Error("expected a DriftDetected event")
+	}
+}
+
+func TestChangeFreeze_SummaryJoinsDriftReport(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	cf := &freezeoperatorv1alpha1.ChangeFreeze{
+		ObjectMeta: metav1.ObjectMeta{Name: "holiday", Finalizers: []string{policyFinalizer}},
+		Spec: freezeoperatorv1alpha1.ChangeFreezeSpec{
+			StartTime: metav1.NewTime(now.Add(-time.Hour)),
+			EndTime:   metav1.NewTime(now.Add(time.Hour)),
+			Target: freezeoperatorv1alpha1.TargetSpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				Kinds:             []freezeoperatorv1alpha1.TargetKind{freezeoperatorv1alpha1.TargetKindDeployment},
+			},
+		},
+	}
+	c := newFakeClient(cf, newCronNamespace(), newReportDeployment("api:1.0"))
+	reports := &report.Aggregator{}
+	r := &ChangeFreezeReconciler{Client: c, Scheme: c.Scheme(), Recorder: record.NewFakeRecorder(50), Reports: reports}
+	req := reconcile.Request{NamespacedName: client.ObjectKey{Name: "holiday"}}
+	ref := freezeoperatorv1alpha1.PolicyReference{Kind: "ChangeFreeze", Name: "holiday"}
+
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	if start, ok := reports.Started(ref); !ok || start.Unix() != cf.Spec.StartTime.Unix() {
+		t.Fatalf("expected the occurrence to begin at the freeze start, got %v", start)
+	}
+	reports.RecordDenied(ref, "alice", testCronNS, freezeoperatorv1alpha1.ActionRollout)
+
+	if err := c.Get(ctx, req.NamespacedName, cf); err != nil {
+		t.Fatal(err)
+	}
+	cf.Spec.EndTime = metav1.NewTime(now.Add(-time.Minute))
+	if err := c.Update(ctx, cf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+
+	var fr freezeoperatorv1alpha1.FreezeReport
+	if err := c.Get(ctx, client.ObjectKey{Name: reportName("holiday", cf.Spec.StartTime.Time)}, &fr); err != nil {
+		t.Fatalf("expected a report: %v", err)
+	}
+	if fr.Status.Workloads != 1 {
+		t.Errorf("expected the drift to be kept, got %+v", fr.Status)
+	}
+	if fr.Status.Summary == nil || fr.Status.Summary.Denied != 1 {
+		t.Fatalf("expected a summary with one denial, got %+v", fr.Status.Summary)
+	}
+	if _, ok := reports.Summary(ref); ok {
+		t.Error("expected the occurrence to be dropped once written")
+	}
+}
+
+func TestMaintenanceWindow_SummaryWrittenWhenWindowOpens(t *testing.T) {
+	ctx := context.Background()
+	mw := &freezeoperatorv1alpha1.MaintenanceWindow{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly", Finalizers: []string{policyFinalizer}},
+		Spec: freezeoperatorv1alpha1.MaintenanceWindowSpec{
+			Timezone: "UTC",
+			Mode:     freezeoperatorv1alpha1.MaintenanceWindowModeDenyOutsideWindows,
+			Windows: []freezeoperatorv1alpha1.MaintenanceWindowWindowSpec{
+				{Name: "always", Schedule: "0 * * * *", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+			},
+		},
+	}
+	c := newFakeClient(mw)
+	reports := &report.Aggregator{}
+	r := &MaintenanceWindowReconciler{Client: c, Scheme: c.Scheme(), Recorder: record.NewFakeRecorder(50), Reports: reports}
+	ref := freezeoperatorv1alpha1.PolicyReference{Kind: "MaintenanceWindow", Name: "nightly"}
+
+	// The period before the window was recorded while the window was closed
+	start := time.Now().Add(-time.Hour).Truncate(time.Minute)
+	reports.Begin(ref, start)
+	reports.RecordException(ref, "ex-hotfix", "alice")
+
+	if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKey{Name: "nightly"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	var fr freezeoperatorv1alpha1.FreezeReport
+	if err := c.Get(ctx, client.ObjectKey{Name: reportName("nightly", start)}, &fr); err != nil {
+		t.Fatalf("expected a report: %v", err)
+	}
+	if fr.Spec.Policy != ref {
+		t.Errorf("unexpected policy %+v", fr.Spec.Policy)
+	}
+	if s := fr.Status.Summary; s == nil || len(s.Exceptions) != 1 || s.Exceptions[0].User != "alice" {
+		t.Errorf("unexpected summary %+v", fr.Status.Summary)
+	}
+	if _, ok := reports.Started(ref); ok {
+		t.Error("expected the occurrence to be dropped once written")
+	}
+}
+
+func TestReportName(t *testing.T) {
+	start := time.Date(2026, 12, 24, 8, 30, 0, 0, time.UTC)
+	if got := reportName("holiday", start); got != "holiday-20261224-0830" {
+		t.Errorf("unexpected report name %q", got)
+	}
+	if got := reportName(strings.Repeat("a", 260), start); len(got) > 253 {
+		t.Errorf("expected a name of at most 253 characters, got %d", len(got))
+	}
+}
@@ -29,10 +29,20 @@ import (
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
 	"github.com/jamalshahverdiev/kube-freeze-operator/internal/metrics"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/policy"
+)
+
+const (
+	conditionTypeApproved = "Approved"
+
+	reasonApprovalPending = "ApprovalPending"
+	reasonApproved        = "Approved"
 )
 
 // FreezeExceptionReconciler reconciles a FreezeException object
@@ -40,11 +50,16 @@ type FreezeExceptionReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+
+	// MinApprovals is the operator-wide minimum number of approvals, reported in
+	// the Approved condition when it exceeds spec.requiredApprovals.
+	MinApprovals int32
 }
 
 // +kubebuilder:rbac:groups=freeze-operator.io,resources=freezeexceptions,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=freeze-operator.io,resources=freezeexceptions/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=freeze-operator.io,resources=freezeexceptions/finalizers,verbs=update
+// +kubebuilder:rbac:groups=freeze-operator.io,resources=freezeexceptionapprovals,verbs=get;list;watch;delete
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -77,9 +92,19 @@ func (r *FreezeExceptionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	// Track state changes for events
 	wasActive := ex.Status.Active
 
+	approvals, err := policy.ExceptionApprovals(ctx, r.Client, ex)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	approvalsBefore := policy.CurrentApprovals(ex)
+
 	// Update status
 	ex.Status.Active = active
 	ex.Status.ObservedGeneration = ex.Generation
+	setPolicyPhase(&ex.Status.Phase, &ex.Status.TimeUntilStart, &ex.Status.LastTransitionTime, now, activeFrom, activeTo)
+	ex.Status.Approvals = approvals
+	ex.Status.ApprovalCount = policy.CurrentApprovals(ex)
+	r.setApprovalCondition(ex, approvalsBefore)
 
 	var requeueAfter time.Duration
 	if active {
@@ -113,9 +138,9 @@ func (r *FreezeExceptionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 				"Exception expired")
 		}
 
-		// If not yet started, requeue at start time
+		// If not yet started, requeue at start time, refreshing timeUntilStart until then
 		if now.Before(activeFrom) {
-			requeueAfter = activeFrom.Sub(now) + time.Second
+			requeueAfter = min(activeFrom.Sub(now)+time.Second, timeUntilStartRefresh)
 		} else {
 			// Already ended, no need to requeue frequently
 			requeueAfter = 10 * time.Minute
@@ -142,14 +167,89 @@ func (r *FreezeExceptionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		metrics.ActiveFreezePolicies.WithLabelValues("freezeexception", ex.Name).Set(0)
 	}
 
+	// Delete the exception and its approvals once it has been expired for ttlAfterExpired
+	if ex.Status.Phase == freezeoperatorv1alpha1.PolicyPhaseExpired {
+		deleteApprovals := func(ctx context.Context) error { return r.deleteApprovals(ctx, ex) }
+		after, err := collectExpired(ctx, r.Client, r.Recorder, ex, ex.Spec.TTLAfterExpired, activeTo, now, deleteApprovals)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if after > 0 {
+			requeueAfter = min(requeueAfter, after)
+		}
+	}
+
 	logger.V(1).Info("reconciled", "active", active, "requeueAfter", requeueAfter)
 	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
+// deleteApprovals deletes every FreezeExceptionApproval naming ex. Approvals are
+// created by the approvers and not owned by the exception, so they would
+// otherwise outlive it.
+func (r *FreezeExceptionReconciler) deleteApprovals(ctx context.Context, ex *freezeoperatorv1alpha1.FreezeException) error {
+	var list freezeoperatorv1alpha1.FreezeExceptionApprovalList
+	if err := r.List(ctx, &list); err != nil {
+		return fmt.Errorf("list FreezeExceptionApproval: %w", err)
+	}
+	for i := range list.Items {
+		a := &list.Items[i]
+		if a.Spec.ExceptionName != ex.Name {
+			continue
+		}
+		if err := r.Delete(ctx, a); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("delete FreezeExceptionApproval %s: %w", a.Name, err)
+		}
+	}
+	return nil
+}
+
+// setApprovalCondition reports quorum progress for exceptions that require approvals.
+func (r *FreezeExceptionReconciler) setApprovalCondition(ex *freezeoperatorv1alpha1.FreezeException, approvalsBefore int32) {
+	required := policy.RequiredApprovals(ex, r.MinApprovals)
+	if required == 0 {
+		meta.RemoveStatusCondition(&ex.Status.Conditions, conditionTypeApproved)
+		return
+	}
+
+	count := ex.Status.ApprovalCount
+	if count < required {
+		meta.SetStatusCondition(&ex.Status.Conditions, metav1.Condition{
+			Type:               conditionTypeApproved,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: ex.Generation,
+			Reason:             reasonApprovalPending,
+			Message:            fmt.Sprintf("%d of %d required approvals", count, required),
+		})
+		return
+	}
+
+	meta.SetStatusCondition(&ex.Status.Conditions, metav1.Condition{
+		Type:               conditionTypeApproved,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: ex.Generation,
+		Reason:             reasonApproved,
+		Message:            fmt.Sprintf("%d of %d required approvals", count, required),
+	})
+	if approvalsBefore < required && r.Recorder != nil {
+		r.Recorder.Event(ex, corev1.EventTypeNormal, reasonApproved,
+			fmt.Sprintf("Exception approved by %d users", count))
+	}
+}
+
+// exceptionForApproval maps a FreezeExceptionApproval to the exception it approves.
+func exceptionForApproval(_ context.Context, obj client.Object) []reconcile.Request {
+	a, ok := obj.(*freezeoperatorv1alpha1.FreezeExceptionApproval)
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: client.ObjectKey{Name: a.Spec.ExceptionName}}}
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *FreezeExceptionReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&freezeoperatorv1alpha1.FreezeException{}).
+		Watches(&freezeoperatorv1alpha1.FreezeExceptionApproval{}, handler.EnqueueRequestsFromMapFunc(exceptionForApproval)).
 		Named("freezeexception").
 		Complete(r)
 }
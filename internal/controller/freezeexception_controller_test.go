@@ -23,6 +23,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -54,7 +55,7 @@ var _ = Describe("FreezeException Controller", func() {
 					Spec: freezeoperatorv1alpha1.FreezeExceptionSpec{
 						ActiveFrom: metav1.Time{Time: now},
 						ActiveTo:   metav1.Time{Time: now.Add(time.Hour)},
-						Target: freezeoperatorv1alpha1.TargetSpec{
+						Target: &freezeoperatorv1alpha1.TargetSpec{
 							Kinds: []freezeoperatorv1alpha1.TargetKind{freezeoperatorv1alpha1.TargetKindDeployment},
 						},
 						Allow:  []freezeoperatorv1alpha1.Action{freezeoperatorv1alpha1.ActionRollout},
@@ -85,5 +86,39 @@ var _ = Describe("FreezeException Controller", func() {
 			})
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		It("should record distinct approvers in status", func() {
+			resource := &freezeoperatorv1alpha1.FreezeException{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			resource.Spec.RequiredApprovals = 2
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+
+			By("creating two approvals by the same user")
+			for _, name := range []string{"approval-alice-1", "approval-alice-2"} {
+				approval := &freezeoperatorv1alpha1.FreezeExceptionApproval{
+					ObjectMeta: metav1.ObjectMeta{Name: name},
+					Spec: freezeoperatorv1alpha1.FreezeExceptionApprovalSpec{
+						ExceptionName:       resourceName,
+						ExceptionGeneration: resource.Generation,
+						Approver:            "alice",
+					},
+				}
+				Expect(k8sClient.Create(ctx, approval)).To(Succeed())
+				DeferCleanup(func() { Expect(k8sClient.Delete(ctx, approval)).To(Succeed()) })
+			}
+
+			controllerReconciler := &FreezeExceptionReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.Approvals).To(HaveLen(1))
+			Expect(resource.Status.Approvals[0].User).To(Equal("alice"))
+			Expect(resource.Status.ApprovalCount).To(Equal(int32(1)))
+			Expect(meta.IsStatusConditionFalse(resource.Status.Conditions, conditionTypeApproved)).To(BeTrue())
+		})
 	})
 })
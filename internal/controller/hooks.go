@@ -0,0 +1,297 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+)
+
+const (
+	// labelHook marks Jobs launched by a policy hook with the hook type.
+	labelHook = "freeze-operator.io/hook"
+
+	// annotationHookPolicy and annotationHookTransition record the policy and the
+	// transition a hook Job was launched for.
+	annotationHookPolicy     = "freeze-operator.io/hook-policy"
+	annotationHookTransition = "freeze-operator.io/hook-transition"
+
+	reasonHookLaunched     = "HookLaunched"
+	reasonHookLaunchFailed = "HookLaunchFailed"
+	reasonHookSucceeded    = "HookSucceeded"
+	reasonHookFailed       = "HookFailed"
+
+	// hookJobGracePeriod is how long a launched Job may be missing from the cache
+	// before the hook is reported as failed.
+	hookJobGracePeriod = time.Minute
+)
+
+// hookTransition is a change in whether a policy enforces. Its time identifies the
+// transition, so it must be the same on every reconcile that observes it.
+type hookTransition struct {
+	Type freezeoperatorv1alpha1.HookType
+	Time time.Time
+}
+
+// hookConditionType returns the condition reporting the outcome of a hook type,
+// OnActivateHook or OnDeactivateHook.
+func hookConditionType(t freezeoperatorv1alpha1.HookType) string {
+	return string(t) + "Hook"
+}
+
+// hookFor returns the hook of the given type, or nil if it is not configured.
+func hookFor(hooks *freezeoperatorv1alpha1.PolicyHooksSpec, t freezeoperatorv1alpha1.HookType) *freezeoperatorv1alpha1.HookSpec {
+	if hooks == nil {
+		return nil
+	}
+	if t == freezeoperatorv1alpha1.HookTypeOnActivate {
+		return hooks.OnActivate
+	}
+	return hooks.OnDeactivate
+}
+
+// reconcileHooks launches the hook for transition unless it already ran for it,
+// and refreshes the phase of hook Jobs still running. Outcomes are recorded in
+// statuses and conditions and reported as Events; the caller patches the status.
+func reconcileHooks(
+	ctx context.Context,
+	c client.Client,
+	scheme *runtime.Scheme,
+	recorder record.EventRecorder,
+	owner client.Object,
+	holder string,
+	hooks *freezeoperatorv1alpha1.PolicyHooksSpec,
+	transition *hookTransition,
+	statuses *[]freezeoperatorv1alpha1.HookStatus,
+	conditions *[]metav1.Condition,
+) error {
+	var errs []error
+
+	var launched freezeoperatorv1alpha1.HookType
+	if transition != nil {
+		if hook := hookFor(hooks, transition.Type); hook != nil && !hookRan(*statuses, transition) {
+			st, err := launchHook(ctx, c, scheme, owner, holder, transition, hook)
+			setHookStatus(statuses, st)
+			reportHook(recorder, owner, conditions, st, err != nil)
+			if err != nil {
+				errs = append(errs, err)
+			}
+			launched = transition.Type
+		}
+	}
+
+	for i := range *statuses {
+		st := &(*statuses)[i]
+		if st.Phase != freezeoperatorv1alpha1.HookPhaseRunning || st.Type == launched {
+			continue
+		}
+		changed, err := refreshHook(ctx, c, st)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if changed {
+			reportHook(recorder, owner, conditions, *st, false)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// hookRan reports whether the hook for transition was already launched. A hook
+// whose Job could not be created has no start time and is launched again.
+func hookRan(statuses []freezeoperatorv1alpha1.HookStatus, transition *hookTransition) bool {
+	return slices.ContainsFunc(statuses, func(st freezeoperatorv1alpha1.HookStatus) bool {
+		return st.Type == transition.Type && st.StartTime != nil &&
+			st.TransitionTime.Time.Equal(transition.Time.Truncate(time.Second))
+	})
+}
+
+// setHookStatus replaces the status of st's hook type.
+func setHookStatus(statuses *[]freezeoperatorv1alpha1.HookStatus, st freezeoperatorv1alpha1.HookStatus) {
+	for i := range *statuses {
+		if (*statuses)[i].Type == st.Type {
+			(*statuses)[i] = st
+			return
+		}
+	}
+	*statuses = append(*statuses, st)
+}
+
+// launchHook creates the Job for a hook from its CronJob template and returns the
+// hook's status, which is Failed if the Job could not be created. The Job name is
+// derived from the policy and the transition, so a reconcile that launches the
+// same hook again finds the existing Job instead of creating another one.
+func launchHook(
+	ctx context.Context,
+	c client.Client,
+	scheme *runtime.Scheme,
+	owner client.Object,
+	holder string,
+	transition *hookTransition,
+	hook *freezeoperatorv1alpha1.HookSpec,
+) (freezeoperatorv1alpha1.HookStatus, error) {
+	at := transition.Time.Truncate(time.Second)
+	ref := hook.JobTemplateRef
+	st := freezeoperatorv1alpha1.HookStatus{
+		Type:           transition.Type,
+		TransitionTime: metav1.NewTime(at),
+		JobNamespace:   ref.Namespace,
+		JobName:        hookJobName(owner.GetName(), holder, transition.Type, at),
+		Phase:          freezeoperatorv1alpha1.HookPhaseRunning,
+	}
+	fail := func(err error) (freezeoperatorv1alpha1.HookStatus, error) {
+		st.Phase = freezeoperatorv1alpha1.HookPhaseFailed
+		st.Message = err.Error()
+		return st, err
+	}
+
+	var cron batchv1.CronJob
+	if err := c.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, &cron); err != nil {
+		return fail(fmt.Errorf("get job template %s/%s: %w", ref.Namespace, ref.Name, err))
+	}
+
+	tmpl := cron.Spec.JobTemplate
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        st.JobName,
+			Namespace:   ref.Namespace,
+			Labels:      maps.Clone(tmpl.Labels),
+			Annotations: maps.Clone(tmpl.Annotations),
+		},
+		Spec: *tmpl.Spec.DeepCopy(),
+	}
+	if job.Labels == nil {
+		job.Labels = make(map[string]string)
+	}
+	if job.Annotations == nil {
+		job.Annotations = make(map[string]string)
+	}
+	job.Labels[labelHook] = string(transition.Type)
+	job.Annotations[annotationHookPolicy] = holder
+	job.Annotations[annotationHookTransition] = at.UTC().Format(time.RFC3339)
+	if err := controllerutil.SetControllerReference(owner, job, scheme); err != nil {
+		return fail(fmt.Errorf("set owner of job %s/%s: %w", job.Namespace, job.Name, err))
+	}
+
+	if err := c.Create(ctx, job); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fail(fmt.Errorf("create job %s/%s: %w", job.Namespace, job.Name, err))
+	}
+	now := metav1.Now()
+	st.StartTime = &now
+	return st, nil
+}
+
+// hookJobName returns the name of the Job launched by a hook for the transition
+// at the given time. Job names are kept within 63 characters, since they are
+// copied to a label on the Job's pods.
+func hookJobName(name, holder string, t freezeoperatorv1alpha1.HookType, at time.Time) string {
+	h := fnv.New32a()
+	_, _ = fmt.Fprintf(h, "%s/%s/%d", holder, t, at.Unix())
+
+	suffix := "-activate-"
+	if t == freezeoperatorv1alpha1.HookTypeOnDeactivate {
+		suffix = "-deactivate-"
+	}
+	suffix += fmt.Sprintf("%08x", h.Sum32())
+
+	if limit := 63 - len(suffix); len(name) > limit {
+		name = strings.TrimRight(name[:limit], "-.")
+	}
+	return name + suffix
+}
+
+// refreshHook updates the phase of a running hook from its Job and reports
+// whether it changed.
+func refreshHook(ctx context.Context, c client.Client, st *freezeoperatorv1alpha1.HookStatus) (bool, error) {
+	var job batchv1.Job
+	if err := c.Get(ctx, client.ObjectKey{Namespace: st.JobNamespace, Name: st.JobName}, &job); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("get job %s/%s: %w", st.JobNamespace, st.JobName, err)
+		}
+		// A Job created moments ago may not be cached yet.
+		if st.StartTime != nil && time.Since(st.StartTime.Time) < hookJobGracePeriod {
+			return false, nil
+		}
+		st.Phase = freezeoperatorv1alpha1.HookPhaseFailed
+		st.Message = "Job no longer exists"
+		return true, nil
+	}
+
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			st.Phase = freezeoperatorv1alpha1.HookPhaseSucceeded
+			st.Message = ""
+			return true, nil
+		case batchv1.JobFailed:
+			st.Phase = freezeoperatorv1alpha1.HookPhaseFailed
+			st.Message = cond.Message
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// reportHook sets the hook type's condition from st and emits an Event.
+// launchFailed distinguishes a Job that could not be created from a failed Job.
+func reportHook(
+	recorder record.EventRecorder,
+	owner client.Object,
+	conditions *[]metav1.Condition,
+	st freezeoperatorv1alpha1.HookStatus,
+	launchFailed bool,
+) {
+	job := st.JobNamespace + "/" + st.JobName
+
+	cond := metav1.Condition{
+		Type:               hookConditionType(st.Type),
+		ObservedGeneration: owner.GetGeneration(),
+	}
+	eventType := corev1.EventTypeNormal
+	var message string
+	switch st.Phase {
+	case freezeoperatorv1alpha1.HookPhaseRunning:
+		cond.Status = metav1.ConditionUnknown
+		cond.Reason = reasonHookLaunched
+		message = fmt.Sprintf("%s hook launched Job %s", st.Type, job)
+	case freezeoperatorv1alpha1.HookPhaseSucceeded:
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = reasonHookSucceeded
+		message = fmt.Sprintf("%s hook Job %s succeeded", st.Type, job)
+	default:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = reasonHookFailed
+		message = fmt.Sprintf("%s hook Job %s failed: %s", st.Type, job, st.Message)
+		if launchFailed {
+			cond.Reason = reasonHookLaunchFailed
+			message = fmt.Sprintf("%s hook could not launch a Job: %s", st.Type, st.Message)
+		}
+		eventType = corev1.EventTypeWarning
+	}
+	cond.Message = message
+	meta.SetStatusCondition(conditions, cond)
+
+	if recorder != nil {
+		recorder.Event(owner, eventType, cond.Reason, message)
+	}
+}
@@ -0,0 +1,372 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/report"
+)
+
+const (
+	testHookNS       = "ops"
+	testHookTemplate = "announce-freeze"
+)
+
+func newHookTemplate() *batchv1.CronJob {
+	suspend := true
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: testHookTemplate, Namespace: testHookNS},
+		Spec: batchv1.CronJobSpec{
+			Schedule: "0 0 1 1 *",
+			Suspend:  &suspend,
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "announce"}},
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							RestartPolicy: corev1.RestartPolicyNever,
+							Containers:    []corev1.Container{{Name: "announce", Image: "curlimages/curl"}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func newHookedChangeFreeze(now time.Time) *freezeoperatorv1alpha1.ChangeFreeze {
+	return &freezeoperatorv1alpha1.ChangeFreeze{
+		ObjectMeta: metav1.ObjectMeta{Name: "holiday", Finalizers: []string{policyFinalizer}},
+		Spec: freezeoperatorv1alpha1.ChangeFreezeSpec{
+			StartTime: metav1.NewTime(now.Add(-time.Minute)),
+			EndTime:   metav1.NewTime(now.Add(time.Hour)),
+			Behavior: freezeoperatorv1alpha1.PolicyBehaviorSpec{
+				Hooks: &freezeoperatorv1alpha1.PolicyHooksSpec{
+					OnActivate: &freezeoperatorv1alpha1.HookSpec{
+						JobTemplateRef: freezeoperatorv1alpha1.JobTemplateReference{Namespace: testHookNS, Name: testHookTemplate},
+					},
+				},
+			},
+		},
+		// Evaluated once before the freeze started
+		Status: freezeoperatorv1alpha1.ChangeFreezeStatus{ObservedGeneration: 1},
+	}
+}
+
+func listHookJobs(t *testing.T, c client.Client) []batchv1.Job {
+	t.Helper()
+	var jobs batchv1.JobList
+	if err := c.List(context.Background(), &jobs, client.InNamespace(testHookNS)); err != nil {
+		t.Fatal(err)
+	}
+	return jobs.Items
+}
+
+func TestChangeFreeze_HookRunsOncePerTransition(t *testing.T) {
+	ctx := context.Background()
+	cf := newHookedChangeFreeze(time.Now())
+	c := newFakeClient(cf, newHookTemplate())
+	recorder := record.NewFakeRecorder(20)
+	r := &ChangeFreezeReconciler{Client: c, Scheme: c.Scheme(), Recorder: recorder}
+	req := reconcile.Request{NamespacedName: client.ObjectKey{Name: "holiday"}}
+
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+
+	jobs := listHookJobs(t, c)
+	if len(jobs) != 1 {
+		t.Fatalf("expected one hook Job, got %d", len(jobs))
+	}
+	job := jobs[0]
+	if job.Labels[labelHook] != string(freezeoperatorv1alpha1.HookTypeOnActivate) || job.Labels["app"] != "announce" {
+		t.Errorf("unexpected Job labels %v", job.Labels)
+	}
+	if job.Annotations[annotationHookPolicy] != "ChangeFreeze/holiday" {
+		t.Errorf("unexpected hook policy annotation %q", job.Annotations[annotationHookPolicy])
+	}
+	if ref := metav1.GetControllerOf(&job); ref == nil || ref.Kind != "ChangeFreeze" || ref.Name != "holiday" {
+		t.Errorf("expected the Job to be owned by the ChangeFreeze, got %v", ref)
+	}
+
+	if err := c.Get(ctx, req.NamespacedName, cf); err != nil {
+		t.Fatal(err)
+	}
+	if len(cf.Status.Hooks) != 1 {
+		t.Fatalf("expected one hook status, got %v", cf.Status.Hooks)
+	}
+	st := cf.Status.Hooks[0]
+	if st.Phase != freezeoperatorv1alpha1.HookPhaseRunning || st.JobName != job.Name || st.StartTime == nil {
+		t.Errorf("unexpected hook status %+v", st)
+	}
+	if !st.TransitionTime.Equal(&cf.Spec.StartTime) {
+		t.Errorf("expected the transition to be identified by the start time, got %v", st.TransitionTime)
+	}
+	cond := meta.FindStatusCondition(cf.Status.Conditions, "OnActivateHook")
+	if cond == nil || cond.Status != metav1.ConditionUnknown || cond.Reason != reasonHookLaunched {
+		t.Errorf("expected a launched OnActivateHook condition, got %v", cond)
+	}
+
+	// A reconcile that observes the transition again, as after a lost status
+	// update, finds the Job it already created.
+	cf.Status.Active = false
+	cf.Status.Hooks = nil
+	if err := c.Status().Update(ctx, cf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	if jobs := listHookJobs(t, c); len(jobs) != 1 {
+		t.Errorf("expected the hook to run once, got %d Jobs", len(jobs))
+	}
+}
+
+func TestChangeFreeze_HookOnFirstEvaluationWhileActive(t *testing.T) {
+	ctx := context.Background()
+	cf := newHookedChangeFreeze(time.Now())
+	cf.Status = freezeoperatorv1alpha1.ChangeFreezeStatus{}
+	c := newFakeClient(cf, newHookTemplate())
+	r := &ChangeFreezeReconciler{Client: c, Scheme: c.Scheme()}
+	req := reconcile.Request{NamespacedName: client.ObjectKey{Name: "holiday"}}
+
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	jobs := listHookJobs(t, c)
+	if len(jobs) != 1 || jobs[0].Labels[labelHook] != string(freezeoperatorv1alpha1.HookTypeOnActivate) {
+		t.Fatalf("expected an OnActivate Job for a freeze created while active, got %v", jobs)
+	}
+	if err := c.Get(ctx, req.NamespacedName, cf); err != nil {
+		t.Fatal(err)
+	}
+	if len(cf.Status.Hooks) != 1 || !cf.Status.Hooks[0].TransitionTime.Equal(&cf.Spec.StartTime) {
+		t.Errorf("expected the transition to be dated at the start time, got %v", cf.Status.Hooks)
+	}
+
+	// A first evaluation repeated after a lost status update reuses the Job.
+	cf.Status = freezeoperatorv1alpha1.ChangeFreezeStatus{}
+	if err := c.Status().Update(ctx, cf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	if jobs := listHookJobs(t, c); len(jobs) != 1 {
+		t.Errorf("expected the hook to run once, got %d Jobs", len(jobs))
+	}
+}
+
+func TestChangeFreeze_NoHookOnFirstEvaluationWhenInactive(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	cf := newHookedChangeFreeze(now)
+	cf.Spec.StartTime = metav1.NewTime(now.Add(time.Hour))
+	cf.Spec.EndTime = metav1.NewTime(now.Add(2 * time.Hour))
+	cf.Spec.Behavior.Hooks.OnDeactivate = cf.Spec.Behavior.Hooks.OnActivate.DeepCopy()
+	cf.Status = freezeoperatorv1alpha1.ChangeFreezeStatus{}
+	c := newFakeClient(cf, newHookTemplate())
+	r := &ChangeFreezeReconciler{Client: c, Scheme: c.Scheme()}
+
+	if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKey{Name: "holiday"}}); err != nil {
+		t.Fatal(err)
+	}
+	if jobs := listHookJobs(t, c); len(jobs) != 0 {
+		t.Errorf("expected no hook Job for a freeze that has not started, got %d", len(jobs))
+	}
+}
+
+func TestMaintenanceWindow_HookOnFirstEvaluationOutsideWindows(t *testing.T) {
+	ctx := context.Background()
+	created := metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+	mw := &freezeoperatorv1alpha1.MaintenanceWindow{
+		ObjectMeta: metav1.ObjectMeta{Name: "yearly", Finalizers: []string{policyFinalizer}, CreationTimestamp: created},
+		Spec: freezeoperatorv1alpha1.MaintenanceWindowSpec{
+			Timezone: "UTC",
+			Mode:     freezeoperatorv1alpha1.MaintenanceWindowModeDenyOutsideWindows,
+			Windows: []freezeoperatorv1alpha1.MaintenanceWindowWindowSpec{
+				{Name: "never-now", Schedule: "0 0 29 2 *", Duration: metav1.Duration{Duration: time.Minute}},
+			},
+			Behavior: freezeoperatorv1alpha1.PolicyBehaviorSpec{
+				Hooks: &freezeoperatorv1alpha1.PolicyHooksSpec{
+					OnActivate: &freezeoperatorv1alpha1.HookSpec{
+						JobTemplateRef: freezeoperatorv1alpha1.JobTemplateReference{Namespace: testHookNS, Name: testHookTemplate},
+					},
+				},
+			},
+		},
+	}
+	c := newFakeClient(mw, newHookTemplate())
+	r := &MaintenanceWindowReconciler{Client: c, Scheme: c.Scheme(), Recorder: record.NewFakeRecorder(20), Reports: &report.Aggregator{}}
+
+	if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKey{Name: "yearly"}}); err != nil {
+		t.Fatal(err)
+	}
+	jobs := listHookJobs(t, c)
+	if len(jobs) != 1 || jobs[0].Labels[labelHook] != string(freezeoperatorv1alpha1.HookTypeOnActivate) {
+		t.Fatalf("expected an OnActivate Job for a window created outside its windows, got %v", jobs)
+	}
+	if err := c.Get(ctx, client.ObjectKey{Name: "yearly"}, mw); err != nil {
+		t.Fatal(err)
+	}
+	if len(mw.Status.Hooks) != 1 || !mw.Status.Hooks[0].TransitionTime.Equal(&mw.CreationTimestamp) {
+		t.Errorf("expected the transition to be dated at creation, got %v", mw.Status.Hooks)
+	}
+}
+
+func TestReconcileHooks_LaunchFailureIsRetried(t *testing.T) {
+	ctx := context.Background()
+	cf := newHookedChangeFreeze(time.Now())
+	c := newFakeClient(cf)
+	recorder := record.NewFakeRecorder(20)
+	transition := &hookTransition{Type: freezeoperatorv1alpha1.HookTypeOnActivate, Time: cf.Spec.StartTime.Time}
+
+	err := reconcileHooks(ctx, c, c.Scheme(), recorder, cf, "ChangeFreeze/holiday", cf.Spec.Behavior.Hooks,
+		transition, &cf.Status.Hooks, &cf.Status.Conditions)
+	if err == nil {
+		t.Fatal("expected an error for a missing job template")
+	}
+	st := cf.Status.Hooks[0]
+	if st.Phase != freezeoperatorv1alpha1.HookPhaseFailed || st.StartTime != nil {
+		t.Errorf("unexpected hook status %+v", st)
+	}
+	cond := meta.FindStatusCondition(cf.Status.Conditions, "OnActivateHook")
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != reasonHookLaunchFailed {
+		t.Errorf("expected a HookLaunchFailed condition, got %v", cond)
+	}
+	if event := <-recorder.Events; !strings.Contains(event, reasonHookLaunchFailed) {
+		t.Errorf("expected a HookLaunchFailed event, got %q", event)
+	}
+
+	if err := c.Create(ctx, newHookTemplate()); err != nil {
+		t.Fatal(err)
+	}
+	if err := reconcileHooks(ctx, c, c.Scheme(), recorder, cf, "ChangeFreeze/holiday", cf.Spec.Behavior.Hooks,
+		transition, &cf.Status.Hooks, &cf.Status.Conditions); err != nil {
+		t.Fatal(err)
+	}
+	if len(cf.Status.Hooks) != 1 || cf.Status.Hooks[0].Phase != freezeoperatorv1alpha1.HookPhaseRunning {
+		t.Errorf("expected the retried hook to be running, got %+v", cf.Status.Hooks)
+	}
+	if jobs := listHookJobs(t, c); len(jobs) != 1 {
+		t.Errorf("expected one hook Job, got %d", len(jobs))
+	}
+}
+
+func TestReconcileHooks_ReportsJobOutcome(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition batchv1.JobConditionType
+		phase     freezeoperatorv1alpha1.HookPhase
+		status    metav1.ConditionStatus
+		reason    string
+	}{
+		{"succeeded", batchv1.JobComplete, freezeoperatorv1alpha1.HookPhaseSucceeded, metav1.ConditionTrue, reasonHookSucceeded},
+		{"failed", batchv1.JobFailed, freezeoperatorv1alpha1.HookPhaseFailed, metav1.ConditionFalse, reasonHookFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			job := &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "holiday-activate-0000abcd", Namespace: testHookNS},
+				Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+					{Type: tt.condition, Status: corev1.ConditionTrue, Message: "done"},
+				}},
+			}
+			cf := newHookedChangeFreeze(time.Now())
+			started := metav1.NewTime(time.Now().Add(-time.Minute))
+			cf.Status.Hooks = []freezeoperatorv1alpha1.HookStatus{{
+				Type:           freezeoperatorv1alpha1.HookTypeOnActivate,
+				TransitionTime: cf.Spec.StartTime,
+				JobNamespace:   testHookNS,
+				JobName:        job.Name,
+				StartTime:      &started,
+				Phase:          freezeoperatorv1alpha1.HookPhaseRunning,
+			}}
+			c := newFakeClient(cf, job)
+			recorder := record.NewFakeRecorder(20)
+
+			if err := reconcileHooks(ctx, c, c.Scheme(), recorder, cf, "ChangeFreeze/holiday", cf.Spec.Behavior.Hooks,
+				nil, &cf.Status.Hooks, &cf.Status.Conditions); err != nil {
+				t.Fatal(err)
+			}
+			if got := cf.Status.Hooks[0].Phase; got != tt.phase {
+				t.Errorf("expected phase %s, got %s", tt.phase, got)
+			}
+			cond := meta.FindStatusCondition(cf.Status.Conditions, "OnActivateHook")
+			if cond == nil || cond.Status != tt.status || cond.Reason != tt.reason {
+				t.Errorf("expected condition %s/%s, got %v", tt.status, tt.reason, cond)
+			}
+			if event := <-recorder.Events; !strings.Contains(event, tt.reason) {
+				t.Errorf("expected a %s event, got %q", tt.reason, event)
+			}
+		})
+	}
+}
+
+func TestHookJobName(t *testing.T) {
+	at := time.Date(2026, 12, 20, 0, 0, 0, 0, time.UTC)
+	name := hookJobName(strings.Repeat("a", 70), "ChangeFreeze/x", freezeoperatorv1alpha1.HookTypeOnDeactivate, at)
+	if len(name) > 63 {
+		t.Errorf("expected a name of at most 63 characters, got %d", len(name))
+	}
+	if !strings.Contains(name, "-deactivate-") {
+		t.Errorf("expected the hook type in %q", name)
+	}
+
+	a := hookJobName("holiday", "ChangeFreeze/holiday", freezeoperatorv1alpha1.HookTypeOnActivate, at)
+	b := hookJobName("holiday", "MaintenanceWindow/holiday", freezeoperatorv1alpha1.HookTypeOnActivate, at)
+	c := hookJobName("holiday", "ChangeFreeze/holiday", freezeoperatorv1alpha1.HookTypeOnActivate, at.Add(time.Hour))
+	if a == b || a == c {
+		t.Errorf("expected distinct names per policy and transition, got %q, %q and %q", a, b, c)
+	}
+}
+
+func TestMaintenanceWindowTransition(t *testing.T) {
+	now := time.Now()
+	start := metav1.NewTime(now.Add(-time.Minute))
+	end := metav1.NewTime(now.Add(-2 * time.Minute))
+
+	opened := maintenanceWindowTransition(&evaluationResult{
+		Active:       true,
+		ActiveWindow: &freezeoperatorv1alpha1.WindowStatus{StartTime: start},
+	}, nil, now)
+	if opened.Type != freezeoperatorv1alpha1.HookTypeOnDeactivate || !opened.Time.Equal(start.Time) {
+		t.Errorf("expected OnDeactivate at the window start, got %+v", opened)
+	}
+
+	closed := maintenanceWindowTransition(&evaluationResult{}, &freezeoperatorv1alpha1.WindowStatus{EndTime: end}, now)
+	if closed.Type != freezeoperatorv1alpha1.HookTypeOnActivate || !closed.Time.Equal(end.Time) {
+		t.Errorf("expected OnActivate at the window end, got %+v", closed)
+	}
+}
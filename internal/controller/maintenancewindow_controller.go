@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"time"
 
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -29,12 +30,19 @@ import (
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/autoscaling"
 	"github.com/jamalshahverdiev/kube-freeze-operator/internal/gitops"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/hold"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/inventory"
 	"github.com/jamalshahverdiev/kube-freeze-operator/internal/metrics"
 	"github.com/jamalshahverdiev/kube-freeze-operator/internal/policy"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/report"
 )
 
 const (
@@ -47,6 +55,8 @@ const (
 	reasonDeactivated       = "Deactivated"
 	reasonCronJobsUpdated   = "CronJobsUpdated"
 	reasonCronJobUpdateFail = "CronJobUpdateFailed"
+
+	reasonAutoscalerReconcileFail = "AutoscalerReconcileFailed"
 )
 
 // MaintenanceWindowReconciler reconciles a MaintenanceWindow object
@@ -54,17 +64,28 @@ type MaintenanceWindowReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+
+	// Reports collects the summary of each period enforced outside the windows,
+	// written to a FreezeReport when a window opens. Nothing is summarised when nil.
+	Reports *report.Aggregator
 }
 
 // +kubebuilder:rbac:groups=freeze-operator.io,resources=maintenancewindows,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=freeze-operator.io,resources=maintenancewindows/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=freeze-operator.io,resources=maintenancewindows/finalizers,verbs=update
 // +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=freeze-operator.io,resources=freezereports,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=freeze-operator.io,resources=freezereports/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=apps,resources=statefulsets;daemonsets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups=argoproj.io,resources=applications,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=kustomize.toolkit.fluxcd.io,resources=kustomizations,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=helm.toolkit.fluxcd.io,resources=helmreleases,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects,verbs=get;list;watch;update;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -84,6 +105,20 @@ func (r *MaintenanceWindowReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		}
 		return ctrl.Result{}, err
 	}
+
+	ref := freezeoperatorv1alpha1.PolicyReference{Kind: string(policy.PolicyKindMaintenanceWindow), Name: mw.Name}
+
+	// Restore everything the policy holds before it goes away
+	if !mw.DeletionTimestamp.IsZero() {
+		r.Reports.Forget(ref)
+		err := finalizePolicy(ctx, r.Client, r.Recorder, mw, &mw.Status.Conditions, "maintenancewindow", maintenanceWindowHolder(mw))
+		return ctrl.Result{}, err
+	}
+	if controllerutil.AddFinalizer(mw, policyFinalizer) {
+		if err := r.Update(ctx, mw); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
 	mwPatch := client.MergeFrom(mw.DeepCopy())
 
 	// Evaluate current state
@@ -109,8 +144,11 @@ func (r *MaintenanceWindowReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return ctrl.Result{RequeueAfter: time.Minute}, err
 	}
 
-	// Track state changes for events
+	// Track state changes for events and hooks. A window first evaluated outside
+	// its windows runs its onActivate hook as if it started enforcing on creation.
 	wasActive := mw.Status.Active
+	previousWindow := mw.Status.ActiveWindow
+	evaluated := mw.Status.ObservedGeneration != 0
 
 	// Update status
 	mw.Status.Active = result.Active
@@ -118,6 +156,13 @@ func (r *MaintenanceWindowReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	mw.Status.NextWindow = result.NextWindow
 	mw.Status.ObservedGeneration = mw.Generation
 
+	// Summarise the period outside the windows from the end of the last window.
+	// That end is only known on the reconcile that closes the window, so a period
+	// already begun is kept.
+	if _, begun := r.Reports.Started(ref); !result.Active && !begun {
+		r.Reports.Begin(ref, maintenanceWindowTransition(result, previousWindow, now).Time)
+	}
+
 	// Update conditions
 	if result.Active {
 		meta.SetStatusCondition(&mw.Status.Conditions, metav1.Condition{
@@ -153,17 +198,49 @@ func (r *MaintenanceWindowReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		Message:            "Successfully evaluated windows",
 	})
 
-	// Update CronJobs if configured
-	if mw.Spec.Behavior.SuspendCronJobs {
-		if err := r.updateCronJobs(ctx, mw, result.Active); err != nil {
-			logger.Error(err, "failed to update CronJobs")
-			if r.Recorder != nil {
-				r.Recorder.Event(mw, corev1.EventTypeWarning, reasonCronJobUpdateFail, err.Error())
-			}
-			// Don't fail reconciliation, just log and continue
-		} else if r.Recorder != nil {
-			r.Recorder.Event(mw, corev1.EventTypeNormal, reasonCronJobsUpdated,
-				fmt.Sprintf("CronJobs suspend status updated (active=%v)", result.Active))
+	// Update CronJobs. Without suspendCronJobs the CronJobs still held by this window
+	// are released.
+	cronResult, err := r.updateCronJobs(ctx, mw, result.Active)
+	if err != nil {
+		logger.Error(err, "failed to update CronJobs")
+		if r.Recorder != nil {
+			r.Recorder.Event(mw, corev1.EventTypeWarning, reasonCronJobUpdateFail, err.Error())
+		}
+		// Don't fail reconciliation, just log and continue
+	} else if cronResult.Changed > 0 && r.Recorder != nil {
+		r.Recorder.Event(mw, corev1.EventTypeNormal, reasonCronJobsUpdated,
+			fmt.Sprintf("CronJobs suspend status updated (active=%v, updated=%d)", result.Active, cronResult.Changed))
+	}
+	suspended := 0
+	for ns, n := range cronResult.Suspended {
+		metrics.CronJobSuspensions.WithLabelValues("maintenancewindow", mw.Name, ns).Set(float64(n))
+		suspended += n
+	}
+	if err == nil {
+		r.Reports.RecordCronJobs(ref, suspended)
+	}
+
+	// Pause Deployments if configured; like CronJobs they are paused outside the
+	// windows, and released without pauseDeployments.
+	pause := mw.Spec.Behavior.PauseDeployments && !result.Active
+	if changed, err := updateDeploymentsForPolicy(ctx, r.Client, &mw.Spec.Target, maintenanceWindowHolder(mw), pause); err != nil {
+		logger.Error(err, "failed to update Deployments")
+		if r.Recorder != nil {
+			r.Recorder.Event(mw, corev1.EventTypeWarning, reasonDeploymentUpdateFail, err.Error())
+		}
+	} else if changed > 0 && r.Recorder != nil {
+		r.Recorder.Event(mw, corev1.EventTypeNormal, reasonDeploymentsUpdated,
+			fmt.Sprintf("Deployments paused status updated (paused=%v, updated=%d)", pause, changed))
+	}
+
+	// Pin autoscalers if configured; capacity is fixed outside the windows, and
+	// released without pinAutoscalers.
+	ar := &autoscaling.Reconciler{Client: r.Client}
+	pin := mw.Spec.Behavior.PinAutoscalers && !result.Active
+	if _, err := ar.Reconcile(ctx, &mw.Spec.Target, maintenanceWindowHolder(mw), pin); err != nil {
+		logger.Error(err, "failed to reconcile autoscalers")
+		if r.Recorder != nil {
+			r.Recorder.Event(mw, corev1.EventTypeWarning, reasonAutoscalerReconcileFail, err.Error())
 		}
 	}
 
@@ -172,18 +249,53 @@ func (r *MaintenanceWindowReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	if mw.Spec.Behavior.GitOps != nil && mw.Spec.Behavior.GitOps.Enabled {
 		gitopsFreezeActive := !result.Active // outside window = freeze on
 		gr := &gitops.Reconciler{Client: r.Client}
-		gResult, err := gr.Reconcile(ctx, mw.Spec.Behavior.GitOps, mw.Name, gitopsFreezeActive)
+		gResult, err := gr.Reconcile(ctx, mw.Spec.Behavior.GitOps, maintenanceWindowHolder(mw), gitopsFreezeActive)
 		if err != nil {
 			logger.Error(err, "failed to reconcile GitOps resources")
 			if r.Recorder != nil {
 				r.Recorder.Event(mw, corev1.EventTypeWarning, "GitOpsReconcileFailed", err.Error())
 			}
+		} else {
+			r.Reports.RecordGitOps(ref, gResult.PausedCount)
 		}
 		mw.Status.GitopsPausedCount = gResult.PausedCount
 		now := gResult.ReconcileTime
 		mw.Status.GitopsLastReconcileTime = &now
 	}
 
+	// Run hooks on transitions and track the Jobs they launched
+	var transition *hookTransition
+	switch {
+	case evaluated && result.Active != wasActive:
+		transition = maintenanceWindowTransition(result, previousWindow, now)
+	case !evaluated && !result.Active:
+		transition = &hookTransition{Type: freezeoperatorv1alpha1.HookTypeOnActivate, Time: mw.CreationTimestamp.Time}
+	}
+	requeueAfter := result.RequeueAfter
+	if err := reconcileHooks(ctx, r.Client, r.Scheme, r.Recorder, mw, maintenanceWindowHolder(mw), mw.Spec.Behavior.Hooks,
+		transition, &mw.Status.Hooks, &mw.Status.Conditions); err != nil {
+		logger.Error(err, "failed to reconcile hooks")
+		requeueAfter = min(requeueAfter, time.Minute)
+	}
+
+	// Write the summary of the period outside the windows once a window opens
+	if result.Active {
+		if err := reconcileSummary(ctx, r.Client, r.Client, r.Recorder, r.Reports, mw, ref, result.ActiveWindow.StartTime.Time); err != nil {
+			logger.Error(err, "failed to record freeze summary")
+			if r.Recorder != nil {
+				r.Recorder.Event(mw, corev1.EventTypeWarning, reasonSummaryFailed, err.Error())
+			}
+			requeueAfter = min(requeueAfter, time.Minute)
+		}
+	}
+
+	// Publish what the policy covers
+	if inv, err := inventory.Compute(ctx, r.Client, &mw.Spec.Target); err != nil {
+		logger.Error(err, "failed to compute affected workloads")
+	} else {
+		mw.Status.AffectedWorkloads = inv.Summary()
+	}
+
 	// Update status
 	if err := r.Status().Patch(ctx, mw, mwPatch); err != nil {
 		return ctrl.Result{}, err
@@ -197,7 +309,22 @@ func (r *MaintenanceWindowReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	}
 
 	// Requeue at next state change
-	return ctrl.Result{RequeueAfter: result.RequeueAfter}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// maintenanceWindowTransition returns the hook transition of a MaintenanceWindow
+// whose window opened or closed. Opening a window stops enforcement and is
+// identified by the window start; closing one starts enforcement and is
+// identified by the end of the window recorded in status.
+func maintenanceWindowTransition(result *evaluationResult, previous *freezeoperatorv1alpha1.WindowStatus, now time.Time) *hookTransition {
+	if result.Active {
+		return &hookTransition{Type: freezeoperatorv1alpha1.HookTypeOnDeactivate, Time: result.ActiveWindow.StartTime.Time}
+	}
+	at := now
+	if previous != nil && !previous.EndTime.IsZero() && !previous.EndTime.After(now) {
+		at = previous.EndTime.Time
+	}
+	return &hookTransition{Type: freezeoperatorv1alpha1.HookTypeOnActivate, Time: at}
 }
 
 type evaluationResult struct {
@@ -265,19 +392,46 @@ func (r *MaintenanceWindowReconciler) evaluateWindows(now time.Time, mw *freezeo
 	return result, nil
 }
 
-func (r *MaintenanceWindowReconciler) updateCronJobs(ctx context.Context, mw *freezeoperatorv1alpha1.MaintenanceWindow, active bool) error {
+func (r *MaintenanceWindowReconciler) updateCronJobs(ctx context.Context, mw *freezeoperatorv1alpha1.MaintenanceWindow, active bool) (cronJobResult, error) {
 	// For MaintenanceWindow: active=true means INSIDE window, so DON'T suspend
 	// For ChangeFreeze: active=true means freeze is on, so DO suspend
 	// MaintenanceWindow logic: suspend when OUTSIDE window (active=false)
-	shouldSuspend := !active
+	shouldSuspend := mw.Spec.Behavior.SuspendCronJobs && !active
+
+	return updateCronJobsForPolicy(ctx, r.Client, &mw.Spec.Target, maintenanceWindowHolder(mw), shouldSuspend)
+}
+
+// maintenanceWindowHolder returns the held-by reference of mw.
+func maintenanceWindowHolder(mw *freezeoperatorv1alpha1.MaintenanceWindow) string {
+	return hold.Policy(string(policy.PolicyKindMaintenanceWindow), mw.Name)
+}
 
-	return r.updateCronJobsForPolicy(ctx, &mw.Spec.Target, mw.Name, shouldSuspend)
+// enqueueMaintenanceWindows returns a map function enqueueing every MaintenanceWindow that want matches.
+func (r *MaintenanceWindowReconciler) enqueueMaintenanceWindows(want policyFilter) handler.MapFunc {
+	return func(ctx context.Context, _ client.Object) []reconcile.Request {
+		var list freezeoperatorv1alpha1.MaintenanceWindowList
+		if err := r.List(ctx, &list); err != nil {
+			log.FromContext(ctx).Error(err, "failed to list MaintenanceWindows")
+			return nil
+		}
+		var reqs []reconcile.Request
+		for i := range list.Items {
+			mw := &list.Items[i]
+			if want(&mw.Spec.Target, &mw.Spec.Behavior) {
+				reqs = append(reqs, reconcile.Request{NamespacedName: client.ObjectKey{Name: mw.Name}})
+			}
+		}
+		return reqs
+	}
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *MaintenanceWindowReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	b, err := watchPolicyTargets(mgr, ctrl.NewControllerManagedBy(mgr).
 		For(&freezeoperatorv1alpha1.MaintenanceWindow{}).
-		Named("maintenancewindow").
-		Complete(r)
+		Owns(&batchv1.Job{}), r.enqueueMaintenanceWindows)
+	if err != nil {
+		return err
+	}
+	return b.Named("maintenancewindow").Complete(r)
 }
@@ -0,0 +1,419 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/autoscaling"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/gitops"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/hold"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/metrics"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/policy"
+)
+
+const (
+	reasonOrphanDetected = "OrphanDetected"
+	reasonOrphanRestored = "OrphanRestored"
+
+	orphanKindGitOps     = "GitOps"
+	orphanKindAutoscaler = "Autoscaler"
+)
+
+// OrphanSweeper periodically restores CronJobs, Deployments, autoscalers and
+// GitOps objects held by a policy that no longer exists or is not active. Such objects are left behind
+// when the operator is uninstalled, a policy is renamed or a finalizer is
+// removed by hand. In dry-run mode orphans are only reported.
+type OrphanSweeper struct {
+	Client   client.Client
+	Recorder record.EventRecorder
+
+	// APIReader re-reads a policy before the objects it held are released; the
+	// cached client is used when nil.
+	APIReader client.Reader
+
+	// Interval is the time between two sweeps.
+	Interval time.Duration
+	// DryRun reports orphans through logs, Events and metrics without restoring them.
+	DryRun bool
+}
+
+// NeedLeaderElection makes only the leader sweep, like the controllers.
+func (s *OrphanSweeper) NeedLeaderElection() bool {
+	return true
+}
+
+// Start sweeps every Interval until ctx is cancelled.
+func (s *OrphanSweeper) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("orphan-sweeper")
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		orphans, err := s.Sweep(ctx)
+		if err != nil {
+			logger.Error(err, "orphan sweep failed")
+			return
+		}
+		logger.V(1).Info("orphan sweep finished", "orphans", orphans, "dryRun", s.DryRun)
+	}, s.Interval)
+	return nil
+}
+
+// policyHolds records what an existing policy holds right now.
+type policyHolds struct {
+	cronJobs    bool
+	deployments bool
+	autoscalers bool
+	gitOps      bool
+}
+
+// Sweep runs a single sweep and returns the number of orphans found per kind.
+func (s *OrphanSweeper) Sweep(ctx context.Context) (map[string]int, error) {
+	holds, err := s.policyHolds(ctx, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	orphans := map[string]int{
+		string(freezeoperatorv1alpha1.TargetKindCronJob):    0,
+		string(freezeoperatorv1alpha1.TargetKindDeployment): 0,
+		orphanKindAutoscaler:                                0,
+		orphanKindGitOps:                                    0,
+	}
+	var errs []error
+
+	n, err := s.sweepCronJobs(ctx, holds)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("cronjobs: %w", err))
+	}
+	orphans[string(freezeoperatorv1alpha1.TargetKindCronJob)] = n
+
+	n, err = s.sweepDeployments(ctx, holds)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("deployments: %w", err))
+	}
+	orphans[string(freezeoperatorv1alpha1.TargetKindDeployment)] = n
+
+	n, err = s.sweepAutoscalers(ctx, holds)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("autoscalers: %w", err))
+	}
+	orphans[orphanKindAutoscaler] = n
+
+	n, err = s.sweepGitOps(ctx, holds)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("gitops: %w", err))
+	}
+	orphans[orphanKindGitOps] = n
+
+	for kind, n := range orphans {
+		metrics.OrphanedObjects.WithLabelValues(kind).Set(float64(n))
+	}
+	return orphans, errors.Join(errs...)
+}
+
+// policyHolds indexes the existing policies by holder reference. A ChangeFreeze
+// holds objects while its freeze period runs, a MaintenanceWindow while all its
+// windows are closed. A window that cannot be evaluated counts as closed, so
+// that the sweeper never releases what a policy might still hold.
+func (s *OrphanSweeper) policyHolds(ctx context.Context, now time.Time) (map[string]policyHolds, error) {
+	out := make(map[string]policyHolds)
+
+	var cfs freezeoperatorv1alpha1.ChangeFreezeList
+	if err := s.Client.List(ctx, &cfs); err != nil {
+		return nil, fmt.Errorf("list ChangeFreezes: %w", err)
+	}
+	for i := range cfs.Items {
+		cf := &cfs.Items[i]
+		out[hold.Policy(string(policy.PolicyKindChangeFreeze), cf.Name)] = changeFreezeHolds(cf, now)
+	}
+
+	var mws freezeoperatorv1alpha1.MaintenanceWindowList
+	if err := s.Client.List(ctx, &mws); err != nil {
+		return nil, fmt.Errorf("list MaintenanceWindows: %w", err)
+	}
+	for i := range mws.Items {
+		mw := &mws.Items[i]
+		out[maintenanceWindowHolder(mw)] = maintenanceWindowHolds(mw, now)
+	}
+
+	return out, nil
+}
+
+func changeFreezeHolds(cf *freezeoperatorv1alpha1.ChangeFreeze, now time.Time) policyHolds {
+	return holdsFor(&cf.Spec.Behavior, policy.ChangeFreezeActive(cf, now))
+}
+
+func maintenanceWindowHolds(mw *freezeoperatorv1alpha1.MaintenanceWindow, now time.Time) policyHolds {
+	closed := true
+	if res, err := (&MaintenanceWindowReconciler{}).evaluateWindows(now, mw); err == nil {
+		closed = !res.Active
+	}
+	return holdsFor(&mw.Spec.Behavior, closed)
+}
+
+func holdsFor(behavior *freezeoperatorv1alpha1.PolicyBehaviorSpec, enforcing bool) policyHolds {
+	return policyHolds{
+		cronJobs:    enforcing && behavior.SuspendCronJobs,
+		deployments: enforcing && behavior.PauseDeployments,
+		autoscalers: enforcing && behavior.PinAutoscalers,
+		gitOps:      enforcing && behavior.GitOps != nil && behavior.GitOps.Enabled,
+	}
+}
+
+// stale reports whether ref no longer holds an object. A bare policy name, as
+// written by the legacy managed-by annotation and by the GitOps reconciler, is
+// live if a policy of either kind with that name holds the object.
+func stale(holds map[string]policyHolds, ref string, holding func(policyHolds) bool) bool {
+	if strings.Contains(ref, "/") {
+		return !holding(holds[ref])
+	}
+	return !holding(holds[hold.Policy(string(policy.PolicyKindChangeFreeze), ref)]) &&
+		!holding(holds[hold.Policy(string(policy.PolicyKindMaintenanceWindow), ref)])
+}
+
+// staleRefs returns the refs that no longer hold an object. Policies are listed
+// before the objects they hold, so a policy created or activated in between would
+// look stale; every stale ref is confirmed by re-reading its policy uncached.
+func (s *OrphanSweeper) staleRefs(ctx context.Context, holds map[string]policyHolds, refs []string, holding func(policyHolds) bool) ([]string, error) {
+	refs = slices.DeleteFunc(refs, func(ref string) bool { return !stale(holds, ref, holding) })
+	if len(refs) == 0 {
+		return nil, nil
+	}
+
+	now := time.Now().UTC()
+	current := make(map[string]policyHolds)
+	for _, ref := range refs {
+		candidates := []string{ref}
+		if !strings.Contains(ref, "/") {
+			candidates = []string{
+				hold.Policy(string(policy.PolicyKindChangeFreeze), ref),
+				hold.Policy(string(policy.PolicyKindMaintenanceWindow), ref),
+			}
+		}
+		for _, c := range candidates {
+			if _, ok := current[c]; ok {
+				continue
+			}
+			h, err := s.currentHolds(ctx, c, now)
+			if err != nil {
+				return nil, err
+			}
+			current[c] = h
+		}
+	}
+	return slices.DeleteFunc(refs, func(ref string) bool { return !stale(current, ref, holding) }), nil
+}
+
+// currentHolds reads the policy behind the holder reference ref through the API
+// reader and returns what it holds at now.
+func (s *OrphanSweeper) currentHolds(ctx context.Context, ref string, now time.Time) (policyHolds, error) {
+	reader := s.APIReader
+	if reader == nil {
+		reader = s.Client
+	}
+	kind, name, _ := strings.Cut(ref, "/")
+	switch policy.PolicyKind(kind) {
+	case policy.PolicyKindChangeFreeze:
+		cf := &freezeoperatorv1alpha1.ChangeFreeze{}
+		if err := reader.Get(ctx, client.ObjectKey{Name: name}, cf); err != nil {
+			return policyHolds{}, client.IgnoreNotFound(err)
+		}
+		return changeFreezeHolds(cf, now), nil
+	case policy.PolicyKindMaintenanceWindow:
+		mw := &freezeoperatorv1alpha1.MaintenanceWindow{}
+		if err := reader.Get(ctx, client.ObjectKey{Name: name}, mw); err != nil {
+			return policyHolds{}, client.IgnoreNotFound(err)
+		}
+		return maintenanceWindowHolds(mw, now), nil
+	}
+	return policyHolds{}, nil
+}
+
+func (s *OrphanSweeper) sweepCronJobs(ctx context.Context, holds map[string]policyHolds) (int, error) {
+	var cronList batchv1.CronJobList
+	if err := s.Client.List(ctx, &cronList); err != nil {
+		return 0, fmt.Errorf("list cronjobs: %w", err)
+	}
+
+	orphans := 0
+	var errs []error
+	for i := range cronList.Items {
+		cron := &cronList.Items[i]
+		staleHolders, err := s.staleRefs(ctx, holds, hold.Holders(cron.Annotations), func(p policyHolds) bool { return p.cronJobs })
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if len(staleHolders) == 0 {
+			continue
+		}
+		orphans++
+
+		if s.DryRun {
+			s.report(ctx, cron, string(freezeoperatorv1alpha1.TargetKindCronJob), staleHolders)
+			continue
+		}
+		for _, h := range staleHolders {
+			reconcileCronJob(cron, h, false)
+		}
+		if err := s.Client.Update(ctx, cron); err != nil {
+			errs = append(errs, fmt.Errorf("update cronjob %s/%s: %w", cron.Namespace, cron.Name, err))
+			continue
+		}
+		s.restored(ctx, cron, string(freezeoperatorv1alpha1.TargetKindCronJob), staleHolders)
+	}
+	return orphans, errors.Join(errs...)
+}
+
+func (s *OrphanSweeper) sweepDeployments(ctx context.Context, holds map[string]policyHolds) (int, error) {
+	var depList appsv1.DeploymentList
+	if err := s.Client.List(ctx, &depList); err != nil {
+		return 0, fmt.Errorf("list deployments: %w", err)
+	}
+
+	orphans := 0
+	var errs []error
+	for i := range depList.Items {
+		dep := &depList.Items[i]
+		staleHolders, err := s.staleRefs(ctx, holds, hold.Holders(dep.Annotations), func(p policyHolds) bool { return p.deployments })
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if len(staleHolders) == 0 {
+			continue
+		}
+		orphans++
+
+		if s.DryRun {
+			s.report(ctx, dep, string(freezeoperatorv1alpha1.TargetKindDeployment), staleHolders)
+			continue
+		}
+		for _, h := range staleHolders {
+			reconcileDeployment(dep, h, false)
+		}
+		if err := s.Client.Update(ctx, dep); err != nil {
+			errs = append(errs, fmt.Errorf("update deployment %s/%s: %w", dep.Namespace, dep.Name, err))
+			continue
+		}
+		s.restored(ctx, dep, string(freezeoperatorv1alpha1.TargetKindDeployment), staleHolders)
+	}
+	return orphans, errors.Join(errs...)
+}
+
+func (s *OrphanSweeper) sweepAutoscalers(ctx context.Context, holds map[string]policyHolds) (int, error) {
+	managed, err := autoscaling.ListManaged(ctx, s.Client)
+	if err != nil {
+		return 0, err
+	}
+
+	orphans := 0
+	var errs []error
+	for _, obj := range managed {
+		staleHolders, err := s.staleRefs(ctx, holds, hold.Holders(obj.GetAnnotations()), func(p policyHolds) bool { return p.autoscalers })
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if len(staleHolders) == 0 {
+			continue
+		}
+		orphans++
+
+		if s.DryRun {
+			s.report(ctx, obj, orphanKindAutoscaler, staleHolders)
+			continue
+		}
+		var failed error
+		for _, h := range staleHolders {
+			if failed = autoscaling.Release(ctx, s.Client, obj, h); failed != nil {
+				break
+			}
+		}
+		if failed != nil {
+			errs = append(errs, failed)
+			continue
+		}
+		s.restored(ctx, obj, orphanKindAutoscaler, staleHolders)
+	}
+	return orphans, errors.Join(errs...)
+}
+
+func (s *OrphanSweeper) sweepGitOps(ctx context.Context, holds map[string]policyHolds) (int, error) {
+	managed, err := gitops.ListManaged(ctx, s.Client)
+	if err != nil {
+		return 0, err
+	}
+
+	orphans := 0
+	var errs []error
+	for _, obj := range managed {
+		ref := obj.GetAnnotations()[gitops.AnnotationManagedByPolicy]
+		staleRefs, err := s.staleRefs(ctx, holds, []string{ref}, func(p policyHolds) bool { return p.gitOps })
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if len(staleRefs) == 0 {
+			continue
+		}
+		orphans++
+
+		if s.DryRun {
+			s.report(ctx, obj, orphanKindGitOps, []string{ref})
+			continue
+		}
+		if err := gitops.Restore(ctx, s.Client, obj); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		s.restored(ctx, obj, orphanKindGitOps, []string{ref})
+	}
+	return orphans, errors.Join(errs...)
+}
+
+func (s *OrphanSweeper) report(ctx context.Context, obj client.Object, kind string, refs []string) {
+	log.FromContext(ctx).Info("orphaned object found (dry run)", "kind", kind,
+		"namespace", obj.GetNamespace(), "name", obj.GetName(), "heldBy", refs)
+	if s.Recorder != nil {
+		s.Recorder.Event(obj, corev1.EventTypeWarning, reasonOrphanDetected,
+			fmt.Sprintf("Held by %s, which no longer exists or is not active; not restored in dry-run mode", strings.Join(refs, ", ")))
+	}
+}
+
+func (s *OrphanSweeper) restored(ctx context.Context, obj client.Object, kind string, refs []string) {
+	log.FromContext(ctx).Info("orphaned object restored", "kind", kind,
+		"namespace", obj.GetNamespace(), "name", obj.GetName(), "heldBy", refs)
+	metrics.OrphansRestored.WithLabelValues(kind).Inc()
+	if s.Recorder != nil {
+		s.Recorder.Event(obj, corev1.EventTypeNormal, reasonOrphanRestored,
+			fmt.Sprintf("Released by %s, which no longer exists or is not active", strings.Join(refs, ", ")))
+	}
+}
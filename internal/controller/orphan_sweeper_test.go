@@ -0,0 +1,308 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/autoscaling"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/gitops"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/hold"
+)
+
+func newChangeFreeze(name string, active bool) *freezeoperatorv1alpha1.ChangeFreeze {
+	start := time.Now().Add(-time.Hour)
+	if !active {
+		start = time.Now().Add(time.Hour)
+	}
+	return &freezeoperatorv1alpha1.ChangeFreeze{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: freezeoperatorv1alpha1.ChangeFreezeSpec{
+			StartTime: metav1.NewTime(start),
+			EndTime:   metav1.NewTime(start.Add(2 * time.Hour)),
+			Behavior: freezeoperatorv1alpha1.PolicyBehaviorSpec{
+				SuspendCronJobs:  true,
+				PauseDeployments: true,
+				PinAutoscalers:   true,
+				GitOps:           &freezeoperatorv1alpha1.GitOpsSpec{Enabled: true},
+			},
+		},
+	}
+}
+
+func newHeldCronJob(name string, heldBy string) *batchv1.CronJob {
+	cron := newCronJob(name, nil, true)
+	cron.Annotations = map[string]string{hold.AnnotationHeldBy: heldBy, annotationOriginalSuspend: "false"}
+	return cron
+}
+
+func newHeldDeployment(name string, heldBy string) *appsv1.Deployment {
+	dep := newDeployment(true, nil, map[string]string{hold.AnnotationHeldBy: heldBy, annotationOriginalPaused: "false"})
+	dep.Name = name
+	return dep
+}
+
+func newPinnedHPA(name string, heldBy string) *autoscalingv2.HorizontalPodAutoscaler {
+	minReplicas := int32(3)
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testCronNS, Annotations: map[string]string{
+			hold.AnnotationHeldBy:                     heldBy,
+			autoscaling.AnnotationManaged:             "true",
+			autoscaling.AnnotationOriginalMinReplicas: "1",
+			autoscaling.AnnotationOriginalMaxReplicas: "10",
+		}},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: name},
+			MinReplicas:    &minReplicas,
+			MaxReplicas:    3,
+		},
+	}
+}
+
+func newPausedScaledObject(name string, heldBy string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(testScaledObjectGVK)
+	obj.SetName(name)
+	obj.SetNamespace(testCronNS)
+	obj.SetAnnotations(map[string]string{
+		hold.AnnotationHeldBy:            heldBy,
+		autoscaling.AnnotationManaged:    "true",
+		autoscaling.AnnotationKEDAPaused: "true",
+	})
+	obj.Object["spec"] = map[string]any{"scaleTargetRef": map[string]any{"name": name}}
+	return obj
+}
+
+func newPausedKustomization(policyRef string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(testFluxKustomizationGVK)
+	obj.SetName("infra")
+	obj.SetNamespace("flux-system")
+	obj.SetAnnotations(map[string]string{
+		gitops.AnnotationManaged:         "true",
+		gitops.AnnotationManagedByPolicy: policyRef,
+		gitops.AnnotationOriginalSuspend: "false",
+	})
+	obj.Object["spec"] = map[string]any{"suspend": true}
+	return obj
+}
+
+func getKustomization(ctx context.Context, c client.Client) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(testFluxKustomizationGVK)
+	_ = c.Get(ctx, client.ObjectKey{Name: "infra", Namespace: "flux-system"}, obj)
+	return obj
+}
+
+func TestOrphanSweeper_RestoresOrphans(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeClient(
+		newChangeFreeze("active", true),
+		newChangeFreeze("upcoming", false),
+		newHeldCronJob("live", "ChangeFreeze/active"),
+		newHeldCronJob("deleted", "ChangeFreeze/gone"),
+		newHeldCronJob("inactive", "ChangeFreeze/upcoming"),
+		newHeldCronJob("shared", "ChangeFreeze/active,ChangeFreeze/gone"),
+		newPausedKustomization("gone"),
+	)
+
+	orphans, err := (&OrphanSweeper{Client: c}).Sweep(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if orphans["CronJob"] != 3 || orphans[orphanKindGitOps] != 1 {
+		t.Errorf("expected 3 CronJob and 1 GitOps orphans, got %v", orphans)
+	}
+
+	for name, want := range map[string]bool{"live": true, "deleted": false, "inactive": false, "shared": true} {
+		if got := isSuspended(getCronJob(ctx, c, name)); got != want {
+			t.Errorf("CronJob %s: suspended=%v, want %v", name, got, want)
+		}
+	}
+	if got := getCronJob(ctx, c, "shared").Annotations[hold.AnnotationHeldBy]; got != "ChangeFreeze/active" {
+		t.Errorf("expected only the live holder to remain, got %q", got)
+	}
+	if suspended, _, _ := unstructured.NestedBool(getKustomization(ctx, c).Object, "spec", "suspend"); suspended {
+		t.Error("Kustomization paused by a deleted policy should be resumed")
+	}
+}
+
+func TestOrphanSweeper_DryRun(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeClient(
+		newHeldCronJob("deleted", "ChangeFreeze/gone"),
+		newPausedKustomization("gone"),
+	)
+
+	orphans, err := (&OrphanSweeper{Client: c, DryRun: true}).Sweep(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if orphans["CronJob"] != 1 || orphans[orphanKindGitOps] != 1 {
+		t.Errorf("expected 1 CronJob and 1 GitOps orphan, got %v", orphans)
+	}
+	if !isSuspended(getCronJob(ctx, c, "deleted")) {
+		t.Error("dry run must not resume CronJobs")
+	}
+	if suspended, _, _ := unstructured.NestedBool(getKustomization(ctx, c).Object, "spec", "suspend"); !suspended {
+		t.Error("dry run must not resume GitOps objects")
+	}
+}
+
+func TestOrphanSweeper_LegacyAndGitOpsNames(t *testing.T) {
+	ctx := context.Background()
+	legacy := newCronJob("legacy", nil, true)
+	legacy.Annotations = map[string]string{hold.AnnotationManagedBy: "active", annotationOriginalSuspend: "false"}
+	c := newFakeClient(newChangeFreeze("active", true), legacy, newPausedKustomization("active"))
+
+	orphans, err := (&OrphanSweeper{Client: c}).Sweep(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if orphans["CronJob"] != 0 || orphans[orphanKindGitOps] != 0 {
+		t.Errorf("bare names of an active policy are not orphans, got %v", orphans)
+	}
+	if !isSuspended(getCronJob(ctx, c, "legacy")) {
+		t.Error("CronJob held by an active policy must stay suspended")
+	}
+}
+
+func TestOrphanSweeper_KeepsHoldsOfNewPolicies(t *testing.T) {
+	ctx := context.Background()
+	// The cache has not seen the freeze that suspended the CronJob yet.
+	cached := newFakeClient(newHeldCronJob("fresh", "ChangeFreeze/new"), newHeldCronJob("orphan", "ChangeFreeze/gone"))
+	live := newFakeClient(newChangeFreeze("new", true))
+
+	orphans, err := (&OrphanSweeper{Client: cached, APIReader: live}).Sweep(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if orphans["CronJob"] != 1 {
+		t.Errorf("expected 1 CronJob orphan, got %v", orphans)
+	}
+	for name, want := range map[string]bool{"fresh": true, "orphan": false} {
+		cron := &batchv1.CronJob{}
+		if err := cached.Get(ctx, client.ObjectKey{Name: name, Namespace: testCronNS}, cron); err != nil {
+			t.Fatal(err)
+		}
+		if *cron.Spec.Suspend != want {
+			t.Errorf("CronJob %s: suspend=%v, want %v", name, *cron.Spec.Suspend, want)
+		}
+	}
+}
+
+func TestOrphanSweeper_Deployments(t *testing.T) {
+	ctx := context.Background()
+	// A policy that turned pauseDeployments off no longer holds its Deployments.
+	unpaused := newChangeFreeze("unpaused", true)
+	unpaused.Spec.Behavior.PauseDeployments = false
+	c := newFakeClient(
+		newChangeFreeze("active", true),
+		unpaused,
+		newHeldDeployment("live", "ChangeFreeze/active"),
+		newHeldDeployment("deleted", "ChangeFreeze/gone"),
+		newHeldDeployment("disabled", "ChangeFreeze/unpaused"),
+		newHeldDeployment("shared", "ChangeFreeze/active,ChangeFreeze/gone"),
+	)
+
+	orphans, err := (&OrphanSweeper{Client: c}).Sweep(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if orphans["Deployment"] != 3 {
+		t.Errorf("expected 3 Deployment orphans, got %v", orphans)
+	}
+	for name, want := range map[string]bool{"live": true, "deleted": false, "disabled": false, "shared": true} {
+		dep := &appsv1.Deployment{}
+		if err := c.Get(ctx, client.ObjectKey{Name: name, Namespace: testCronNS}, dep); err != nil {
+			t.Fatal(err)
+		}
+		if dep.Spec.Paused != want {
+			t.Errorf("Deployment %s: paused=%v, want %v", name, dep.Spec.Paused, want)
+		}
+		if name == "shared" && dep.Annotations[hold.AnnotationHeldBy] != "ChangeFreeze/active" {
+			t.Errorf("expected only the live holder to remain, got %q", dep.Annotations[hold.AnnotationHeldBy])
+		}
+	}
+}
+
+func TestOrphanSweeper_HorizontalPodAutoscalers(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeClient(
+		newChangeFreeze("active", true),
+		newPinnedHPA("live", "ChangeFreeze/active"),
+		newPinnedHPA("deleted", "ChangeFreeze/gone"),
+	)
+
+	orphans, err := (&OrphanSweeper{Client: c}).Sweep(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if orphans[orphanKindAutoscaler] != 1 {
+		t.Errorf("expected 1 autoscaler orphan, got %v", orphans)
+	}
+	for name, wantMax := range map[string]int32{"live": 3, "deleted": 10} {
+		hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+		if err := c.Get(ctx, client.ObjectKey{Name: name, Namespace: testCronNS}, hpa); err != nil {
+			t.Fatal(err)
+		}
+		if hpa.Spec.MaxReplicas != wantMax {
+			t.Errorf("HPA %s: maxReplicas=%d, want %d", name, hpa.Spec.MaxReplicas, wantMax)
+		}
+	}
+}
+
+func TestOrphanSweeper_ScaledObjects(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeClient(
+		newChangeFreeze("active", true),
+		newPausedScaledObject("deleted", "ChangeFreeze/gone"),
+		newPausedScaledObject("shared", "ChangeFreeze/active,ChangeFreeze/gone"),
+	)
+
+	orphans, err := (&OrphanSweeper{Client: c}).Sweep(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if orphans[orphanKindAutoscaler] != 2 {
+		t.Errorf("expected 2 autoscaler orphans, got %v", orphans)
+	}
+	for name, want := range map[string]string{"deleted": "", "shared": "ChangeFreeze/active"} {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(testScaledObjectGVK)
+		if err := c.Get(ctx, client.ObjectKey{Name: name, Namespace: testCronNS}, obj); err != nil {
+			t.Fatal(err)
+		}
+		ann := obj.GetAnnotations()
+		if ann[hold.AnnotationHeldBy] != want {
+			t.Errorf("ScaledObject %s: held-by=%q, want %q", name, ann[hold.AnnotationHeldBy], want)
+		}
+		if paused := ann[autoscaling.AnnotationKEDAPaused] == "true"; paused != (want != "") {
+			t.Errorf("ScaledObject %s: paused=%v, want %v", name, paused, want != "")
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"fmt"
+	"slices"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/gitops"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/inventory"
+)
+
+// policyFilter reports whether a policy with the given target and behavior has to
+// be reconciled when a watched object changes.
+type policyFilter func(target *freezeoperatorv1alpha1.TargetSpec, behavior *freezeoperatorv1alpha1.PolicyBehaviorSpec) bool
+
+// targetsKind returns a filter matching policies that target kind. Their
+// inventory, and for CronJobs their suspension, depends on objects of that kind.
+func targetsKind(kind freezeoperatorv1alpha1.TargetKind) policyFilter {
+	return func(target *freezeoperatorv1alpha1.TargetSpec, _ *freezeoperatorv1alpha1.PolicyBehaviorSpec) bool {
+		return slices.Contains(target.Kinds, kind)
+	}
+}
+
+// allPolicies matches every policy; namespace labels decide what any policy covers.
+func allPolicies(_ *freezeoperatorv1alpha1.TargetSpec, _ *freezeoperatorv1alpha1.PolicyBehaviorSpec) bool {
+	return true
+}
+
+// managesGitOps returns a filter matching policies that pause the given GitOps provider.
+func managesGitOps(provider freezeoperatorv1alpha1.GitOpsProvider) policyFilter {
+	return func(_ *freezeoperatorv1alpha1.TargetSpec, behavior *freezeoperatorv1alpha1.PolicyBehaviorSpec) bool {
+		g := behavior.GitOps
+		return g != nil && g.Enabled && slices.Contains(g.Providers, provider)
+	}
+}
+
+// watchPolicyTargets adds the watches shared by the ChangeFreeze and
+// MaintenanceWindow controllers, so that side effects and the affected-workload
+// inventory follow workloads, namespaces and GitOps objects created, deleted or
+// relabelled mid-freeze within seconds instead of on the next requeue. Only
+// creations, deletions and label changes are of interest; the operator's own
+// annotation updates do not pass the predicate. Workloads other than CronJobs
+// are watched as metadata only. GitOps kinds whose CRDs are not installed are
+// skipped.
+func watchPolicyTargets(
+	mgr ctrl.Manager,
+	b *builder.Builder,
+	enqueue func(policyFilter) handler.MapFunc,
+) (*builder.Builder, error) {
+	labelsChanged := builder.WithPredicates(predicate.LabelChangedPredicate{})
+
+	b = b.
+		Watches(&batchv1.CronJob{}, handler.EnqueueRequestsFromMapFunc(enqueue(targetsKind(freezeoperatorv1alpha1.TargetKindCronJob))), labelsChanged).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(enqueue(allPolicies)), labelsChanged)
+
+	for kind, gvk := range inventory.Kinds {
+		if kind == freezeoperatorv1alpha1.TargetKindCronJob {
+			continue
+		}
+		obj := &metav1.PartialObjectMetadata{}
+		obj.SetGroupVersionKind(gvk)
+		b = b.Watches(obj, handler.EnqueueRequestsFromMapFunc(enqueue(targetsKind(kind))), labelsChanged)
+	}
+
+	for provider, gvks := range gitops.Kinds {
+		installed, err := installedKinds(mgr.GetRESTMapper(), gvks)
+		if err != nil {
+			return nil, err
+		}
+		for _, gvk := range gvks {
+			if !slices.Contains(installed, gvk) {
+				mgr.GetLogger().Info("GitOps CRD not installed, not watching it", "kind", gvk.String())
+				continue
+			}
+			obj := &metav1.PartialObjectMetadata{}
+			obj.SetGroupVersionKind(gvk)
+			b = b.Watches(obj, handler.EnqueueRequestsFromMapFunc(enqueue(managesGitOps(provider))), labelsChanged)
+		}
+	}
+
+	return b, nil
+}
+
+// installedKinds returns the kinds among gvks that the API server serves.
+func installedKinds(mapper meta.RESTMapper, gvks []schema.GroupVersionKind) ([]schema.GroupVersionKind, error) {
+	var out []schema.GroupVersionKind
+	for _, gvk := range gvks {
+		if _, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return nil, fmt.Errorf("map %s: %w", gvk, err)
+		}
+		out = append(out, gvk)
+	}
+	return out, nil
+}
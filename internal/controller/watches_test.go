@@ -0,0 +1,122 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/gitops"
+)
+
+func TestPolicyFilters(t *testing.T) {
+	cronTarget := &freezeoperatorv1alpha1.TargetSpec{Kinds: []freezeoperatorv1alpha1.TargetKind{freezeoperatorv1alpha1.TargetKindCronJob}}
+	deployTarget := &freezeoperatorv1alpha1.TargetSpec{Kinds: []freezeoperatorv1alpha1.TargetKind{freezeoperatorv1alpha1.TargetKindDeployment}}
+	argo := &freezeoperatorv1alpha1.PolicyBehaviorSpec{GitOps: &freezeoperatorv1alpha1.GitOpsSpec{
+		Enabled:   true,
+		Providers: []freezeoperatorv1alpha1.GitOpsProvider{freezeoperatorv1alpha1.GitOpsProviderArgoCD},
+	}}
+
+	tests := []struct {
+		name     string
+		filter   policyFilter
+		target   *freezeoperatorv1alpha1.TargetSpec
+		behavior *freezeoperatorv1alpha1.PolicyBehaviorSpec
+		want     bool
+	}{
+		{"cronjobs targeted", targetsKind(freezeoperatorv1alpha1.TargetKindCronJob), cronTarget, &freezeoperatorv1alpha1.PolicyBehaviorSpec{}, true},
+		{"cronjobs not targeted", targetsKind(freezeoperatorv1alpha1.TargetKindCronJob), deployTarget, &freezeoperatorv1alpha1.PolicyBehaviorSpec{SuspendCronJobs: true}, false},
+		{"deployments targeted", targetsKind(freezeoperatorv1alpha1.TargetKindDeployment), deployTarget, &freezeoperatorv1alpha1.PolicyBehaviorSpec{}, true},
+		{"namespaces", allPolicies, cronTarget, &freezeoperatorv1alpha1.PolicyBehaviorSpec{}, true},
+		{"argocd provider", managesGitOps(freezeoperatorv1alpha1.GitOpsProviderArgoCD), deployTarget, argo, true},
+		{"flux provider not configured", managesGitOps(freezeoperatorv1alpha1.GitOpsProviderFlux), deployTarget, argo, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter(tt.target, tt.behavior); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnqueuePolicies(t *testing.T) {
+	target := freezeoperatorv1alpha1.TargetSpec{Kinds: []freezeoperatorv1alpha1.TargetKind{freezeoperatorv1alpha1.TargetKindCronJob}}
+	c := newFakeClientBuilder(
+		&freezeoperatorv1alpha1.ChangeFreeze{
+			ObjectMeta: metav1.ObjectMeta{Name: "suspends"},
+			Spec: freezeoperatorv1alpha1.ChangeFreezeSpec{
+				Target:   target,
+				Behavior: freezeoperatorv1alpha1.PolicyBehaviorSpec{SuspendCronJobs: true},
+			},
+		},
+		&freezeoperatorv1alpha1.ChangeFreeze{
+			ObjectMeta: metav1.ObjectMeta{Name: "deployments-only"},
+			Spec: freezeoperatorv1alpha1.ChangeFreezeSpec{Target: freezeoperatorv1alpha1.TargetSpec{
+				Kinds: []freezeoperatorv1alpha1.TargetKind{freezeoperatorv1alpha1.TargetKindDeployment},
+			}},
+		},
+		&freezeoperatorv1alpha1.MaintenanceWindow{
+			ObjectMeta: metav1.ObjectMeta{Name: "weekdays"},
+			Spec: freezeoperatorv1alpha1.MaintenanceWindowSpec{
+				Target:   target,
+				Behavior: freezeoperatorv1alpha1.PolicyBehaviorSpec{SuspendCronJobs: true},
+			},
+		},
+	).Build()
+
+	cron := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "report", Namespace: "prod"}}
+
+	cfReqs := (&ChangeFreezeReconciler{Client: c}).enqueueChangeFreezes(targetsKind(freezeoperatorv1alpha1.TargetKindCronJob))(context.Background(), cron)
+	if len(cfReqs) != 1 || cfReqs[0].Name != "suspends" {
+		t.Errorf("expected only ChangeFreeze suspends, got %v", cfReqs)
+	}
+	mwReqs := (&MaintenanceWindowReconciler{Client: c}).enqueueMaintenanceWindows(targetsKind(freezeoperatorv1alpha1.TargetKindCronJob))(context.Background(), cron)
+	if len(mwReqs) != 1 || mwReqs[0].Name != "weekdays" {
+		t.Errorf("expected only MaintenanceWindow weekdays, got %v", mwReqs)
+	}
+}
+
+func TestInstalledKinds(t *testing.T) {
+	argo := gitops.Kinds[freezeoperatorv1alpha1.GitOpsProviderArgoCD]
+	flux := gitops.Kinds[freezeoperatorv1alpha1.GitOpsProviderFlux]
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	for _, gvk := range argo {
+		mapper.Add(gvk, meta.RESTScopeNamespace)
+	}
+
+	installed, err := installedKinds(mapper, slices.Concat(argo, flux))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(installed, argo) {
+		t.Errorf("expected only the ArgoCD kinds, got %v", installed)
+	}
+
+	none, err := installedKinds(mapper, []schema.GroupVersionKind{{Group: "example.com", Version: "v1", Kind: "Missing"}})
+	if err != nil || len(none) != 0 {
+		t.Errorf("expected no kinds and no error for a missing CRD, got %v, %v", none, err)
+	}
+}
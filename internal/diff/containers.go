@@ -0,0 +1,158 @@
+package diff
+
+import (
+	"fmt"
+	"slices"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+)
+
+// ContainerDiff describes how one container of a pod template changed.
+type ContainerDiff struct {
+	Name string
+	Init bool
+
+	// OldImage is empty for added containers, NewImage for removed ones.
+	OldImage string
+	NewImage string
+
+	Changes []freezev1alpha1.ContainerChange
+}
+
+// ImageChanged reports whether the container runs a new image: its image changed or
+// the container was added.
+func (d ContainerDiff) ImageChanged() bool {
+	return d.NewImage != "" && d.NewImage != d.OldImage
+}
+
+// PodTemplateChange summarizes the change to a workload's pod template.
+type PodTemplateChange struct {
+	// Containers lists changed, added and removed containers and init containers.
+	Containers []ContainerDiff
+
+	// PodSpecChanged is set when the pod template changed outside of containers and
+	// init containers, including its labels and annotations.
+	PodSpecChanged bool
+}
+
+// PodTemplate returns the pod template of a workload object.
+func PodTemplate(kind freezev1alpha1.TargetKind, obj runtime.Object) (*corev1.PodTemplateSpec, error) {
+	switch kind {
+	case freezev1alpha1.TargetKindDeployment:
+		if d, ok := obj.(*appsv1.Deployment); ok {
+			return &d.Spec.Template, nil
+		}
+	case freezev1alpha1.TargetKindStatefulSet:
+		if s, ok := obj.(*appsv1.StatefulSet); ok {
+			return &s.Spec.Template, nil
+		}
+	case freezev1alpha1.TargetKindDaemonSet:
+		if d, ok := obj.(*appsv1.DaemonSet); ok {
+			return &d.Spec.Template, nil
+		}
+	case freezev1alpha1.TargetKindCronJob:
+		if c, ok := obj.(*batchv1.CronJob); ok {
+			return &c.Spec.JobTemplate.Spec.Template, nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported kind: %s", kind)
+	}
+	return nil, fmt.Errorf("unexpected object type %T for kind %s", obj, kind)
+}
+
+// ComparePodTemplates returns the change from oldT to newT. A nil oldT describes a
+// newly created object, in which every container counts as added.
+func ComparePodTemplates(oldT, newT *corev1.PodTemplateSpec) PodTemplateChange {
+	var oldSpec corev1.PodSpec
+	if oldT != nil {
+		oldSpec = oldT.Spec
+	}
+	newSpec := newT.Spec
+
+	var out PodTemplateChange
+	out.Containers = append(out.Containers, compareContainers(oldSpec.InitContainers, newSpec.InitContainers, true)...)
+	out.Containers = append(out.Containers, compareContainers(oldSpec.Containers, newSpec.Containers, false)...)
+
+	if oldT != nil {
+		a, b := oldSpec.DeepCopy(), newSpec.DeepCopy()
+		a.Containers, b.Containers = nil, nil
+		a.InitContainers, b.InitContainers = nil, nil
+		out.PodSpecChanged = !equality.Semantic.DeepEqual(a, b) ||
+			!equality.Semantic.DeepEqual(oldT.Labels, newT.Labels) ||
+			!equality.Semantic.DeepEqual(oldT.Annotations, newT.Annotations)
+	}
+	return out
+}
+
+func compareContainers(oldCs, newCs []corev1.Container, init bool) []ContainerDiff {
+	var out []ContainerDiff
+	for i := range newCs {
+		n := &newCs[i]
+		idx := slices.IndexFunc(oldCs, func(c corev1.Container) bool { return c.Name == n.Name })
+		if idx < 0 {
+			out = append(out, ContainerDiff{
+				Name:     n.Name,
+				Init:     init,
+				NewImage: n.Image,
+				Changes:  []freezev1alpha1.ContainerChange{freezev1alpha1.ContainerChangeImage, freezev1alpha1.ContainerChangeOther},
+			})
+			continue
+		}
+		if changes := containerChanges(&oldCs[idx], n); len(changes) > 0 {
+			out = append(out, ContainerDiff{
+				Name:     n.Name,
+				Init:     init,
+				OldImage: oldCs[idx].Image,
+				NewImage: n.Image,
+				Changes:  changes,
+			})
+		}
+	}
+	for i := range oldCs {
+		o := &oldCs[i]
+		if !slices.ContainsFunc(newCs, func(c corev1.Container) bool { return c.Name == o.Name }) {
+			out = append(out, ContainerDiff{
+				Name:     o.Name,
+				Init:     init,
+				OldImage: o.Image,
+				Changes:  []freezev1alpha1.ContainerChange{freezev1alpha1.ContainerChangeOther},
+			})
+		}
+	}
+	return out
+}
+
+// containerChanges lists the categories in which o and n differ.
+func containerChanges(o, n *corev1.Container) []freezev1alpha1.ContainerChange {
+	var out []freezev1alpha1.ContainerChange
+	if o.Image != n.Image {
+		out = append(out, freezev1alpha1.ContainerChangeImage)
+	}
+	if !equality.Semantic.DeepEqual(o.Env, n.Env) || !equality.Semantic.DeepEqual(o.EnvFrom, n.EnvFrom) {
+		out = append(out, freezev1alpha1.ContainerChangeEnv)
+	}
+	if !equality.Semantic.DeepEqual(o.Resources, n.Resources) {
+		out = append(out, freezev1alpha1.ContainerChangeResources)
+	}
+	if !equality.Semantic.DeepEqual(o.Command, n.Command) || !equality.Semantic.DeepEqual(o.Args, n.Args) {
+		out = append(out, freezev1alpha1.ContainerChangeCommand)
+	}
+
+	a, b := o.DeepCopy(), n.DeepCopy()
+	for _, c := range []*corev1.Container{a, b} {
+		c.Image = ""
+		c.Env, c.EnvFrom = nil, nil
+		c.Resources = corev1.ResourceRequirements{}
+		c.Command, c.Args = nil, nil
+	}
+	if !equality.Semantic.DeepEqual(a, b) {
+		out = append(out, freezev1alpha1.ContainerChangeOther)
+	}
+	return out
+}
@@ -0,0 +1,108 @@
+package diff
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+)
+
+func podTemplate(containers ...corev1.Container) *corev1.PodTemplateSpec {
+	return &corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: containers}}
+}
+
+func TestComparePodTemplates_ImageChange(t *testing.T) {
+	g := NewWithT(t)
+
+	oldT := podTemplate(corev1.Container{Name: "app", Image: "img:v1"}, corev1.Container{Name: "sidecar", Image: "proxy:v1"})
+	newT := oldT.DeepCopy()
+	newT.Spec.Containers[0].Image = imgV2
+
+	change := ComparePodTemplates(oldT, newT)
+	g.Expect(change.PodSpecChanged).To(BeFalse())
+	g.Expect(change.Containers).To(HaveLen(1))
+	g.Expect(change.Containers[0].Name).To(Equal("app"))
+	g.Expect(change.Containers[0].ImageChanged()).To(BeTrue())
+	g.Expect(change.Containers[0].Changes).To(Equal([]freezev1alpha1.ContainerChange{freezev1alpha1.ContainerChangeImage}))
+}
+
+func TestComparePodTemplates_ChangeCategories(t *testing.T) {
+	g := NewWithT(t)
+
+	oldT := podTemplate(corev1.Container{Name: "app", Image: "img:v1"})
+	newT := oldT.DeepCopy()
+	newT.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "A", Value: "1"}}
+	newT.Spec.Containers[0].Args = []string{"--verbose"}
+	newT.Spec.Containers[0].WorkingDir = "/srv"
+
+	change := ComparePodTemplates(oldT, newT)
+	g.Expect(change.Containers).To(HaveLen(1))
+	g.Expect(change.Containers[0].ImageChanged()).To(BeFalse())
+	g.Expect(change.Containers[0].Changes).To(ConsistOf(
+		freezev1alpha1.ContainerChangeEnv,
+		freezev1alpha1.ContainerChangeCommand,
+		freezev1alpha1.ContainerChangeOther,
+	))
+}
+
+func TestComparePodTemplates_AddedAndRemovedContainers(t *testing.T) {
+	g := NewWithT(t)
+
+	oldT := podTemplate(corev1.Container{Name: "app", Image: "img:v1"}, corev1.Container{Name: "old", Image: "old:v1"})
+	newT := podTemplate(corev1.Container{Name: "app", Image: "img:v1"})
+	newT.Spec.InitContainers = []corev1.Container{{Name: "migrate", Image: "migrate:v1"}}
+
+	change := ComparePodTemplates(oldT, newT)
+	g.Expect(change.Containers).To(HaveLen(2))
+
+	added := change.Containers[0]
+	g.Expect(added.Name).To(Equal("migrate"))
+	g.Expect(added.Init).To(BeTrue())
+	g.Expect(added.ImageChanged()).To(BeTrue())
+
+	removed := change.Containers[1]
+	g.Expect(removed.Name).To(Equal("old"))
+	g.Expect(removed.ImageChanged()).To(BeFalse())
+	g.Expect(removed.Changes).To(Equal([]freezev1alpha1.ContainerChange{freezev1alpha1.ContainerChangeOther}))
+}
+
+func TestComparePodTemplates_PodSpecChange(t *testing.T) {
+	g := NewWithT(t)
+
+	oldT := podTemplate(corev1.Container{Name: "app", Image: "img:v1"})
+	newT := oldT.DeepCopy()
+	newT.Spec.ServiceAccountName = "privileged"
+
+	change := ComparePodTemplates(oldT, newT)
+	g.Expect(change.PodSpecChanged).To(BeTrue())
+	g.Expect(change.Containers).To(BeEmpty())
+}
+
+func TestComparePodTemplates_MetadataChange(t *testing.T) {
+	g := NewWithT(t)
+
+	oldT := podTemplate(corev1.Container{Name: "app", Image: "img:v1"})
+	newT := oldT.DeepCopy()
+	newT.Labels = map[string]string{"tier": "privileged"}
+	g.Expect(ComparePodTemplates(oldT, newT).PodSpecChanged).To(BeTrue())
+
+	newT = oldT.DeepCopy()
+	newT.Annotations = map[string]string{"kubectl.kubernetes.io/restartedAt": "2026-10-18T00:00:00Z"}
+	g.Expect(ComparePodTemplates(oldT, newT).PodSpecChanged).To(BeTrue())
+
+	// Empty and nil metadata are the same.
+	newT = oldT.DeepCopy()
+	newT.Labels = map[string]string{}
+	g.Expect(ComparePodTemplates(oldT, newT).PodSpecChanged).To(BeFalse())
+}
+
+func TestComparePodTemplates_Create_AllContainersAdded(t *testing.T) {
+	g := NewWithT(t)
+
+	change := ComparePodTemplates(nil, podTemplate(corev1.Container{Name: "app", Image: "img:v1"}))
+	g.Expect(change.PodSpecChanged).To(BeFalse())
+	g.Expect(change.Containers).To(HaveLen(1))
+	g.Expect(change.Containers[0].ImageChanged()).To(BeTrue())
+}
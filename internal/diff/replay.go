@@ -0,0 +1,93 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+// serverMetadata lists the metadata fields the API server populates.
+var serverMetadata = []string{"uid", "resourceVersion", "generation", "creationTimestamp", "managedFields", "selfLink"}
+
+// StripServerFields removes status and the metadata the API server populates from
+// a serialized object, leaving the fields a client submits.
+func StripServerFields(raw []byte) ([]byte, error) {
+	var obj map[string]any
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, fmt.Errorf("decode object: %w", err)
+	}
+	delete(obj, "status")
+	if meta, ok := obj["metadata"].(map[string]any); ok {
+		for _, f := range serverMetadata {
+			delete(meta, f)
+		}
+	}
+	return json.Marshal(obj)
+}
+
+// ReplayPatch returns the JSON merge patch that turns base into desired, to be
+// applied to current, a later version of the same object. Only the fields the
+// change touches are patched, so fields changed since base by others, such as the
+// replicas an autoscaler sets, are kept. If a field the change touches was
+// changed differently since base, ReplayPatch returns its path as the conflict
+// instead. All three objects are compared without server-populated fields.
+func ReplayPatch(base, desired, current []byte) (patch []byte, conflict string, err error) {
+	var objs [3][]byte
+	for i, raw := range [][]byte{base, desired, current} {
+		if objs[i], err = StripServerFields(raw); err != nil {
+			return nil, "", err
+		}
+	}
+	change, err := mergePatch(objs[0], objs[1])
+	if err != nil {
+		return nil, "", err
+	}
+	drift, err := mergePatch(objs[0], objs[2])
+	if err != nil {
+		return nil, "", err
+	}
+	if path := overlap(change, drift, ""); path != "" {
+		return nil, path, nil
+	}
+	patch, err = json.Marshal(change)
+	return patch, "", err
+}
+
+func mergePatch(from, to []byte) (map[string]any, error) {
+	raw, err := jsonpatch.CreateMergePatch(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("compute merge patch: %w", err)
+	}
+	var patch map[string]any
+	if err := json.Unmarshal(raw, &patch); err != nil {
+		return nil, fmt.Errorf("decode merge patch: %w", err)
+	}
+	return patch, nil
+}
+
+// overlap returns the first path both merge patches set to different values.
+func overlap(a, b map[string]any, prefix string) string {
+	for _, k := range slices.Sorted(maps.Keys(a)) {
+		bv, ok := b[k]
+		if !ok {
+			continue
+		}
+		path := prefix + "." + k
+		am, aok := a[k].(map[string]any)
+		bm, bok := bv.(map[string]any)
+		if aok && bok {
+			if p := overlap(am, bm, path); p != "" {
+				return p
+			}
+			continue
+		}
+		if !reflect.DeepEqual(a[k], bv) {
+			return path
+		}
+	}
+	return ""
+}
@@ -0,0 +1,99 @@
+package diff
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func replayDeployment(image string, replicas int32, env ...corev1.EnvVar) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "d", Namespace: "ns", Generation: 3, ResourceVersion: "10"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr(replicas),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "c", Image: image, Env: env}}},
+			},
+		},
+	}
+}
+
+func marshal(g *WithT, obj any) []byte {
+	raw, err := json.Marshal(obj)
+	g.Expect(err).NotTo(HaveOccurred())
+	return raw
+}
+
+func TestStripServerFields(t *testing.T) {
+	g := NewWithT(t)
+
+	d := replayDeployment("img:v1", 1)
+	d.UID = "abc"
+	d.Status.Replicas = 1
+	raw, err := StripServerFields(marshal(g, d))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	var stripped map[string]any
+	g.Expect(json.Unmarshal(raw, &stripped)).To(Succeed())
+	g.Expect(stripped).NotTo(HaveKey("status"))
+	g.Expect(stripped["metadata"]).To(Equal(map[string]any{"name": "d", "namespace": "ns"}))
+}
+
+func TestReplayPatch_KeepsFieldsChangedByOthers(t *testing.T) {
+	g := NewWithT(t)
+
+	base := replayDeployment("img:v1", 2)
+	desired := replayDeployment("img:v2", 2)
+	// An autoscaler scaled the Deployment after the change was deferred.
+	current := replayDeployment("img:v1", 5)
+	current.Generation = 4
+
+	patch, conflict, err := ReplayPatch(marshal(g, base), marshal(g, desired), marshal(g, current))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(conflict).To(BeEmpty())
+	g.Expect(string(patch)).To(ContainSubstring("img:v2"))
+	g.Expect(string(patch)).NotTo(ContainSubstring("replicas"))
+}
+
+func TestReplayPatch_RemovesDeletedFields(t *testing.T) {
+	g := NewWithT(t)
+
+	base := replayDeployment("img:v1", 2)
+	base.Labels = map[string]string{"team": "a", "tier": "web"}
+	desired := replayDeployment("img:v1", 2)
+	desired.Labels = map[string]string{"team": "a"}
+
+	patch, conflict, err := ReplayPatch(marshal(g, base), marshal(g, desired), marshal(g, base))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(conflict).To(BeEmpty())
+	g.Expect(string(patch)).To(Equal(`{"metadata":{"labels":{"tier":null}}}`))
+}
+
+func TestReplayPatch_ReportsConflictingField(t *testing.T) {
+	g := NewWithT(t)
+
+	base := replayDeployment("img:v1", 2)
+	desired := replayDeployment("img:v2", 2)
+	current := replayDeployment("img:v3", 2)
+
+	patch, conflict, err := ReplayPatch(marshal(g, base), marshal(g, desired), marshal(g, current))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(patch).To(BeNil())
+	g.Expect(conflict).To(Equal(".spec.template.spec.containers"))
+}
+
+func TestReplayPatch_SameChangeIsNoConflict(t *testing.T) {
+	g := NewWithT(t)
+
+	base := replayDeployment("img:v1", 2)
+	desired := replayDeployment("img:v2", 2)
+
+	_, conflict, err := ReplayPatch(marshal(g, base), marshal(g, desired), marshal(g, desired))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(conflict).To(BeEmpty())
+}
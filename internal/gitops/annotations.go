@@ -21,7 +21,8 @@ const (
 	// AnnotationManaged marks an object as currently managed by the freeze-operator.
 	AnnotationManaged = "freeze-operator.io/managed"
 
-	// AnnotationManagedByPolicy records which policy (name) put the object in pause.
+	// AnnotationManagedByPolicy records which policy (Kind/name, see hold.Policy) put
+	// the object in pause. Objects paused by an older operator record a bare name.
 	AnnotationManagedByPolicy = "freeze-operator.io/managed-by-policy"
 
 	// AnnotationOriginalAutoSync stores the original ArgoCD spec.syncPolicy.automated JSON
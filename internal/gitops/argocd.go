@@ -28,6 +28,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/hold"
 )
 
 // argoCDApplicationGVK is the GroupVersionKind for ArgoCD Application resources.
@@ -127,7 +128,7 @@ func reconcileArgoCDApp(
 	}
 
 	managedByUs := annotations[AnnotationManaged] == annotationManagedValue &&
-		annotations[AnnotationManagedByPolicy] == policyRef
+		hold.Same(annotations[AnnotationManagedByPolicy], policyRef)
 
 	switch {
 	case active && !managedByUs:
@@ -28,6 +28,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/hold"
 )
 
 var (
@@ -159,7 +160,7 @@ func reconcileFluxObject(
 	}
 
 	managedByUs := annotations[AnnotationManaged] == annotationManagedValue &&
-		annotations[AnnotationManagedByPolicy] == policyRef
+		hold.Same(annotations[AnnotationManagedByPolicy], policyRef)
 
 	switch {
 	case active && !managedByUs:
@@ -891,3 +891,55 @@ func TestReconciler_FullCycle_PauseAndRestore(t *testing.T) {
 		}
 	}
 }
+
+// ---------------------------------------------------------------------------
+// orphan helpers
+// ---------------------------------------------------------------------------
+
+func TestListManagedAndRestore(t *testing.T) {
+	ctx := context.Background()
+	app := newArgoCDApp("myapp", nil, nil)
+	app.SetAnnotations(map[string]string{
+		AnnotationManaged:          annotationManagedValue,
+		AnnotationManagedByPolicy:  "deleted-freeze",
+		AnnotationOriginalAutoSync: `{"prune":true}`,
+	})
+	ks := newFluxResource(fluxKustomizationGVK, "infra", nil, true)
+	ks.SetAnnotations(map[string]string{
+		AnnotationManaged:         annotationManagedValue,
+		AnnotationManagedByPolicy: "deleted-freeze",
+		AnnotationOriginalSuspend: "false",
+	})
+	unmanaged := newFluxResource(fluxHelmReleaseGVK, "podinfo", nil, true)
+	c := buildFakeClient(app, ks, unmanaged)
+
+	managed, err := ListManaged(ctx, c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(managed) != 2 {
+		t.Fatalf("expected 2 managed objects, got %d", len(managed))
+	}
+
+	for _, obj := range managed {
+		if err := Restore(ctx, c, obj); err != nil {
+			t.Fatalf("restore %s: %v", obj.GetName(), err)
+		}
+	}
+
+	gotApp := getApp(ctx, c, "myapp")
+	if _, found, _ := unstructured.NestedMap(gotApp.Object, "spec", "syncPolicy", "automated"); !found {
+		t.Error("expected autosync to be restored")
+	}
+	if gotApp.GetAnnotations()[AnnotationManaged] != "" {
+		t.Error("expected managed annotation removed from Application")
+	}
+	gotKs := getFlux(ctx, c, fluxKustomizationGVK, "infra")
+	if suspended, _, _ := unstructured.NestedBool(gotKs.Object, "spec", "suspend"); suspended {
+		t.Error("expected Kustomization to be resumed")
+	}
+	gotHR := getFlux(ctx, c, fluxHelmReleaseGVK, "podinfo")
+	if suspended, _, _ := unstructured.NestedBool(gotHR.Object, "spec", "suspend"); !suspended {
+		t.Error("unmanaged HelmRelease must not be touched")
+	}
+}
@@ -40,6 +40,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
@@ -59,11 +61,19 @@ type Result struct {
 	ReconcileTime metav1.Time
 }
 
+// Kinds lists the kinds managed for each GitOps provider. Controllers watch them so
+// that objects created or relabelled during a freeze are paused without waiting
+// for the next periodic reconcile.
+var Kinds = map[freezev1alpha1.GitOpsProvider][]schema.GroupVersionKind{
+	freezev1alpha1.GitOpsProviderArgoCD: {argoCDApplicationGVK},
+	freezev1alpha1.GitOpsProviderFlux:   {fluxKustomizationGVK, fluxHelmReleaseGVK},
+}
+
 // Reconcile pauses (active=true) or resumes (active=false) all configured GitOps resources.
 //
 // Parameters:
 //   - gitops    the GitOpsSpec from the policy's behavior block
-//   - policyRef the name of the owning policy (used as a managed-by annotation value)
+//   - policyRef the held-by reference of the owning policy, see hold.Policy
 //   - active    whether the freeze is currently active
 func (r *Reconciler) Reconcile(
 	ctx context.Context,
@@ -100,6 +110,43 @@ func (r *Reconciler) Reconcile(
 	return result, errors.Join(errs...)
 }
 
+// ListManaged returns every GitOps object the operator currently manages,
+// whichever policy paused it. Kinds whose CRDs are not installed are skipped.
+func ListManaged(ctx context.Context, c client.Client) ([]*unstructured.Unstructured, error) {
+	var out []*unstructured.Unstructured
+	for _, gvks := range Kinds {
+		for _, gvk := range gvks {
+			list := &unstructured.UnstructuredList{}
+			list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+			if err := c.List(ctx, list); err != nil {
+				if isNoMatchError(err) {
+					continue
+				}
+				return out, fmt.Errorf("list %s: %w", gvk.Kind, err)
+			}
+			for i := range list.Items {
+				if list.Items[i].GetAnnotations()[AnnotationManaged] == annotationManagedValue {
+					out = append(out, &list.Items[i])
+				}
+			}
+		}
+	}
+	return out, nil
+}
+
+// Restore resumes a managed GitOps object on behalf of the policy recorded in
+// its managed-by-policy annotation, restoring its original state.
+func Restore(ctx context.Context, c client.Client, obj *unstructured.Unstructured) error {
+	policyRef := obj.GetAnnotations()[AnnotationManagedByPolicy]
+	var err error
+	if obj.GroupVersionKind() == argoCDApplicationGVK {
+		_, err = reconcileArgoCDApp(ctx, c, obj, policyRef, false)
+	} else {
+		_, err = reconcileFluxObject(ctx, c, obj, obj.GetKind(), policyRef, false)
+	}
+	return err
+}
+
 // ---------------------------------------------------------------------------
 // helpers shared between argocd.go and flux.go
 // ---------------------------------------------------------------------------
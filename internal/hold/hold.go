@@ -0,0 +1,126 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hold reference-counts the policies holding an object suspended, paused
+// or pinned. The holders are recorded in the freeze-operator.io/held-by annotation
+// as comma-separated Kind/name references; the object is restored when the last
+// policy releases it.
+package hold
+
+import (
+	"slices"
+	"strings"
+)
+
+const (
+	// AnnotationHeldBy lists the policies holding an object.
+	AnnotationHeldBy = "freeze-operator.io/held-by"
+
+	// AnnotationManagedBy held the single policy name of a suspended CronJob before
+	// held-by was introduced.
+	AnnotationManagedBy = "freeze-operator.io/managed-by"
+
+	// annotationManagedByPolicy held the single policy name of a pinned autoscaler.
+	annotationManagedByPolicy = "freeze-operator.io/managed-by-policy"
+)
+
+// legacyAnnotations are still read, so objects held by an older operator are released.
+var legacyAnnotations = []string{AnnotationManagedBy, annotationManagedByPolicy}
+
+// Policy returns the held-by reference of a policy. Both the kind and the name
+// are needed, since a ChangeFreeze and a MaintenanceWindow may share a name.
+func Policy(kind, name string) string {
+	return kind + "/" + name
+}
+
+// Holders returns the policies holding an object. A legacy owner is returned as a
+// bare policy name.
+func Holders(annotations map[string]string) []string {
+	var out []string
+	if v := annotations[AnnotationHeldBy]; v != "" {
+		out = strings.Split(v, ",")
+	}
+	for _, key := range legacyAnnotations {
+		if legacy := annotations[key]; legacy != "" && !slices.Contains(out, legacy) {
+			out = append(out, legacy)
+		}
+	}
+	return out
+}
+
+// Same reports whether h refers to holder. A bare legacy name matches a policy of
+// any kind with that name.
+func Same(h, holder string) bool {
+	if h == holder {
+		return true
+	}
+	_, name, _ := strings.Cut(holder, "/")
+	return !strings.Contains(h, "/") && h == name
+}
+
+// HeldBy reports whether holder holds the object.
+func HeldBy(annotations map[string]string, holder string) bool {
+	return slices.ContainsFunc(Holders(annotations), func(h string) bool { return Same(h, holder) })
+}
+
+// Add adds holder to the holders in annotations. It reports whether holder is the
+// first holder, in which case the caller must save the original state, and
+// whether the annotations changed.
+func Add(annotations map[string]string, holder string) (first, changed bool) {
+	hs := Holders(annotations)
+	if slices.ContainsFunc(hs, func(h string) bool { return Same(h, holder) }) {
+		if !hasLegacy(annotations) {
+			return false, false
+		}
+		// Rewrite a legacy owner as a qualified holder.
+		hs = slices.DeleteFunc(hs, func(h string) bool { return Same(h, holder) })
+		set(annotations, append(hs, holder))
+		return false, true
+	}
+	set(annotations, append(hs, holder))
+	return len(hs) == 0, true
+}
+
+// Remove removes holder from the holders in annotations. It reports whether holder
+// was holding the object and whether it was the last holder, in which case the
+// caller must restore the original state.
+func Remove(annotations map[string]string, holder string) (held, last bool) {
+	hs := Holders(annotations)
+	rest := slices.DeleteFunc(slices.Clone(hs), func(h string) bool { return Same(h, holder) })
+	if len(rest) == len(hs) {
+		return false, false
+	}
+	set(annotations, rest)
+	return true, len(rest) == 0
+}
+
+func hasLegacy(annotations map[string]string) bool {
+	return slices.ContainsFunc(legacyAnnotations, func(key string) bool { return annotations[key] != "" })
+}
+
+// set writes the holders to annotations, removing the annotations when there are
+// none left.
+func set(annotations map[string]string, hs []string) {
+	for _, key := range legacyAnnotations {
+		delete(annotations, key)
+	}
+	if len(hs) == 0 {
+		delete(annotations, AnnotationHeldBy)
+		return
+	}
+	slices.Sort(hs)
+	annotations[AnnotationHeldBy] = strings.Join(hs, ",")
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hold
+
+import "testing"
+
+func TestAddRemove(t *testing.T) {
+	cf, mw := Policy("ChangeFreeze", "holiday"), Policy("MaintenanceWindow", "holiday")
+	ann := map[string]string{}
+
+	if first, changed := Add(ann, cf); !first || !changed {
+		t.Errorf("first Add: got first=%v changed=%v", first, changed)
+	}
+	if first, changed := Add(ann, cf); first || changed {
+		t.Errorf("repeated Add: got first=%v changed=%v", first, changed)
+	}
+	if first, changed := Add(ann, mw); first || !changed {
+		t.Errorf("second holder: got first=%v changed=%v", first, changed)
+	}
+	if got := ann[AnnotationHeldBy]; got != cf+","+mw {
+		t.Errorf("held-by = %q", got)
+	}
+
+	if held, last := Remove(ann, cf); !held || last {
+		t.Errorf("Remove %s: got held=%v last=%v", cf, held, last)
+	}
+	if HeldBy(ann, cf) || !HeldBy(ann, mw) {
+		t.Errorf("expected only %s to hold, got %v", mw, ann)
+	}
+	if held, last := Remove(ann, mw); !held || !last {
+		t.Errorf("Remove %s: got held=%v last=%v", mw, held, last)
+	}
+	if len(ann) != 0 {
+		t.Errorf("expected annotations removed, got %v", ann)
+	}
+}
+
+func TestLegacyOwner(t *testing.T) {
+	holder := Policy("ChangeFreeze", "holiday")
+	for _, key := range legacyAnnotations {
+		ann := map[string]string{key: "holiday"}
+		if !HeldBy(ann, holder) {
+			t.Errorf("%s: bare legacy name should match %s", key, holder)
+		}
+		if HeldBy(ann, Policy("ChangeFreeze", "other")) {
+			t.Errorf("%s: legacy name should not match another policy", key)
+		}
+
+		// Adding rewrites the legacy owner as a qualified holder.
+		if first, changed := Add(ann, holder); first || !changed {
+			t.Errorf("%s: Add got first=%v changed=%v", key, first, changed)
+		}
+		if ann[AnnotationHeldBy] != holder || ann[key] != "" {
+			t.Errorf("%s: expected legacy owner rewritten, got %v", key, ann)
+		}
+	}
+
+	ann := map[string]string{AnnotationManagedBy: "holiday"}
+	if held, last := Remove(ann, holder); !held || !last || len(ann) != 0 {
+		t.Errorf("Remove legacy: got held=%v last=%v annotations=%v", held, last, ann)
+	}
+}
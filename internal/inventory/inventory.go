@@ -0,0 +1,128 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package inventory computes the namespaces and workloads a freeze policy
+// covers, using the same target matching as the admission webhook.
+//
+// Workloads are listed as metadata only. When the reader is the manager's
+// cache, the lists are served from watch-backed informers instead of being
+// fetched from the API server on every call.
+package inventory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/policy"
+)
+
+// SampleSize is the number of workloads listed in a policy's status.
+const SampleSize = 20
+
+// Kinds maps each target kind to the GroupVersionKind listed for it.
+var Kinds = map[freezev1alpha1.TargetKind]schema.GroupVersionKind{
+	freezev1alpha1.TargetKindDeployment:  {Group: "apps", Version: "v1", Kind: "Deployment"},
+	freezev1alpha1.TargetKindStatefulSet: {Group: "apps", Version: "v1", Kind: "StatefulSet"},
+	freezev1alpha1.TargetKindDaemonSet:   {Group: "apps", Version: "v1", Kind: "DaemonSet"},
+	freezev1alpha1.TargetKindCronJob:     {Group: "batch", Version: "v1", Kind: "CronJob"},
+}
+
+// Inventory is the set of namespaces and workloads a target selects.
+type Inventory struct {
+	// Namespaces is the number of namespaces matched by the namespaceSelector.
+	Namespaces int
+	// Workloads lists the matching workloads, sorted by namespace, kind and name.
+	Workloads []freezev1alpha1.AffectedWorkload
+}
+
+// Compute lists the namespaces and workloads selected by target.
+func Compute(ctx context.Context, c client.Reader, target *freezev1alpha1.TargetSpec) (Inventory, error) {
+	var inv Inventory
+	if target == nil {
+		return inv, nil
+	}
+
+	var nsList corev1.NamespaceList
+	if err := c.List(ctx, &nsList); err != nil {
+		return inv, fmt.Errorf("list namespaces: %w", err)
+	}
+	nsLabels := make(map[string]map[string]string, len(nsList.Items))
+	for _, ns := range nsList.Items {
+		if policy.NamespaceMatches(target, ns.Labels) {
+			nsLabels[ns.Name] = ns.Labels
+			inv.Namespaces++
+		}
+	}
+
+	for _, kind := range target.Kinds {
+		gvk, ok := Kinds[kind]
+		if !ok {
+			continue
+		}
+		list := &metav1.PartialObjectMetadataList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := c.List(ctx, list); err != nil {
+			return inv, fmt.Errorf("list %s: %w", gvk.Kind, err)
+		}
+		for _, obj := range list.Items {
+			labels, ok := nsLabels[obj.Namespace]
+			if !ok || !policy.TargetMatches(target, labels, obj.Labels, kind) {
+				continue
+			}
+			inv.Workloads = append(inv.Workloads, freezev1alpha1.AffectedWorkload{
+				Kind:      kind,
+				Namespace: obj.Namespace,
+				Name:      obj.Name,
+			})
+		}
+	}
+
+	slices.SortFunc(inv.Workloads, func(a, b freezev1alpha1.AffectedWorkload) int {
+		return cmp.Or(
+			cmp.Compare(a.Namespace, b.Namespace),
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+	return inv, nil
+}
+
+// Summary returns the status summary of inv: counts per kind and the first
+// SampleSize workloads.
+func (inv Inventory) Summary() *freezev1alpha1.AffectedWorkloadsStatus {
+	out := &freezev1alpha1.AffectedWorkloadsStatus{
+		Namespaces: inv.Namespaces,
+		Total:      len(inv.Workloads),
+		Sample:     slices.Clone(inv.Workloads[:min(len(inv.Workloads), SampleSize)]),
+	}
+	counts := make(map[freezev1alpha1.TargetKind]int)
+	for _, w := range inv.Workloads {
+		counts[w.Kind]++
+	}
+	for kind, n := range counts {
+		out.Counts = append(out.Counts, freezev1alpha1.WorkloadCount{Kind: kind, Count: n})
+	}
+	slices.SortFunc(out.Counts, func(a, b freezev1alpha1.WorkloadCount) int { return cmp.Compare(a.Kind, b.Kind) })
+	return out
+}
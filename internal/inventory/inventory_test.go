@@ -0,0 +1,146 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+)
+
+func buildFakeClient(objs ...client.Object) client.Client {
+	s := runtime.NewScheme()
+	_ = corev1.AddToScheme(s)
+	_ = appsv1.AddToScheme(s)
+	_ = batchv1.AddToScheme(s)
+	return fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build()
+}
+
+func newNamespace(name, env string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"env": env}}}
+}
+
+func newDeployment(namespace, name string, labels map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels}}
+}
+
+func newCronJob(namespace, name string) *batchv1.CronJob {
+	return &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+}
+
+func prodTarget(kinds ...freezev1alpha1.TargetKind) *freezev1alpha1.TargetSpec {
+	return &freezev1alpha1.TargetSpec{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+		Kinds:             kinds,
+	}
+}
+
+func TestCompute_MatchesTarget(t *testing.T) {
+	c := buildFakeClient(
+		newNamespace("prod-b", "prod"),
+		newNamespace("prod-a", "prod"),
+		newNamespace("dev", "dev"),
+		newDeployment("prod-b", "api", nil),
+		newDeployment("prod-a", "web", nil),
+		newDeployment("dev", "api", nil),
+		newCronJob("prod-a", "report"),
+	)
+
+	inv, err := Compute(context.Background(), c, prodTarget(freezev1alpha1.TargetKindDeployment, freezev1alpha1.TargetKindCronJob))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inv.Namespaces != 2 {
+		t.Errorf("expected 2 namespaces, got %d", inv.Namespaces)
+	}
+	want := []freezev1alpha1.AffectedWorkload{
+		{Kind: freezev1alpha1.TargetKindCronJob, Namespace: "prod-a", Name: "report"},
+		{Kind: freezev1alpha1.TargetKindDeployment, Namespace: "prod-a", Name: "web"},
+		{Kind: freezev1alpha1.TargetKindDeployment, Namespace: "prod-b", Name: "api"},
+	}
+	if fmt.Sprint(inv.Workloads) != fmt.Sprint(want) {
+		t.Errorf("expected %v, got %v", want, inv.Workloads)
+	}
+}
+
+func TestCompute_ObjectSelectorAndKinds(t *testing.T) {
+	c := buildFakeClient(
+		newNamespace("prod", "prod"),
+		newDeployment("prod", "api", map[string]string{"tier": "backend"}),
+		newDeployment("prod", "web", map[string]string{"tier": "frontend"}),
+		newCronJob("prod", "report"),
+	)
+	target := prodTarget(freezev1alpha1.TargetKindDeployment)
+	target.ObjectSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "backend"}}
+
+	inv, err := Compute(context.Background(), c, target)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(inv.Workloads) != 1 || inv.Workloads[0].Name != "api" {
+		t.Errorf("expected only Deployment api, got %v", inv.Workloads)
+	}
+}
+
+func TestCompute_NilTarget(t *testing.T) {
+	inv, err := Compute(context.Background(), buildFakeClient(), nil)
+	if err != nil || inv.Namespaces != 0 || len(inv.Workloads) != 0 {
+		t.Errorf("expected an empty inventory, got %+v, %v", inv, err)
+	}
+}
+
+func TestSummary(t *testing.T) {
+	var inv Inventory
+	inv.Namespaces = 1
+	for i := range SampleSize + 5 {
+		inv.Workloads = append(inv.Workloads, freezev1alpha1.AffectedWorkload{
+			Kind: freezev1alpha1.TargetKindDeployment, Namespace: "prod", Name: fmt.Sprintf("app-%02d", i),
+		})
+	}
+	inv.Workloads = append(inv.Workloads, freezev1alpha1.AffectedWorkload{
+		Kind: freezev1alpha1.TargetKindCronJob, Namespace: "prod", Name: "report",
+	})
+
+	sum := inv.Summary()
+	if sum.Total != SampleSize+6 || sum.Namespaces != 1 {
+		t.Errorf("unexpected totals: %+v", sum)
+	}
+	if len(sum.Sample) != SampleSize {
+		t.Errorf("expected %d sampled workloads, got %d", SampleSize, len(sum.Sample))
+	}
+	want := []freezev1alpha1.WorkloadCount{
+		{Kind: freezev1alpha1.TargetKindCronJob, Count: 1},
+		{Kind: freezev1alpha1.TargetKindDeployment, Count: SampleSize + 5},
+	}
+	if fmt.Sprint(sum.Counts) != fmt.Sprint(want) {
+		t.Errorf("expected counts %v, got %v", want, sum.Counts)
+	}
+
+	if empty := (Inventory{}).Summary(); empty.Total != 0 || empty.Sample != nil || empty.Counts != nil {
+		t.Errorf("expected an empty summary, got %+v", empty)
+	}
+}
@@ -42,6 +42,24 @@ var (
 		[]string{"exception_name", "policy_type", "policy_name"},
 	)
 
+	// BreakGlassOverrides tracks the number of times the break-glass annotation overrode a deny policy
+	BreakGlassOverrides = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "freeze_operator_break_glass_overrides_total",
+			Help: "Total number of times the break-glass annotation overrode a deny policy",
+		},
+		[]string{"policy_type", "policy_name", "namespace", "kind", "action"},
+	)
+
+	// DeferredChanges tracks DeferredChanges reaching a final phase
+	DeferredChanges = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "freeze_operator_deferred_changes_total",
+			Help: "Total number of DeferredChanges by final phase (Applied, Conflict, Superseded, Failed)",
+		},
+		[]string{"phase", "namespace", "kind"},
+	)
+
 	// ReconciliationDuration tracks controller reconciliation duration
 	ReconciliationDuration = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -61,6 +79,24 @@ var (
 		[]string{"policy_type", "policy_name", "namespace"},
 	)
 
+	// OrphanedObjects tracks objects held by a missing or inactive policy, as found by the last sweep
+	OrphanedObjects = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "freeze_operator_orphaned_objects",
+			Help: "Number of objects held by a policy that no longer exists or is not active, as of the last sweep",
+		},
+		[]string{"kind"},
+	)
+
+	// OrphansRestored tracks orphaned objects restored by the sweeper
+	OrphansRestored = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "freeze_operator_orphans_restored_total",
+			Help: "Total number of orphaned objects restored by the sweeper",
+		},
+		[]string{"kind"},
+	)
+
 	// APIRequests tracks CI helper API evaluate requests by decision
 	APIRequests = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -96,8 +132,12 @@ func init() {
 		DeniedRequests,
 		AllowedRequests,
 		ExceptionOverrides,
+		BreakGlassOverrides,
+		DeferredChanges,
 		ReconciliationDuration,
 		CronJobSuspensions,
+		OrphanedObjects,
+		OrphansRestored,
 		APIRequests,
 		APILatency,
 		APIErrors,
@@ -17,6 +17,19 @@ import (
 
 type Evaluator struct {
 	Client client.Reader
+
+	// RequireApprovedExceptions ignores FreezeExceptions without spec.approvedBy.
+	// The FreezeException webhook guarantees that approvedBy was set by a separate
+	// approver when this mode is enabled.
+	RequireApprovedExceptions bool
+
+	// BreakGlassGroups lists the groups whose members may override any policy with the
+	// break-glass annotation. Break-glass is disabled when empty.
+	BreakGlassGroups []string
+
+	// MinApprovals is the operator-wide minimum number of approvals every
+	// FreezeException needs, regardless of a lower spec.requiredApprovals.
+	MinApprovals int32
 }
 
 type denyCandidate struct {
@@ -50,16 +63,24 @@ func (e *Evaluator) Evaluate(ctx context.Context, in Input) (Decision, error) {
 		EvaluationTime:  in.Now,
 	}
 
-	matchedDenies, err := e.collectDenyCandidates(ctx, in, nsLabels)
+	m, err := e.collectMatches(ctx, in, nsLabels)
 	if err != nil {
 		return Decision{}, err
 	}
+	dec.Upcoming = m.upcoming
 
-	if len(matchedDenies) == 0 {
+	if len(m.denies) == 0 && len(m.budgets) == 0 {
 		return dec, nil
 	}
 
-	chosen := selectDenyCandidate(matchedDenies)
+	// Overrides lift rate limits as well as denies; the matched policy is the chosen
+	// deny, or the first rate limit if nothing denies.
+	var chosen denyCandidate
+	if len(m.denies) > 0 {
+		chosen = selectDenyCandidate(m.denies)
+	} else {
+		chosen = denyCandidate{ref: m.budgets[0].Policy}
+	}
 
 	if override := e.checkExceptionOverride(ctx, in, nsLabels); override != nil {
 		dec.Allowed = true
@@ -71,11 +92,30 @@ func (e *Evaluator) Evaluate(ctx context.Context, in Input) (Decision, error) {
 		return dec, nil
 	}
 
+	if e.breakGlassAllowed(in) {
+		dec.Allowed = true
+		dec.MatchedPolicy = &chosen.ref
+		dec.MatchedOverride = &PolicyRef{Kind: PolicyKindBreakGlass, Name: in.Username}
+		dec.Reason = "Break-glass: " + in.BreakGlassReason
+		dec.NextAllowedTime = chosen.nextAllowed
+		dec.FreezeEndTime = chosen.freezeEnd
+		return dec, nil
+	}
+
+	if len(m.denies) == 0 {
+		dec.Budgets = m.budgets
+		return dec, nil
+	}
+
 	dec.Allowed = false
 	dec.MatchedPolicy = &chosen.ref
 	dec.Reason = chosen.reason
 	dec.NextAllowedTime = chosen.nextAllowed
 	dec.FreezeEndTime = chosen.freezeEnd
+	dec.DeferChanges = chosen.behavior != nil && chosen.behavior.DeferChanges
+	dec.PausesDeployments = !slices.ContainsFunc(m.denies, func(d denyCandidate) bool {
+		return d.behavior == nil || !d.behavior.PauseDeployments
+	})
 	return dec, nil
 }
 
@@ -93,102 +133,172 @@ func (e *Evaluator) getNamespaceLabels(ctx context.Context, in Input) (map[strin
 	return ns.Labels, nil
 }
 
-func (e *Evaluator) collectDenyCandidates(ctx context.Context, in Input, nsLabels map[string]string) ([]denyCandidate, error) {
-	matchedDenies := make([]denyCandidate, 0, 4)
+// matches collects what the active and upcoming policies say about a request.
+type matches struct {
+	denies   []denyCandidate
+	upcoming []Upcoming
+	budgets  []Budget
+}
 
-	cfDenies, err := e.collectChangeFreezes(ctx, in, nsLabels)
-	if err != nil {
-		return nil, err
+func (e *Evaluator) collectMatches(ctx context.Context, in Input, nsLabels map[string]string) (matches, error) {
+	var m matches
+	if err := e.collectChangeFreezes(ctx, in, nsLabels, &m); err != nil {
+		return matches{}, err
 	}
-	matchedDenies = append(matchedDenies, cfDenies...)
-
-	mwDenies, err := e.collectMaintenanceWindows(ctx, in, nsLabels)
-	if err != nil {
-		return nil, err
+	if err := e.collectMaintenanceWindows(ctx, in, nsLabels, &m); err != nil {
+		return matches{}, err
 	}
-	matchedDenies = append(matchedDenies, mwDenies...)
-
-	return matchedDenies, nil
+	if err := e.collectRolloutLimits(ctx, in, nsLabels, &m); err != nil {
+		return matches{}, err
+	}
+	sort.SliceStable(m.upcoming, func(i, j int) bool {
+		return m.upcoming[i].StartTime.Before(m.upcoming[j].StartTime)
+	})
+	return m, nil
 }
 
-func (e *Evaluator) collectChangeFreezes(ctx context.Context, in Input, nsLabels map[string]string) ([]denyCandidate, error) {
+func (e *Evaluator) collectChangeFreezes(ctx context.Context, in Input, nsLabels map[string]string, m *matches) error {
 	var list freezev1alpha1.ChangeFreezeList
 	if err := e.Client.List(ctx, &list); err != nil {
-		return nil, fmt.Errorf("list ChangeFreeze: %w", err)
+		return fmt.Errorf("list ChangeFreeze: %w", err)
 	}
 
-	denies := make([]denyCandidate, 0, len(list.Items))
 	for i := range list.Items {
 		cf := &list.Items[i]
-		if !targetMatches(&cf.Spec.Target, nsLabels, in.ObjectLabels, in.Kind) {
+		ref := PolicyRef{Kind: PolicyKindChangeFreeze, Name: cf.Name}
+		inScope, escaping := scopeMatches(&cf.Spec.Target, nsLabels, in)
+		if !inScope {
 			continue
 		}
-		if !actionIn(in.Action, cf.Spec.Rules.Deny) {
+		if !escaping && !actionIn(in.Action, cf.Spec.Rules.Deny) {
+			if ChangeFreezeActive(cf, in.Now) {
+				if b := budgetFor(ref, cf.UID, &cf.Spec.Rules, in); b != nil {
+					m.budgets = append(m.budgets, *b)
+				}
+			}
 			continue
 		}
-		if !in.Now.Before(cf.Spec.EndTime.Time) || in.Now.Before(cf.Spec.StartTime.Time) {
+		if !ChangeFreezeActive(cf, in.Now) {
+			if cf.Spec.NoticePeriod != nil && withinNotice(in.Now, cf.Spec.StartTime.Time, cf.Spec.NoticePeriod.Duration) {
+				m.upcoming = append(m.upcoming, Upcoming{Policy: ref, StartTime: cf.Spec.StartTime.Time})
+			}
 			continue
 		}
 		end := cf.Spec.EndTime.Time
-		denies = append(denies, denyCandidate{
-			ref:            PolicyRef{Kind: PolicyKindChangeFreeze, Name: cf.Name},
-			reason:         firstNonEmpty(cf.Spec.Message.Reason, "ChangeFreeze is active"),
+		reason := firstNonEmpty(cf.Spec.Message.Reason, "ChangeFreeze is active")
+		if escaping {
+			reason = escapeReason(reason)
+		}
+		m.denies = append(m.denies, denyCandidate{
+			ref:            ref,
+			reason:         reason,
 			nextAllowed:    &end,
 			freezeEnd:      &end,
 			behavior:       &cf.Spec.Behavior,
 			determinsticId: cf.Name,
 		})
 	}
-	return denies, nil
+	return nil
 }
 
-func (e *Evaluator) collectMaintenanceWindows(ctx context.Context, in Input, nsLabels map[string]string) ([]denyCandidate, error) {
+func (e *Evaluator) collectMaintenanceWindows(ctx context.Context, in Input, nsLabels map[string]string, m *matches) error {
 	var list freezev1alpha1.MaintenanceWindowList
 	if err := e.Client.List(ctx, &list); err != nil {
-		return nil, fmt.Errorf("list MaintenanceWindow: %w", err)
+		return fmt.Errorf("list MaintenanceWindow: %w", err)
 	}
 
-	denies := make([]denyCandidate, 0, len(list.Items))
 	for i := range list.Items {
 		mw := &list.Items[i]
-		if !targetMatches(&mw.Spec.Target, nsLabels, in.ObjectLabels, in.Kind) {
+		ref := PolicyRef{Kind: PolicyKindMaintenanceWindow, Name: mw.Name}
+		inScope, escaping := scopeMatches(&mw.Spec.Target, nsLabels, in)
+		if !inScope {
 			continue
 		}
-		if !actionIn(in.Action, mw.Spec.Rules.Deny) {
-			continue
-		}
-		if mw.Spec.Mode != freezev1alpha1.MaintenanceWindowModeDenyOutsideWindows {
+		denying, bestNext := MaintenanceWindowDenying(mw, in.Now)
+		if !escaping && !actionIn(in.Action, mw.Spec.Rules.Deny) {
+			if denying {
+				if b := budgetFor(ref, mw.UID, &mw.Spec.Rules, in); b != nil {
+					m.budgets = append(m.budgets, *b)
+				}
+			}
 			continue
 		}
-
-		inAny := false
-		var bestNext *time.Time
-		for _, w := range mw.Spec.Windows {
-			res, err := evalCronWindow(in.Now, mw.Spec.Timezone, w.Schedule, w.Duration)
-			if err != nil {
-				continue
-			}
-			if res.Active {
-				inAny = true
-				break
-			}
-			if res.NextStart != nil && (bestNext == nil || res.NextStart.Before(*bestNext)) {
-				t := *res.NextStart
-				bestNext = &t
+		if denying {
+			reason := firstNonEmpty(mw.Spec.Message.Reason, "Outside maintenance window")
+			if escaping {
+				reason = escapeReason(reason)
 			}
-		}
-
-		if !inAny {
-			denies = append(denies, denyCandidate{
-				ref:            PolicyRef{Kind: PolicyKindMaintenanceWindow, Name: mw.Name},
-				reason:         firstNonEmpty(mw.Spec.Message.Reason, "Outside maintenance window"),
+			m.denies = append(m.denies, denyCandidate{
+				ref:            ref,
+				reason:         reason,
 				nextAllowed:    bestNext,
 				behavior:       &mw.Spec.Behavior,
 				determinsticId: mw.Name,
 			})
+			continue
+		}
+		if mw.Spec.NoticePeriod == nil {
+			continue
+		}
+		if closes := MaintenanceWindowClosesAt(mw, in.Now); closes != nil && withinNotice(in.Now, *closes, mw.Spec.NoticePeriod.Duration) {
+			m.upcoming = append(m.upcoming, Upcoming{Policy: ref, StartTime: *closes})
+		}
+	}
+	return nil
+}
+
+// ChangeFreezeActive reports whether cf is enforcing at now.
+func ChangeFreezeActive(cf *freezev1alpha1.ChangeFreeze, now time.Time) bool {
+	return now.Before(cf.Spec.EndTime.Time) && !now.Before(cf.Spec.StartTime.Time)
+}
+
+// MaintenanceWindowDenying reports whether mw currently denies changes (now is outside
+// all of its windows) and, if so, when the next window opens.
+func MaintenanceWindowDenying(mw *freezev1alpha1.MaintenanceWindow, now time.Time) (bool, *time.Time) {
+	if mw.Spec.Mode != freezev1alpha1.MaintenanceWindowModeDenyOutsideWindows {
+		return false, nil
+	}
+	var bestNext *time.Time
+	for _, w := range mw.Spec.Windows {
+		res, err := evalCronWindow(now, mw.Spec.Timezone, w.Schedule, w.Duration)
+		if err != nil {
+			continue
+		}
+		if res.Active {
+			return false, nil
+		}
+		if res.NextStart != nil && (bestNext == nil || res.NextStart.Before(*bestNext)) {
+			t := *res.NextStart
+			bestNext = &t
 		}
 	}
-	return denies, nil
+	return true, bestNext
+}
+
+// MaintenanceWindowClosesAt returns when mw starts to deny changes again if now is
+// inside one of its windows, or nil when the window is followed directly by another.
+func MaintenanceWindowClosesAt(mw *freezev1alpha1.MaintenanceWindow, now time.Time) *time.Time {
+	if mw.Spec.Mode != freezev1alpha1.MaintenanceWindowModeDenyOutsideWindows {
+		return nil
+	}
+	var closes *time.Time
+	for _, w := range mw.Spec.Windows {
+		res, err := evalCronWindow(now, mw.Spec.Timezone, w.Schedule, w.Duration)
+		if err != nil || !res.Active {
+			continue
+		}
+		if closes == nil || res.ActiveEnd.After(*closes) {
+			t := *res.ActiveEnd
+			closes = &t
+		}
+	}
+	if closes == nil {
+		return nil
+	}
+	if denying, _ := MaintenanceWindowDenying(mw, *closes); !denying {
+		return nil
+	}
+	return closes
 }
 
 func selectDenyCandidate(matchedDenies []denyCandidate) denyCandidate {
@@ -218,23 +328,181 @@ func (e *Evaluator) checkExceptionOverride(ctx context.Context, in Input, nsLabe
 	}
 	for i := range list.Items {
 		ex := &list.Items[i]
-		if !targetMatches(&ex.Spec.Target, nsLabels, in.ObjectLabels, in.Kind) {
+		if slices.Contains(in.ExcludeExceptions, ex.Name) {
+			continue
+		}
+		if !exceptionCovers(ex, in, nsLabels) {
 			continue
 		}
 		if !actionIn(in.Action, ex.Spec.Allow) {
 			continue
 		}
-		if !in.Now.Before(ex.Spec.ActiveTo.Time) || in.Now.Before(ex.Spec.ActiveFrom.Time) {
+		if !e.exceptionUsable(ctx, ex, in.Now) {
 			continue
 		}
 		if !constraintsPass(ex.Spec.Constraints, in.ObjectLabels, in.Username, in.Groups) {
 			continue
 		}
+		if in.OldObjectLabels != nil && !constraintsPass(ex.Spec.Constraints, in.OldObjectLabels, in.Username, in.Groups) {
+			continue
+		}
+		if !changeAllowed(ex.Spec.Constraints, in.Change) {
+			continue
+		}
 		return &PolicyRef{Kind: PolicyKindFreezeException, Name: ex.Name}
 	}
 	return nil
 }
 
+// breakGlassAllowed reports whether the request carries a break-glass reason from a
+// member of a break-glass group.
+func (e *Evaluator) breakGlassAllowed(in Input) bool {
+	if in.BreakGlassReason == "" || len(e.BreakGlassGroups) == 0 {
+		return false
+	}
+	return slices.ContainsFunc(in.Groups, func(g string) bool { return slices.Contains(e.BreakGlassGroups, g) })
+}
+
+// exceptionCovers reports whether the exception's target or objects select the object.
+func exceptionCovers(ex *freezev1alpha1.FreezeException, in Input, nsLabels map[string]string) bool {
+	if ex.Spec.Target == nil {
+		return objectListed(ex.Spec.Objects, in)
+	}
+	// An exception must cover the object both before and after the change;
+	// otherwise adding or removing labels could be used to qualify for it.
+	if !targetMatches(ex.Spec.Target, nsLabels, in.ObjectLabels, in.Kind) {
+		return false
+	}
+	if in.OldObjectLabels != nil && !targetMatches(ex.Spec.Target, nsLabels, in.OldObjectLabels, in.Kind) {
+		return false
+	}
+	return true
+}
+
+func objectListed(objects []freezev1alpha1.ExceptionObjectReference, in Input) bool {
+	if in.Name == "" {
+		return false
+	}
+	return slices.ContainsFunc(objects, func(o freezev1alpha1.ExceptionObjectReference) bool {
+		return o.Kind == in.Kind && o.Namespace == in.Namespace && o.Name == in.Name
+	})
+}
+
+// exceptionUsable reports whether ex may grant overrides at now, independent of
+// what it targets.
+func (e *Evaluator) exceptionUsable(ctx context.Context, ex *freezev1alpha1.FreezeException, now time.Time) bool {
+	if !now.Before(ex.Spec.ActiveTo.Time) || now.Before(ex.Spec.ActiveFrom.Time) {
+		return false
+	}
+	if e.RequireApprovedExceptions && ex.Spec.ApprovedBy == "" {
+		return false
+	}
+	if ExceptionExhausted(ex) {
+		return false
+	}
+	return e.approvalQuorumReached(ctx, ex)
+}
+
+// approvalQuorumReached reports whether ex has been approved by RequiredApprovals
+// distinct users at its current generation. Approvals are counted from the
+// FreezeExceptionApproval objects, whose approver the admission webhook checks,
+// rather than from status.approvals, which any status writer could forge.
+func (e *Evaluator) approvalQuorumReached(ctx context.Context, ex *freezev1alpha1.FreezeException) bool {
+	required := RequiredApprovals(ex, e.MinApprovals)
+	if required == 0 {
+		return true
+	}
+	approvals, err := ExceptionApprovals(ctx, e.Client, ex)
+	if err != nil {
+		return false
+	}
+	return countApprovals(approvals, ex.Generation) >= required
+}
+
+// ExceptionExhausted reports whether ex has admitted spec.maxUses requests.
+func ExceptionExhausted(ex *freezev1alpha1.FreezeException) bool {
+	return ex.Spec.MaxUses > 0 && ex.Status.Usage.Count >= ex.Spec.MaxUses
+}
+
+// RequiredApprovals returns the number of approvals ex needs: spec.requiredApprovals,
+// raised to the operator-wide minimum.
+func RequiredApprovals(ex *freezev1alpha1.FreezeException, minimum int32) int32 {
+	return max(ex.Spec.RequiredApprovals, minimum)
+}
+
+// CurrentApprovals counts the distinct users in status.approvals that approved the
+// current generation of ex.
+func CurrentApprovals(ex *freezev1alpha1.FreezeException) int32 {
+	return countApprovals(ex.Status.Approvals, ex.Generation)
+}
+
+// ExceptionApprovals returns the approvals of ex, one per user and generation, oldest
+// first. Approvals by the creator of the exception are ignored.
+func ExceptionApprovals(ctx context.Context, c client.Reader, ex *freezev1alpha1.FreezeException) ([]freezev1alpha1.FreezeExceptionApproverStatus, error) {
+	var list freezev1alpha1.FreezeExceptionApprovalList
+	if err := c.List(ctx, &list); err != nil {
+		return nil, fmt.Errorf("list FreezeExceptionApproval: %w", err)
+	}
+
+	creator := ex.Annotations[freezev1alpha1.AnnotationCreatedBy]
+	var out []freezev1alpha1.FreezeExceptionApproverStatus
+	for i := range list.Items {
+		a := &list.Items[i]
+		if a.Spec.ExceptionName != ex.Name || a.DeletionTimestamp != nil {
+			continue
+		}
+		if creator != "" && a.Spec.Approver == creator {
+			continue
+		}
+		// Approvals of a recreated exception with the same name must not carry over.
+		if a.CreationTimestamp.Before(&ex.CreationTimestamp) {
+			continue
+		}
+		dup := slices.ContainsFunc(out, func(s freezev1alpha1.FreezeExceptionApproverStatus) bool {
+			return s.User == a.Spec.Approver && s.Generation == a.Spec.ExceptionGeneration
+		})
+		if dup {
+			continue
+		}
+		out = append(out, freezev1alpha1.FreezeExceptionApproverStatus{
+			User:       a.Spec.Approver,
+			Approval:   a.Name,
+			Generation: a.Spec.ExceptionGeneration,
+			ApprovedAt: a.CreationTimestamp,
+		})
+	}
+	slices.SortStableFunc(out, func(a, b freezev1alpha1.FreezeExceptionApproverStatus) int {
+		return a.ApprovedAt.Compare(b.ApprovedAt.Time)
+	})
+	return out, nil
+}
+
+// countApprovals counts the distinct users in approvals that approved generation.
+func countApprovals(approvals []freezev1alpha1.FreezeExceptionApproverStatus, generation int64) int32 {
+	var users []string
+	for _, a := range approvals {
+		if a.Generation == generation && !slices.Contains(users, a.User) {
+			users = append(users, a.User)
+		}
+	}
+	return int32(len(users))
+}
+
+// TargetMatches reports whether t selects an object of kind with objLabels in a
+// namespace with nsLabels, exactly as admission requests are matched.
+func TargetMatches(t *freezev1alpha1.TargetSpec, nsLabels, objLabels map[string]string, kind freezev1alpha1.TargetKind) bool {
+	return targetMatches(t, nsLabels, objLabels, kind)
+}
+
+// NamespaceMatches reports whether t's namespaceSelector matches nsLabels.
+func NamespaceMatches(t *freezev1alpha1.TargetSpec, nsLabels map[string]string) bool {
+	if t == nil {
+		return false
+	}
+	ok, err := matchLabelSelector(t.NamespaceSelector, nsLabels)
+	return err == nil && ok
+}
+
 func targetMatches(t *freezev1alpha1.TargetSpec, nsLabels map[string]string, objLabels map[string]string, kind freezev1alpha1.TargetKind) bool {
 	if t == nil {
 		return false
@@ -253,6 +521,23 @@ func targetMatches(t *freezev1alpha1.TargetSpec, nsLabels map[string]string, obj
 	return true
 }
 
+// scopeMatches reports whether the object is in the target's scope before or after
+// the change, and whether the change moves it out of scope (a selector escape).
+// Escapes are deniable regardless of the classified action: otherwise removing a
+// label would let every later change through.
+func scopeMatches(t *freezev1alpha1.TargetSpec, nsLabels map[string]string, in Input) (inScope bool, escaping bool) {
+	inNew := targetMatches(t, nsLabels, in.ObjectLabels, in.Kind)
+	if in.OldObjectLabels == nil {
+		return inNew, false
+	}
+	inOld := targetMatches(t, nsLabels, in.OldObjectLabels, in.Kind)
+	return inNew || inOld, inOld && !inNew
+}
+
+func escapeReason(reason string) string {
+	return fmt.Sprintf("%s (label change would move the object out of the policy scope)", reason)
+}
+
 func actionIn(a freezev1alpha1.Action, list []freezev1alpha1.Action) bool {
 	return slices.Contains(list, a)
 }
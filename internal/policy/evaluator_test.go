@@ -12,6 +12,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/diff"
 )
 
 func TestEvaluator_ChangeFreezeDenyAndExceptionAllow(t *testing.T) {
@@ -44,7 +45,7 @@ func TestEvaluator_ChangeFreezeDenyAndExceptionAllow(t *testing.T) {
 		Spec: freezev1alpha1.FreezeExceptionSpec{
 			ActiveFrom: metav1.Time{Time: now.Add(-time.Minute)},
 			ActiveTo:   metav1.Time{Time: now.Add(time.Minute)},
-			Target: freezev1alpha1.TargetSpec{
+			Target: &freezev1alpha1.TargetSpec{
 				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
 				Kinds:             []freezev1alpha1.TargetKind{freezev1alpha1.TargetKindDeployment},
 			},
@@ -114,3 +115,154 @@ func TestEvaluator_ChangeFreezeDeny(t *testing.T) {
 	g.Expect(dec.Reason).To(Equal("freeze"))
 	g.Expect(dec.NextAllowedTime).ToNot(BeNil())
 }
+
+func TestCompileImagePattern(t *testing.T) {
+	g := NewWithT(t)
+
+	glob, err := CompileImagePattern("registry.example.com/payments/*")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(glob.MatchString("registry.example.com/payments/api:v2")).To(BeTrue())
+	g.Expect(glob.MatchString("registry.example.com/payments/team/api:v2")).To(BeTrue(), "* must cross /")
+	g.Expect(glob.MatchString("registry.example.com/payments-evil/api:v2")).To(BeFalse())
+	g.Expect(glob.MatchString("evil.io/registry.example.com/payments/api")).To(BeFalse(), "globs are anchored")
+
+	re, err := CompileImagePattern(`regex:registry\.example\.com/api:v[0-9]+`)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(re.MatchString("registry.example.com/api:v12")).To(BeTrue())
+	g.Expect(re.MatchString("registry.example.com/api:v12-debug")).To(BeFalse(), "regexes are anchored")
+
+	_, err = CompileImagePattern("regex:(")
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestChangeAllowed(t *testing.T) {
+	g := NewWithT(t)
+
+	const digest = "@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	imageChange := func(name, image string, changes ...freezev1alpha1.ContainerChange) *diff.PodTemplateChange {
+		return &diff.PodTemplateChange{Containers: []diff.ContainerDiff{{
+			Name: name, OldImage: "registry.example.com/api:v1", NewImage: image, Changes: changes,
+		}}}
+	}
+
+	c := &freezev1alpha1.FreezeExceptionConstraintsSpec{AllowedImages: []string{"registry.example.com/api*"}}
+	g.Expect(changeAllowed(c, imageChange("app", "registry.example.com/api:v2", freezev1alpha1.ContainerChangeImage))).To(BeTrue())
+	g.Expect(changeAllowed(c, imageChange("app", "docker.io/feature:v1", freezev1alpha1.ContainerChangeImage))).To(BeFalse())
+	g.Expect(changeAllowed(c, nil)).To(BeTrue(), "no pod template change")
+
+	c.RequireDigest = true
+	g.Expect(changeAllowed(c, imageChange("app", "registry.example.com/api:v2", freezev1alpha1.ContainerChangeImage))).To(BeFalse())
+	g.Expect(changeAllowed(c, imageChange("app", "registry.example.com/api"+digest, freezev1alpha1.ContainerChangeImage))).To(BeTrue())
+
+	c = &freezev1alpha1.FreezeExceptionConstraintsSpec{Containers: []freezev1alpha1.ContainerConstraintSpec{
+		{Name: "app", AllowedChanges: []freezev1alpha1.ContainerChange{freezev1alpha1.ContainerChangeImage}},
+	}}
+	g.Expect(changeAllowed(c, imageChange("app", "registry.example.com/api:v2", freezev1alpha1.ContainerChangeImage))).To(BeTrue())
+	g.Expect(changeAllowed(c, imageChange("app", "registry.example.com/api:v2", freezev1alpha1.ContainerChangeImage, freezev1alpha1.ContainerChangeEnv))).To(BeFalse())
+	g.Expect(changeAllowed(c, imageChange("sidecar", "proxy:v2", freezev1alpha1.ContainerChangeImage))).To(BeFalse(), "unlisted container")
+	g.Expect(changeAllowed(c, &diff.PodTemplateChange{PodSpecChanged: true})).To(BeFalse())
+}
+
+func TestEvaluator_BreakGlassOverride(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(freezev1alpha1.AddToScheme(scheme)).To(Succeed())
+
+	now := time.Date(2026, 1, 28, 12, 0, 0, 0, time.UTC)
+	cf := &freezev1alpha1.ChangeFreeze{
+		ObjectMeta: metav1.ObjectMeta{Name: "cf"},
+		Spec: freezev1alpha1.ChangeFreezeSpec{
+			StartTime: metav1.Time{Time: now.Add(-time.Hour)},
+			EndTime:   metav1.Time{Time: now.Add(time.Hour)},
+			Target:    freezev1alpha1.TargetSpec{Kinds: []freezev1alpha1.TargetKind{freezev1alpha1.TargetKindDeployment}},
+			Rules:     freezev1alpha1.PolicyRulesSpec{Deny: []freezev1alpha1.Action{freezev1alpha1.ActionRollout}},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cf).Build()
+	e := &Evaluator{Client: cl, BreakGlassGroups: []string{"sre"}}
+
+	in := Input{
+		Now:              now,
+		Namespace:        "prod",
+		NamespaceTags:    map[string]string{},
+		Kind:             freezev1alpha1.TargetKindDeployment,
+		Action:           freezev1alpha1.ActionRollout,
+		Username:         "alice",
+		Groups:           []string{"sre"},
+		BreakGlassReason: "INC-1",
+	}
+	dec, err := e.Evaluate(context.Background(), in)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(dec.Allowed).To(BeTrue())
+	g.Expect(dec.MatchedOverride).To(Equal(&PolicyRef{Kind: PolicyKindBreakGlass, Name: "alice"}))
+	g.Expect(dec.MatchedPolicy).To(Equal(&PolicyRef{Kind: PolicyKindChangeFreeze, Name: "cf"}))
+
+	in.Groups = []string{"developers"}
+	dec, err = e.Evaluate(context.Background(), in)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(dec.Allowed).To(BeFalse())
+}
+
+func TestEvaluator_Upcoming(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(freezev1alpha1.AddToScheme(scheme)).To(Succeed())
+
+	now := time.Date(2026, 1, 28, 12, 0, 0, 0, time.UTC)
+	target := freezev1alpha1.TargetSpec{Kinds: []freezev1alpha1.TargetKind{freezev1alpha1.TargetKindDeployment}}
+	rules := freezev1alpha1.PolicyRulesSpec{Deny: []freezev1alpha1.Action{freezev1alpha1.ActionRollout}}
+	notice := &metav1.Duration{Duration: 6 * time.Hour}
+
+	soon := &freezev1alpha1.ChangeFreeze{
+		ObjectMeta: metav1.ObjectMeta{Name: "holiday-2026"},
+		Spec: freezev1alpha1.ChangeFreezeSpec{
+			StartTime:    metav1.Time{Time: now.Add(3*time.Hour + 12*time.Minute)},
+			EndTime:      metav1.Time{Time: now.Add(48 * time.Hour)},
+			NoticePeriod: notice,
+			Target:       target,
+			Rules:        rules,
+		},
+	}
+	later := soon.DeepCopy()
+	later.Name = "later"
+	later.Spec.StartTime = metav1.Time{Time: now.Add(12 * time.Hour)}
+	silent := soon.DeepCopy()
+	silent.Name = "silent"
+	silent.Spec.NoticePeriod = nil
+	// Open 11:00-13:00 UTC every day, so changes are denied again from 13:00.
+	mw := &freezev1alpha1.MaintenanceWindow{
+		ObjectMeta: metav1.ObjectMeta{Name: "midday"},
+		Spec: freezev1alpha1.MaintenanceWindowSpec{
+			Timezone:     "UTC",
+			Mode:         freezev1alpha1.MaintenanceWindowModeDenyOutsideWindows,
+			Windows:      []freezev1alpha1.MaintenanceWindowWindowSpec{{Name: "midday", Schedule: "0 11 * * *", Duration: metav1.Duration{Duration: 2 * time.Hour}}},
+			NoticePeriod: notice,
+			Target:       target,
+			Rules:        rules,
+		},
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(soon, later, silent, mw).Build()
+	e := &Evaluator{Client: cl}
+
+	dec, err := e.Evaluate(context.Background(), Input{
+		Now:           now,
+		Namespace:     "prod",
+		NamespaceTags: map[string]string{},
+		Kind:          freezev1alpha1.TargetKindDeployment,
+		Action:        freezev1alpha1.ActionRollout,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(dec.Allowed).To(BeTrue())
+	g.Expect(dec.Upcoming).To(HaveLen(2))
+	g.Expect(dec.Upcoming[0].Policy).To(Equal(PolicyRef{Kind: PolicyKindMaintenanceWindow, Name: "midday"}))
+	g.Expect(dec.Upcoming[0].StartTime).To(BeTemporally("==", now.Add(time.Hour)))
+	g.Expect(dec.Upcoming[1].Policy).To(Equal(PolicyRef{Kind: PolicyKindChangeFreeze, Name: "holiday-2026"}))
+	g.Expect(dec.Upcoming[1].StartTime).To(BeTemporally("==", now.Add(3*time.Hour+12*time.Minute)))
+	g.Expect(dec.Upcoming[1].Message(now)).To(Equal("ChangeFreeze holiday-2026 starts in 3h12m"))
+	g.Expect(dec.Upcoming[0].Message(now)).To(Equal("MaintenanceWindow midday closes in 1h0m"))
+}
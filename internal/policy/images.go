@@ -0,0 +1,96 @@
+package policy
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/diff"
+)
+
+// regexImagePrefix marks an allowedImages pattern as a regular expression.
+const regexImagePrefix = "regex:"
+
+var digestPattern = regexp.MustCompile(`@sha256:[a-f0-9]{64}$`)
+
+// CompileImagePattern compiles an allowedImages pattern. Globs support "*" (any
+// characters, including "/") and "?" (one character); "regex:" patterns are regular
+// expressions. Either form must match the whole image reference.
+func CompileImagePattern(pattern string) (*regexp.Regexp, error) {
+	if expr, ok := strings.CutPrefix(pattern, regexImagePrefix); ok {
+		return regexp.Compile("^(?:" + expr + ")$")
+	}
+	var b strings.Builder
+	b.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+func imageAllowed(patterns []string, image string) bool {
+	for _, p := range patterns {
+		re, err := CompileImagePattern(p)
+		if err != nil {
+			continue
+		}
+		if re.MatchString(image) {
+			return true
+		}
+	}
+	return false
+}
+
+// changeAllowed reports whether the pod template change stays within the image and
+// container constraints of an exception. A nil change means the pod template did
+// not change.
+func changeAllowed(c *freezev1alpha1.FreezeExceptionConstraintsSpec, change *diff.PodTemplateChange) bool {
+	if c == nil || change == nil {
+		return true
+	}
+	for _, d := range change.Containers {
+		if d.ImageChanged() {
+			if len(c.AllowedImages) > 0 && !imageAllowed(c.AllowedImages, d.NewImage) {
+				return false
+			}
+			if c.RequireDigest && !digestPattern.MatchString(d.NewImage) {
+				return false
+			}
+		}
+	}
+
+	if len(c.Containers) == 0 {
+		return true
+	}
+	if change.PodSpecChanged {
+		return false
+	}
+	for _, d := range change.Containers {
+		if !containerChangeAllowed(c.Containers, d) {
+			return false
+		}
+	}
+	return true
+}
+
+// containerChangeAllowed checks d against the first constraint naming the container,
+// falling back to a "*" entry.
+func containerChangeAllowed(constraints []freezev1alpha1.ContainerConstraintSpec, d diff.ContainerDiff) bool {
+	idx := slices.IndexFunc(constraints, func(cc freezev1alpha1.ContainerConstraintSpec) bool { return cc.Name == d.Name })
+	if idx < 0 {
+		idx = slices.IndexFunc(constraints, func(cc freezev1alpha1.ContainerConstraintSpec) bool { return cc.Name == "*" })
+	}
+	if idx < 0 {
+		return false
+	}
+	return containsAll(constraints[idx].AllowedChanges, d.Changes)
+}
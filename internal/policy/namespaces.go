@@ -0,0 +1,181 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+)
+
+// NamespaceChangeInput describes a label change on a Namespace.
+type NamespaceChangeInput struct {
+	Now time.Time
+
+	Namespace string
+	OldLabels map[string]string
+	NewLabels map[string]string
+
+	Username string
+	Groups   []string
+}
+
+// NamespaceChangeDecision lists the active policies whose namespaceSelector result would
+// flip because of the label change. Policies covered by a FreezeException are reported
+// in Overridden and do not cause a deny.
+type NamespaceChangeDecision struct {
+	Allowed bool
+
+	AffectedPolicies []PolicyRef
+	Overridden       []PolicyRef
+	MatchedOverrides []PolicyRef
+
+	EvaluationTime time.Time
+}
+
+type scopedPolicy struct {
+	ref    PolicyRef
+	target *freezev1alpha1.TargetSpec
+	deny   []freezev1alpha1.Action
+}
+
+// EvaluateNamespaceChange decides whether a namespace label change may be admitted.
+// Relabelling a namespace moves it in or out of every policy that selects it, so the
+// change is denied while any such policy is enforcing, unless an active exception
+// covers the namespace for every kind and action that policy denies.
+func (e *Evaluator) EvaluateNamespaceChange(ctx context.Context, in NamespaceChangeInput) (NamespaceChangeDecision, error) {
+	if e == nil || e.Client == nil {
+		return NamespaceChangeDecision{}, errors.New("policy evaluator: client is required")
+	}
+	if in.Now.IsZero() {
+		in.Now = time.Now().UTC()
+	}
+
+	dec := NamespaceChangeDecision{Allowed: true, EvaluationTime: in.Now}
+
+	active, err := e.collectEnforcingPolicies(ctx, in.Now)
+	if err != nil {
+		return NamespaceChangeDecision{}, err
+	}
+
+	var exceptions *freezev1alpha1.FreezeExceptionList
+	for _, p := range active {
+		before, err := matchLabelSelector(p.target.NamespaceSelector, in.OldLabels)
+		if err != nil {
+			continue
+		}
+		after, err := matchLabelSelector(p.target.NamespaceSelector, in.NewLabels)
+		if err != nil || before == after {
+			continue
+		}
+
+		if exceptions == nil {
+			exceptions = &freezev1alpha1.FreezeExceptionList{}
+			if err := e.Client.List(ctx, exceptions); err != nil {
+				return NamespaceChangeDecision{}, fmt.Errorf("list FreezeException: %w", err)
+			}
+		}
+		if ex := e.namespaceExceptionFor(ctx, exceptions, p, in); ex != nil {
+			dec.Overridden = append(dec.Overridden, p.ref)
+			dec.MatchedOverrides = append(dec.MatchedOverrides, *ex)
+			continue
+		}
+		dec.AffectedPolicies = append(dec.AffectedPolicies, p.ref)
+	}
+
+	dec.Allowed = len(dec.AffectedPolicies) == 0
+	return dec, nil
+}
+
+// collectEnforcingPolicies returns ChangeFreezes and MaintenanceWindows that deny
+// changes at now, sorted by kind and name.
+func (e *Evaluator) collectEnforcingPolicies(ctx context.Context, now time.Time) ([]scopedPolicy, error) {
+	var cfs freezev1alpha1.ChangeFreezeList
+	if err := e.Client.List(ctx, &cfs); err != nil {
+		return nil, fmt.Errorf("list ChangeFreeze: %w", err)
+	}
+	var mws freezev1alpha1.MaintenanceWindowList
+	if err := e.Client.List(ctx, &mws); err != nil {
+		return nil, fmt.Errorf("list MaintenanceWindow: %w", err)
+	}
+
+	out := make([]scopedPolicy, 0, len(cfs.Items)+len(mws.Items))
+	for i := range cfs.Items {
+		cf := &cfs.Items[i]
+		if !ChangeFreezeActive(cf, now) {
+			continue
+		}
+		out = append(out, scopedPolicy{
+			ref:    PolicyRef{Kind: PolicyKindChangeFreeze, Name: cf.Name},
+			target: &cf.Spec.Target,
+			deny:   cf.Spec.Rules.Deny,
+		})
+	}
+	for i := range mws.Items {
+		mw := &mws.Items[i]
+		if denying, _ := MaintenanceWindowDenying(mw, now); !denying {
+			continue
+		}
+		out = append(out, scopedPolicy{
+			ref:    PolicyRef{Kind: PolicyKindMaintenanceWindow, Name: mw.Name},
+			target: &mw.Spec.Target,
+			deny:   mw.Spec.Rules.Deny,
+		})
+	}
+	slices.SortStableFunc(out, func(a, b scopedPolicy) int {
+		if a.ref.Kind != b.ref.Kind {
+			if a.ref.Kind < b.ref.Kind {
+				return -1
+			}
+			return 1
+		}
+		if a.ref.Name < b.ref.Name {
+			return -1
+		}
+		if a.ref.Name > b.ref.Name {
+			return 1
+		}
+		return 0
+	})
+	return out, nil
+}
+
+// namespaceExceptionFor returns the first active exception whose target selects the
+// namespace (before or after the change) without an objectSelector, and allows every kind and
+// action the policy denies.
+func (e *Evaluator) namespaceExceptionFor(ctx context.Context, list *freezev1alpha1.FreezeExceptionList, p scopedPolicy, in NamespaceChangeInput) *PolicyRef {
+	for i := range list.Items {
+		ex := &list.Items[i]
+		if !e.exceptionUsable(ctx, ex, in.Now) {
+			continue
+		}
+		// Exceptions for individual objects or labelled objects cannot cover a namespace.
+		if ex.Spec.Target == nil || ex.Spec.Target.ObjectSelector != nil {
+			continue
+		}
+		before, _ := matchLabelSelector(ex.Spec.Target.NamespaceSelector, in.OldLabels)
+		after, _ := matchLabelSelector(ex.Spec.Target.NamespaceSelector, in.NewLabels)
+		if !before && !after {
+			continue
+		}
+		if !containsAll(ex.Spec.Target.Kinds, p.target.Kinds) || !containsAll(ex.Spec.Allow, p.deny) {
+			continue
+		}
+		if !constraintsPass(ex.Spec.Constraints, nil, in.Username, in.Groups) {
+			continue
+		}
+		return &PolicyRef{Kind: PolicyKindFreezeException, Name: ex.Name}
+	}
+	return nil
+}
+
+func containsAll[T comparable](have []T, want []T) bool {
+	for _, w := range want {
+		if !slices.Contains(have, w) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,25 @@
+package policy
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+
+	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+)
+
+// budgetFor returns the budget the rate limit of an active policy imposes on in, if any.
+func budgetFor(ref PolicyRef, uid types.UID, rules *freezev1alpha1.PolicyRulesSpec, in Input) *Budget {
+	rl := rules.RateLimit
+	if rl == nil || !actionIn(in.Action, rl.Actions) || rl.Period.Duration <= 0 {
+		return nil
+	}
+	b := &Budget{
+		Policy:    ref,
+		PolicyUID: uid,
+		Limit:     rl.Limit,
+		Period:    rl.Period.Duration,
+	}
+	if rl.Scope == freezev1alpha1.RateLimitScopeNamespace {
+		b.Namespace = in.Namespace
+	}
+	return b
+}
@@ -0,0 +1,167 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+)
+
+// maxListedRollouts bounds the number of workloads named in a RolloutLimit denial.
+const maxListedRollouts = 10
+
+// collectRolloutLimits adds a deny candidate for every RolloutLimit whose scope
+// already has maxConcurrent rollouts in progress. Only ROLL_OUT requests are
+// limited, and the requested workload itself does not count.
+func (e *Evaluator) collectRolloutLimits(ctx context.Context, in Input, nsLabels map[string]string, m *matches) error {
+	if in.Action != freezev1alpha1.ActionRollout {
+		return nil
+	}
+	var list freezev1alpha1.RolloutLimitList
+	if err := e.Client.List(ctx, &list); err != nil {
+		return fmt.Errorf("list RolloutLimit: %w", err)
+	}
+
+	var rolling []workloadRef
+	loaded := false
+	for i := range list.Items {
+		rl := &list.Items[i]
+		if inScope, _ := scopeMatches(&rl.Spec.Target, nsLabels, in); !inScope {
+			continue
+		}
+		if !loaded {
+			var err error
+			if rolling, err = e.rollingWorkloads(ctx); err != nil {
+				return err
+			}
+			loaded = true
+		}
+
+		var counted []string
+		for _, w := range rolling {
+			if w.kind == in.Kind && w.namespace == in.Namespace && w.name == in.Name {
+				continue
+			}
+			if targetMatches(&rl.Spec.Target, w.nsLabels, w.labels, w.kind) {
+				counted = append(counted, w.String())
+			}
+		}
+		if int32(len(counted)) < rl.Spec.MaxConcurrent {
+			continue
+		}
+		m.denies = append(m.denies, denyCandidate{
+			ref:            PolicyRef{Kind: PolicyKindRolloutLimit, Name: rl.Name},
+			reason:         rolloutLimitReason(rl, counted),
+			behavior:       &freezev1alpha1.PolicyBehaviorSpec{},
+			determinsticId: rl.Name,
+		})
+	}
+	return nil
+}
+
+func rolloutLimitReason(rl *freezev1alpha1.RolloutLimit, counted []string) string {
+	reason := firstNonEmpty(rl.Spec.Message.Reason, "Too many rollouts in progress")
+	listed := counted
+	if len(listed) > maxListedRollouts {
+		listed = listed[:maxListedRollouts]
+	}
+	out := fmt.Sprintf("%s (%d/%d): %s", reason, len(counted), rl.Spec.MaxConcurrent, strings.Join(listed, ", "))
+	if n := len(counted) - len(listed); n > 0 {
+		out += fmt.Sprintf(" and %d more", n)
+	}
+	return out
+}
+
+// workloadRef is a Deployment or StatefulSet with its namespace labels.
+type workloadRef struct {
+	kind      freezev1alpha1.TargetKind
+	namespace string
+	name      string
+	labels    map[string]string
+	nsLabels  map[string]string
+}
+
+func (w workloadRef) String() string {
+	return fmt.Sprintf("%s %s/%s", w.kind, w.namespace, w.name)
+}
+
+// rollingWorkloads lists the Deployments and StatefulSets with a rollout in
+// progress, sorted by kind, namespace and name.
+func (e *Evaluator) rollingWorkloads(ctx context.Context) ([]workloadRef, error) {
+	var nsList corev1.NamespaceList
+	if err := e.Client.List(ctx, &nsList); err != nil {
+		return nil, fmt.Errorf("list namespaces: %w", err)
+	}
+	nsLabels := make(map[string]map[string]string, len(nsList.Items))
+	for i := range nsList.Items {
+		nsLabels[nsList.Items[i].Name] = nsList.Items[i].Labels
+	}
+
+	var out []workloadRef
+	var deployments appsv1.DeploymentList
+	if err := e.Client.List(ctx, &deployments); err != nil {
+		return nil, fmt.Errorf("list Deployments: %w", err)
+	}
+	for i := range deployments.Items {
+		d := &deployments.Items[i]
+		if DeploymentRollingOut(d) {
+			out = append(out, workloadRef{freezev1alpha1.TargetKindDeployment, d.Namespace, d.Name, d.Labels, nsLabels[d.Namespace]})
+		}
+	}
+	var statefulSets appsv1.StatefulSetList
+	if err := e.Client.List(ctx, &statefulSets); err != nil {
+		return nil, fmt.Errorf("list StatefulSets: %w", err)
+	}
+	for i := range statefulSets.Items {
+		s := &statefulSets.Items[i]
+		if StatefulSetRollingOut(s) {
+			out = append(out, workloadRef{freezev1alpha1.TargetKindStatefulSet, s.Namespace, s.Name, s.Labels, nsLabels[s.Namespace]})
+		}
+	}
+	slices.SortFunc(out, func(a, b workloadRef) int { return strings.Compare(a.String(), b.String()) })
+	return out, nil
+}
+
+// DeploymentRollingOut reports whether d has a rollout in progress, following the
+// checks of "kubectl rollout status". Paused Deployments are not rolling out.
+func DeploymentRollingOut(d *appsv1.Deployment) bool {
+	if d.Spec.Paused {
+		return false
+	}
+	if d.Status.ObservedGeneration < d.Generation {
+		return true
+	}
+	replicas := ptr.Deref(d.Spec.Replicas, 1)
+	return d.Status.UpdatedReplicas < replicas ||
+		d.Status.Replicas > d.Status.UpdatedReplicas ||
+		d.Status.AvailableReplicas < d.Status.UpdatedReplicas
+}
+
+// StatefulSetRollingOut reports whether s has a rollout in progress, following the
+// checks of "kubectl rollout status". OnDelete StatefulSets are never rolling out.
+func StatefulSetRollingOut(s *appsv1.StatefulSet) bool {
+	if s.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return false
+	}
+	if s.Status.ObservedGeneration < s.Generation {
+		return true
+	}
+	replicas := ptr.Deref(s.Spec.Replicas, 1)
+	if s.Status.ReadyReplicas < replicas {
+		return true
+	}
+	var partition int32
+	if ru := s.Spec.UpdateStrategy.RollingUpdate; ru != nil {
+		partition = ptr.Deref(ru.Partition, 0)
+	}
+	if s.Status.UpdatedReplicas < replicas-partition {
+		return true
+	}
+	return partition == 0 && s.Status.UpdateRevision != s.Status.CurrentRevision
+}
@@ -0,0 +1,137 @@
+package policy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+)
+
+func deployment(ns, name string, updated, available, total int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns, Generation: 2},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptr.To[int32](2)},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 2,
+			Replicas:           total,
+			UpdatedReplicas:    updated,
+			AvailableReplicas:  available,
+		},
+	}
+}
+
+func TestDeploymentRollingOut(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(DeploymentRollingOut(deployment("prod", "done", 2, 2, 2))).To(BeFalse())
+	g.Expect(DeploymentRollingOut(deployment("prod", "updating", 1, 2, 3))).To(BeTrue())
+	g.Expect(DeploymentRollingOut(deployment("prod", "old-terminating", 2, 2, 3))).To(BeTrue())
+	g.Expect(DeploymentRollingOut(deployment("prod", "not-available", 2, 1, 2))).To(BeTrue())
+
+	stale := deployment("prod", "stale", 2, 2, 2)
+	stale.Generation = 3
+	g.Expect(DeploymentRollingOut(stale)).To(BeTrue())
+
+	paused := deployment("prod", "paused", 1, 2, 3)
+	paused.Spec.Paused = true
+	g.Expect(DeploymentRollingOut(paused)).To(BeFalse())
+}
+
+func TestStatefulSetRollingOut(t *testing.T) {
+	g := NewWithT(t)
+
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "prod", Generation: 1},
+		Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To[int32](3)},
+		Status: appsv1.StatefulSetStatus{
+			ObservedGeneration: 1,
+			ReadyReplicas:      3,
+			UpdatedReplicas:    3,
+			CurrentRevision:    "db-1",
+			UpdateRevision:     "db-1",
+		},
+	}
+	g.Expect(StatefulSetRollingOut(sts)).To(BeFalse())
+
+	updating := sts.DeepCopy()
+	updating.Status.UpdatedReplicas = 1
+	updating.Status.UpdateRevision = "db-2"
+	g.Expect(StatefulSetRollingOut(updating)).To(BeTrue())
+
+	partitioned := updating.DeepCopy()
+	partitioned.Spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{Partition: ptr.To[int32](2)}
+	g.Expect(StatefulSetRollingOut(partitioned)).To(BeFalse(), "a partitioned rollout is done once the replicas above the partition are updated")
+
+	onDelete := updating.DeepCopy()
+	onDelete.Spec.UpdateStrategy.Type = appsv1.OnDeleteStatefulSetStrategyType
+	g.Expect(StatefulSetRollingOut(onDelete)).To(BeFalse())
+}
+
+func TestEvaluator_RolloutLimit(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(appsv1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(freezev1alpha1.AddToScheme(scheme)).To(Succeed())
+
+	prodLabels := map[string]string{"env": "prod"}
+	rl := &freezev1alpha1.RolloutLimit{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-rollouts"},
+		Spec: freezev1alpha1.RolloutLimitSpec{
+			Target: freezev1alpha1.TargetSpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: prodLabels},
+				Kinds:             []freezev1alpha1.TargetKind{freezev1alpha1.TargetKindDeployment, freezev1alpha1.TargetKindStatefulSet},
+			},
+			MaxConcurrent: 2,
+		},
+	}
+	objs := []client.Object{
+		rl,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod-a", Labels: prodLabels}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod-b", Labels: prodLabels}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev"}},
+		deployment("prod-a", "api", 1, 2, 3),
+		deployment("prod-b", "web", 1, 2, 3),
+		deployment("prod-b", "idle", 2, 2, 2),
+		deployment("dev", "sandbox", 1, 2, 3),
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	e := &Evaluator{Client: cl}
+
+	in := Input{
+		Now:           time.Now().UTC(),
+		Namespace:     "prod-b",
+		NamespaceTags: prodLabels,
+		Kind:          freezev1alpha1.TargetKindDeployment,
+		Action:        freezev1alpha1.ActionRollout,
+		Name:          "idle",
+	}
+	dec, err := e.Evaluate(context.Background(), in)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(dec.Allowed).To(BeFalse())
+	g.Expect(dec.MatchedPolicy).To(Equal(&PolicyRef{Kind: PolicyKindRolloutLimit, Name: "prod-rollouts"}))
+	g.Expect(dec.Reason).To(Equal("Too many rollouts in progress (2/2): Deployment prod-a/api, Deployment prod-b/web"))
+
+	// A workload that is rolling out does not count against its own next rollout.
+	in.Name = "web"
+	dec, err = e.Evaluate(context.Background(), in)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(dec.Allowed).To(BeTrue())
+
+	in.Name = "idle"
+	in.Action = freezev1alpha1.ActionScale
+	dec, err = e.Evaluate(context.Background(), in)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(dec.Allowed).To(BeTrue(), "only ROLL_OUT is limited")
+}
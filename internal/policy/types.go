@@ -3,7 +3,10 @@ package policy
 import (
 	"time"
 
+	"k8s.io/apimachinery/pkg/types"
+
 	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/diff"
 )
 
 type PolicyKind string
@@ -12,6 +15,10 @@ const (
 	PolicyKindFreezeException   PolicyKind = "FreezeException"
 	PolicyKindChangeFreeze      PolicyKind = "ChangeFreeze"
 	PolicyKindMaintenanceWindow PolicyKind = "MaintenanceWindow"
+	PolicyKindRolloutLimit      PolicyKind = "RolloutLimit"
+	// PolicyKindBreakGlass marks an override by the break-glass annotation; the
+	// override name is the requesting user.
+	PolicyKindBreakGlass PolicyKind = "BreakGlass"
 )
 
 type PolicyRef struct {
@@ -28,10 +35,27 @@ type Input struct {
 	Kind   freezev1alpha1.TargetKind
 	Action freezev1alpha1.Action
 
+	// Name is the object name; FreezeExceptions listing objects only match named requests.
+	Name string
+
 	ObjectLabels map[string]string
+	// OldObjectLabels holds the labels before an UPDATE so selector matching
+	// cannot be escaped by relabelling the object; nil for other operations.
+	OldObjectLabels map[string]string
+
+	// Change describes the pod template change of a CREATE or UPDATE; nil when the
+	// pod template did not change or is unknown.
+	Change *diff.PodTemplateChange
 
 	Username string
 	Groups   []string
+
+	// BreakGlassReason is the value of the break-glass annotation on the object, if any.
+	BreakGlassReason string
+
+	// ExcludeExceptions names FreezeExceptions that must not override the request,
+	// such as one whose last use was spent after an earlier evaluation.
+	ExcludeExceptions []string
 }
 
 type Decision struct {
@@ -45,8 +69,45 @@ type Decision struct {
 	NextAllowedTime *time.Time
 	FreezeEndTime   *time.Time
 
+	// Upcoming lists policies that do not deny the request yet but will start to
+	// within their notice period, soonest first.
+	Upcoming []Upcoming
+
+	// DeferChanges is set when the matched policy of a denial asks for denied
+	// changes to be deferred.
+	DeferChanges bool
+
+	// PausesDeployments is set on a denial when every policy that denies the request
+	// pauses Deployments instead.
+	PausesDeployments bool
+
+	// Budgets lists the rate limits an allowed request consumes. The evaluator does
+	// not consume them; the caller must, and deny the request if one is exhausted.
+	Budgets []Budget
+
 	EvaluationTime  time.Time
 	EvaluatedNS     string
 	EvaluatedKind   freezev1alpha1.TargetKind
 	EvaluatedAction freezev1alpha1.Action
 }
+
+// Upcoming is a deny policy inside its notice period.
+type Upcoming struct {
+	Policy PolicyRef
+
+	// StartTime is when the policy starts to deny the request.
+	StartTime time.Time
+}
+
+// Budget is a rate limit that applies to a request.
+type Budget struct {
+	Policy PolicyRef
+	// PolicyUID identifies the policy instance, so a recreated policy starts afresh.
+	PolicyUID types.UID
+
+	// Namespace is set for namespace-scoped budgets; cluster-wide budgets share one counter.
+	Namespace string
+
+	Limit  int32
+	Period time.Duration
+}
@@ -0,0 +1,31 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Message returns a user-facing notice such as "ChangeFreeze holiday-2026 starts in 3h12m".
+func (u Upcoming) Message(now time.Time) string {
+	verb := "starts"
+	if u.Policy.Kind == PolicyKindMaintenanceWindow {
+		verb = "closes"
+	}
+	return fmt.Sprintf("%s %s %s in %s", u.Policy.Kind, u.Policy.Name, verb, FormatLeadTime(u.StartTime.Sub(now)))
+}
+
+// FormatLeadTime formats d rounded to minutes, e.g. "3h12m" or "45m". Durations
+// under a minute are reported as "1m".
+func FormatLeadTime(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d < time.Minute {
+		d = time.Minute
+	}
+	return strings.TrimSuffix(d.String(), "0s")
+}
+
+// withinNotice reports whether start lies in (now, now+notice].
+func withinNotice(now, start time.Time, notice time.Duration) bool {
+	return notice > 0 && start.After(now) && !start.After(now.Add(notice))
+}
@@ -0,0 +1,44 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+)
+
+// ErrExceptionExhausted is returned by RecordExceptionUse when another request spent
+// the last use of the exception.
+var ErrExceptionExhausted = errors.New("exception has no uses left")
+
+// RecordExceptionUse counts one admitted request against a FreezeException. The
+// exception is re-read through reader and written back with its resourceVersion,
+// so concurrent writers cannot spend the same use twice. limited reports whether
+// the exception has a maxUses budget; failing to record a use of such an exception
+// must not admit the request.
+func RecordExceptionUse(ctx context.Context, c client.Client, reader client.Reader, name, user, object string, now time.Time) (limited bool, err error) {
+	err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		ex := &freezev1alpha1.FreezeException{}
+		if err := reader.Get(ctx, types.NamespacedName{Name: name}, ex); err != nil {
+			return fmt.Errorf("get FreezeException %q: %w", name, err)
+		}
+		limited = ex.Spec.MaxUses > 0
+		if ExceptionExhausted(ex) {
+			return ErrExceptionExhausted
+		}
+
+		ex.Status.Usage.Count++
+		ex.Status.Usage.LastUser = user
+		ex.Status.Usage.LastObject = object
+		ex.Status.Usage.LastUsedTime = &metav1.Time{Time: now}
+		return c.Status().Update(ctx, ex)
+	})
+	return limited, err
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package report aggregates, per freeze occurrence, the requests the webhook
+// decided and the objects the controllers suspended or paused, so that the
+// summary can be written to a FreezeReport once the occurrence ends.
+//
+// The aggregator is kept in memory and shared by the webhook and the controllers
+// of one operator process. Only ChangeFreezes and MaintenanceWindows are tracked,
+// and only once a controller has begun their occurrence: replicas that do not
+// lead run no controllers and record nothing.
+package report
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/policy"
+)
+
+// SummarySize is the number of entries listed per breakdown in a FreezeSummary.
+const SummarySize = 50
+
+// Aggregator collects the summary of the open freeze occurrence of every policy.
+// The zero value is ready to use; a nil Aggregator records nothing.
+type Aggregator struct {
+	mu          sync.Mutex
+	occurrences map[freezev1alpha1.PolicyReference]*occurrence
+}
+
+type exceptionKey struct {
+	exception string
+	user      string
+}
+
+// occurrence is the open freeze occurrence of a policy.
+type occurrence struct {
+	// start is the start of the occurrence, as passed to Begin.
+	start time.Time
+	since time.Time
+
+	denied      int
+	byUser      map[string]int
+	byNamespace map[string]int
+	byAction    map[string]int
+	exceptions  map[exceptionKey]int
+
+	breakGlassCount int
+	breakGlass      []freezev1alpha1.BreakGlassUse
+
+	gitopsPeak, gitopsCurrent int
+	cronJobsPeak              int
+}
+
+// tracked reports whether ref is a policy whose occurrences are reported.
+func tracked(ref freezev1alpha1.PolicyReference) bool {
+	return ref.Kind == string(policy.PolicyKindChangeFreeze) || ref.Kind == string(policy.PolicyKindMaintenanceWindow)
+}
+
+// open returns the occurrence of ref, or nil if none was begun. It must be
+// called with the lock held.
+func (a *Aggregator) open(ref freezev1alpha1.PolicyReference) *occurrence {
+	return a.occurrences[ref]
+}
+
+// Begin opens the occurrence of ref starting at start. Calling it again with the
+// same start is a no-op, so controllers may call it on every reconcile while the
+// policy enforces. A different start, such as a rescheduled freeze, drops what
+// was recorded for the previous one.
+func (a *Aggregator) Begin(ref freezev1alpha1.PolicyReference, start time.Time) {
+	if a == nil || !tracked(ref) {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if o := a.occurrences[ref]; o != nil && o.start.Equal(start) {
+		return
+	}
+	if a.occurrences == nil {
+		a.occurrences = make(map[freezev1alpha1.PolicyReference]*occurrence)
+	}
+	a.occurrences[ref] = &occurrence{
+		start:       start,
+		since:       time.Now(),
+		byUser:      make(map[string]int),
+		byNamespace: make(map[string]int),
+		byAction:    make(map[string]int),
+		exceptions:  make(map[exceptionKey]int),
+	}
+}
+
+// Started returns the start of the occurrence of ref, if it was begun.
+func (a *Aggregator) Started(ref freezev1alpha1.PolicyReference) (time.Time, bool) {
+	if a == nil {
+		return time.Time{}, false
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	o := a.open(ref)
+	if o == nil {
+		return time.Time{}, false
+	}
+	return o.start, true
+}
+
+// Forget drops the occurrence of ref.
+func (a *Aggregator) Forget(ref freezev1alpha1.PolicyReference) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.occurrences, ref)
+}
+
+// RecordDenied counts a request the policy denied. Requests denied while no
+// occurrence of the policy is begun are not counted.
+func (a *Aggregator) RecordDenied(ref freezev1alpha1.PolicyReference, user, namespace string, action freezev1alpha1.Action) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if o := a.open(ref); o != nil {
+		o.denied++
+		o.byUser[user]++
+		o.byNamespace[namespace]++
+		o.byAction[string(action)]++
+	}
+}
+
+// RecordException counts a request a FreezeException admitted despite the policy.
+func (a *Aggregator) RecordException(ref freezev1alpha1.PolicyReference, exception, user string) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if o := a.open(ref); o != nil {
+		o.exceptions[exceptionKey{exception: exception, user: user}]++
+	}
+}
+
+// RecordBreakGlass records a request admitted with break-glass despite the policy.
+func (a *Aggregator) RecordBreakGlass(ref freezev1alpha1.PolicyReference, use freezev1alpha1.BreakGlassUse) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if o := a.open(ref); o != nil {
+		o.breakGlassCount++
+		if len(o.breakGlass) < SummarySize {
+			o.breakGlass = append(o.breakGlass, use)
+		}
+	}
+}
+
+// RecordCronJobs records the number of CronJobs the policy holds suspended.
+func (a *Aggregator) RecordCronJobs(ref freezev1alpha1.PolicyReference, suspended int) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if o := a.open(ref); o != nil {
+		o.cronJobsPeak = max(o.cronJobsPeak, suspended)
+	}
+}
+
+// RecordGitOps records the number of GitOps objects the policy holds paused.
+// Objects paused earlier in the occurrence and no longer held are counted as
+// resumed.
+func (a *Aggregator) RecordGitOps(ref freezev1alpha1.PolicyReference, paused int) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if o := a.open(ref); o != nil {
+		o.gitopsPeak = max(o.gitopsPeak, paused)
+		o.gitopsCurrent = paused
+	}
+}
+
+// Summary returns the summary of the open occurrence of ref.
+func (a *Aggregator) Summary(ref freezev1alpha1.PolicyReference) (*freezev1alpha1.FreezeSummary, bool) {
+	if a == nil {
+		return nil, false
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	o := a.open(ref)
+	if o == nil {
+		return nil, false
+	}
+
+	s := &freezev1alpha1.FreezeSummary{
+		RecordedSince:       metav1.NewTime(o.since),
+		Denied:              o.denied,
+		DeniedByUser:        counts(o.byUser),
+		DeniedByNamespace:   counts(o.byNamespace),
+		DeniedByAction:      counts(o.byAction),
+		BreakGlassOverrides: o.breakGlassCount,
+		BreakGlass:          slices.Clone(o.breakGlass),
+		GitopsPaused:        o.gitopsPeak,
+		GitopsResumed:       o.gitopsPeak - o.gitopsCurrent,
+		CronJobsSuspended:   o.cronJobsPeak,
+	}
+	for k, n := range o.exceptions {
+		s.Exceptions = append(s.Exceptions, freezev1alpha1.ExceptionUse{Exception: k.exception, User: k.user, Count: n})
+	}
+	slices.SortFunc(s.Exceptions, func(a, b freezev1alpha1.ExceptionUse) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Exception, b.Exception), cmp.Compare(a.User, b.User))
+	})
+	s.Exceptions = s.Exceptions[:min(len(s.Exceptions), SummarySize)]
+	return s, true
+}
+
+// counts returns the largest counts of m, most requests first.
+func counts(m map[string]int) []freezev1alpha1.RequestCount {
+	out := make([]freezev1alpha1.RequestCount, 0, len(m))
+	for name, n := range m {
+		out = append(out, freezev1alpha1.RequestCount{Name: name, Count: n})
+	}
+	slices.SortFunc(out, func(a, b freezev1alpha1.RequestCount) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Name, b.Name))
+	})
+	if len(out) == 0 {
+		return nil
+	}
+	return out[:min(len(out), SummarySize)]
+}
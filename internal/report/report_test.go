@@ -0,0 +1,138 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+)
+
+var testRef = freezev1alpha1.PolicyReference{Kind: "ChangeFreeze", Name: "holiday"}
+
+func TestAggregator_Summary(t *testing.T) {
+	g := NewWithT(t)
+	a := &Aggregator{}
+	start := time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC)
+
+	// Requests decided before the controller begins the occurrence are dropped.
+	a.RecordDenied(testRef, "carol", "prod", freezev1alpha1.ActionRollout)
+	_, ok := a.Started(testRef)
+	g.Expect(ok).To(BeFalse())
+
+	a.Begin(testRef, start)
+	a.RecordDenied(testRef, "alice", "prod", freezev1alpha1.ActionRollout)
+	a.Begin(testRef, start)
+	got, ok := a.Started(testRef)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(got).To(Equal(start))
+
+	a.RecordDenied(testRef, "bob", "prod", freezev1alpha1.ActionScale)
+	a.RecordDenied(testRef, "alice", "staging", freezev1alpha1.ActionRollout)
+	a.RecordException(testRef, "ex-hotfix", "alice")
+	a.RecordException(testRef, "ex-hotfix", "alice")
+	a.RecordBreakGlass(testRef, freezev1alpha1.BreakGlassUse{User: "oncall", Namespace: "prod", Name: "api", Reason: "outage"})
+	a.RecordCronJobs(testRef, 3)
+	a.RecordCronJobs(testRef, 0)
+	a.RecordGitOps(testRef, 2)
+	a.RecordGitOps(testRef, 0)
+
+	s, ok := a.Summary(testRef)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(s.Denied).To(Equal(3))
+	g.Expect(s.DeniedByUser).To(Equal([]freezev1alpha1.RequestCount{{Name: "alice", Count: 2}, {Name: "bob", Count: 1}}))
+	g.Expect(s.DeniedByNamespace).To(Equal([]freezev1alpha1.RequestCount{{Name: "prod", Count: 2}, {Name: "staging", Count: 1}}))
+	g.Expect(s.DeniedByAction).To(Equal([]freezev1alpha1.RequestCount{{Name: string(freezev1alpha1.ActionRollout), Count: 2}, {Name: string(freezev1alpha1.ActionScale), Count: 1}}))
+	g.Expect(s.Exceptions).To(Equal([]freezev1alpha1.ExceptionUse{{Exception: "ex-hotfix", User: "alice", Count: 2}}))
+	g.Expect(s.BreakGlassOverrides).To(Equal(1))
+	g.Expect(s.BreakGlass).To(HaveLen(1))
+	g.Expect(s.CronJobsSuspended).To(Equal(3))
+	g.Expect(s.GitopsPaused).To(Equal(2))
+	g.Expect(s.GitopsResumed).To(Equal(2))
+
+	a.Forget(testRef)
+	_, ok = a.Summary(testRef)
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestAggregator_SideEffectsNeedAnOpenOccurrence(t *testing.T) {
+	g := NewWithT(t)
+	a := &Aggregator{}
+
+	a.RecordCronJobs(testRef, 3)
+	a.RecordGitOps(testRef, 2)
+	_, ok := a.Summary(testRef)
+	g.Expect(ok).To(BeFalse(), "side effects must not open an occurrence")
+}
+
+func TestAggregator_BeginNewStartDropsPreviousOccurrence(t *testing.T) {
+	g := NewWithT(t)
+	a := &Aggregator{}
+	start := time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC)
+
+	a.Begin(testRef, start)
+	a.RecordDenied(testRef, "alice", "prod", freezev1alpha1.ActionRollout)
+	a.RecordCronJobs(testRef, 3)
+
+	// The freeze was rescheduled.
+	a.Begin(testRef, start.Add(24*time.Hour))
+	got, _ := a.Started(testRef)
+	g.Expect(got).To(Equal(start.Add(24 * time.Hour)))
+	s, ok := a.Summary(testRef)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(s.Denied).To(BeZero())
+	g.Expect(s.CronJobsSuspended).To(BeZero())
+}
+
+func TestAggregator_IgnoresUntrackedPolicies(t *testing.T) {
+	g := NewWithT(t)
+	a := &Aggregator{}
+	ref := freezev1alpha1.PolicyReference{Kind: "RolloutLimit", Name: "limit"}
+
+	a.RecordDenied(ref, "alice", "prod", freezev1alpha1.ActionRollout)
+	_, ok := a.Summary(ref)
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestAggregator_CapsBreakdowns(t *testing.T) {
+	g := NewWithT(t)
+	a := &Aggregator{}
+	a.Begin(testRef, time.Now())
+	for i := range SummarySize + 10 {
+		a.RecordDenied(testRef, fmt.Sprintf("user-%03d", i), "prod", freezev1alpha1.ActionRollout)
+		a.RecordBreakGlass(testRef, freezev1alpha1.BreakGlassUse{User: "oncall"})
+	}
+
+	s, _ := a.Summary(testRef)
+	g.Expect(s.Denied).To(Equal(SummarySize + 10))
+	g.Expect(s.DeniedByUser).To(HaveLen(SummarySize))
+	g.Expect(s.BreakGlassOverrides).To(Equal(SummarySize + 10))
+	g.Expect(s.BreakGlass).To(HaveLen(SummarySize))
+}
+
+func TestAggregator_NilIsNoop(t *testing.T) {
+	var a *Aggregator
+	a.Begin(testRef, time.Now())
+	a.RecordDenied(testRef, "alice", "prod", freezev1alpha1.ActionRollout)
+	if _, ok := a.Summary(testRef); ok {
+		t.Error("expected no summary from a nil aggregator")
+	}
+}
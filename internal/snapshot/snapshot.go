@@ -0,0 +1,356 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package snapshot captures the state of the workloads a freeze covers and
+// compares two captures, so that the operator can report what drifted while a
+// freeze was active.
+//
+// A workload is captured as a hash of its pod template, its desired replicas
+// and the images of its containers, with the digests its running pods report.
+// Workloads and pods are read in full, so callers should pass an uncached
+// reader.
+package snapshot
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/policy"
+)
+
+const (
+	// DriftSize is the number of drifted workloads listed in a FreezeReport.
+	DriftSize = 200
+
+	// SnapshotSize is the number of workloads kept in a FreezeSnapshot, so that
+	// it stays well below the object size etcd accepts.
+	SnapshotSize = 1000
+)
+
+// Capture is the state of the workloads a target selects.
+type Capture struct {
+	// Workloads lists the captured workloads, sorted by namespace, kind and name.
+	Workloads []freezev1alpha1.WorkloadSnapshot
+
+	// managers holds, per workload, the field managers that wrote it at or after
+	// the time passed to Take.
+	managers map[key][]string
+}
+
+type key struct {
+	kind      freezev1alpha1.TargetKind
+	namespace string
+	name      string
+}
+
+func keyOf(w freezev1alpha1.WorkloadSnapshot) key {
+	return key{kind: w.Kind, namespace: w.Namespace, name: w.Name}
+}
+
+// workload is the part of a workload object that is captured.
+type workload struct {
+	meta     metav1.Object
+	template *corev1.PodTemplateSpec
+	replicas *int32
+	selector *metav1.LabelSelector
+}
+
+// Take captures the workloads selected by target. Field managers that wrote a
+// workload at or after since are recorded for Diff; pass the zero time when they
+// are not needed.
+func Take(ctx context.Context, c client.Reader, target *freezev1alpha1.TargetSpec, since time.Time) (*Capture, error) {
+	capture := &Capture{managers: make(map[key][]string)}
+	if target == nil {
+		return capture, nil
+	}
+
+	var nsList corev1.NamespaceList
+	if err := c.List(ctx, &nsList); err != nil {
+		return nil, fmt.Errorf("list namespaces: %w", err)
+	}
+	nsLabels := make(map[string]map[string]string, len(nsList.Items))
+	for _, ns := range nsList.Items {
+		if policy.NamespaceMatches(target, ns.Labels) {
+			nsLabels[ns.Name] = ns.Labels
+		}
+	}
+
+	pods := make(map[string][]corev1.Pod)
+	for _, kind := range target.Kinds {
+		workloads, err := list(ctx, c, kind)
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range workloads {
+			ns := w.meta.GetNamespace()
+			nsl, ok := nsLabels[ns]
+			if !ok || !policy.TargetMatches(target, nsl, w.meta.GetLabels(), kind) {
+				continue
+			}
+			if _, listed := pods[ns]; !listed && w.selector != nil {
+				var podList corev1.PodList
+				if err := c.List(ctx, &podList, client.InNamespace(ns)); err != nil {
+					return nil, fmt.Errorf("list pods in %s: %w", ns, err)
+				}
+				pods[ns] = podList.Items
+			}
+
+			snap, err := snapshotOf(kind, w, pods[ns])
+			if err != nil {
+				return nil, err
+			}
+			capture.Workloads = append(capture.Workloads, snap)
+			if !since.IsZero() {
+				capture.managers[keyOf(snap)] = managersSince(w.meta, since)
+			}
+		}
+	}
+
+	slices.SortFunc(capture.Workloads, func(a, b freezev1alpha1.WorkloadSnapshot) int {
+		return compareKeys(keyOf(a), keyOf(b))
+	})
+	return capture, nil
+}
+
+// Truncate keeps the first n workloads of the capture. It reports whether any
+// were dropped.
+func (c *Capture) Truncate(n int) bool {
+	if len(c.Workloads) <= n {
+		return false
+	}
+	c.Workloads = c.Workloads[:n]
+	return true
+}
+
+// Within drops the workloads that sort after the last of workloads, so that the
+// capture can be compared with a truncated one: workloads past the cut were not
+// captured before and would otherwise be reported as added.
+func (c *Capture) Within(workloads []freezev1alpha1.WorkloadSnapshot) {
+	if len(workloads) == 0 {
+		c.Workloads = nil
+		return
+	}
+	last := keyOf(workloads[len(workloads)-1])
+	c.Workloads = slices.DeleteFunc(c.Workloads, func(w freezev1alpha1.WorkloadSnapshot) bool {
+		return compareKeys(keyOf(w), last) > 0
+	})
+}
+
+// list returns the workloads of kind in all namespaces.
+func list(ctx context.Context, c client.Reader, kind freezev1alpha1.TargetKind) ([]workload, error) {
+	var out []workload
+	switch kind {
+	case freezev1alpha1.TargetKindDeployment:
+		var l appsv1.DeploymentList
+		if err := c.List(ctx, &l); err != nil {
+			return nil, fmt.Errorf("list deployments: %w", err)
+		}
+		for i := range l.Items {
+			d := &l.Items[i]
+			out = append(out, workload{meta: d, template: &d.Spec.Template, replicas: d.Spec.Replicas, selector: d.Spec.Selector})
+		}
+	case freezev1alpha1.TargetKindStatefulSet:
+		var l appsv1.StatefulSetList
+		if err := c.List(ctx, &l); err != nil {
+			return nil, fmt.Errorf("list statefulsets: %w", err)
+		}
+		for i := range l.Items {
+			s := &l.Items[i]
+			out = append(out, workload{meta: s, template: &s.Spec.Template, replicas: s.Spec.Replicas, selector: s.Spec.Selector})
+		}
+	case freezev1alpha1.TargetKindDaemonSet:
+		var l appsv1.DaemonSetList
+		if err := c.List(ctx, &l); err != nil {
+			return nil, fmt.Errorf("list daemonsets: %w", err)
+		}
+		for i := range l.Items {
+			d := &l.Items[i]
+			out = append(out, workload{meta: d, template: &d.Spec.Template, selector: d.Spec.Selector})
+		}
+	case freezev1alpha1.TargetKindCronJob:
+		var l batchv1.CronJobList
+		if err := c.List(ctx, &l); err != nil {
+			return nil, fmt.Errorf("list cronjobs: %w", err)
+		}
+		for i := range l.Items {
+			cj := &l.Items[i]
+			out = append(out, workload{meta: cj, template: &cj.Spec.JobTemplate.Spec.Template})
+		}
+	}
+	return out, nil
+}
+
+// snapshotOf captures a single workload. Image digests are taken from the pods
+// in its namespace that its selector matches.
+func snapshotOf(kind freezev1alpha1.TargetKind, w workload, pods []corev1.Pod) (freezev1alpha1.WorkloadSnapshot, error) {
+	snap := freezev1alpha1.WorkloadSnapshot{
+		Kind:      kind,
+		Namespace: w.meta.GetNamespace(),
+		Name:      w.meta.GetName(),
+	}
+	if w.replicas != nil {
+		replicas := *w.replicas
+		snap.Replicas = &replicas
+	}
+
+	raw, err := json.Marshal(w.template)
+	if err != nil {
+		return snap, fmt.Errorf("hash template of %s %s/%s: %w", kind, snap.Namespace, snap.Name, err)
+	}
+	sum := sha256.Sum256(raw)
+	snap.TemplateHash = hex.EncodeToString(sum[:8])
+
+	digests := make(map[string][]string)
+	if w.selector != nil {
+		if sel, err := metav1.LabelSelectorAsSelector(w.selector); err == nil && !sel.Empty() {
+			for _, pod := range pods {
+				if !sel.Matches(labels.Set(pod.Labels)) {
+					continue
+				}
+				for _, cs := range pod.Status.ContainerStatuses {
+					if cs.ImageID != "" && !slices.Contains(digests[cs.Name], cs.ImageID) {
+						digests[cs.Name] = append(digests[cs.Name], cs.ImageID)
+					}
+				}
+			}
+		}
+	}
+
+	for _, ctr := range w.template.Spec.Containers {
+		d := digests[ctr.Name]
+		slices.Sort(d)
+		snap.Images = append(snap.Images, freezev1alpha1.ContainerImage{Container: ctr.Name, Image: ctr.Image, Digests: d})
+	}
+	return snap, nil
+}
+
+// managersSince returns the field managers whose entries were written at or
+// after since.
+func managersSince(obj metav1.Object, since time.Time) []string {
+	var out []string
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Time == nil || entry.Time.Time.Before(since) || slices.Contains(out, entry.Manager) {
+			continue
+		}
+		out = append(out, entry.Manager)
+	}
+	slices.Sort(out)
+	return out
+}
+
+// Diff compares the workloads captured when a freeze started with those
+// captured at its end. It returns every drifted workload, sorted by namespace,
+// kind and name.
+func Diff(before []freezev1alpha1.WorkloadSnapshot, after *Capture) []freezev1alpha1.WorkloadDrift {
+	prev := make(map[key]freezev1alpha1.WorkloadSnapshot, len(before))
+	for _, w := range before {
+		prev[keyOf(w)] = w
+	}
+
+	var out []freezev1alpha1.WorkloadDrift
+	for _, w := range after.Workloads {
+		k := keyOf(w)
+		old, ok := prev[k]
+		delete(prev, k)
+
+		drift := freezev1alpha1.WorkloadDrift{Kind: w.Kind, Namespace: w.Namespace, Name: w.Name}
+		if !ok {
+			drift.Type = freezev1alpha1.DriftTypeAdded
+		} else if drift.Changes = changes(old, w); len(drift.Changes) > 0 {
+			drift.Type = freezev1alpha1.DriftTypeModified
+		} else {
+			continue
+		}
+		drift.Managers = after.managers[k]
+		out = append(out, drift)
+	}
+
+	for _, w := range prev {
+		out = append(out, freezev1alpha1.WorkloadDrift{
+			Kind:      w.Kind,
+			Namespace: w.Namespace,
+			Name:      w.Name,
+			Type:      freezev1alpha1.DriftTypeRemoved,
+		})
+	}
+
+	slices.SortFunc(out, func(a, b freezev1alpha1.WorkloadDrift) int {
+		return compareKeys(
+			key{kind: a.Kind, namespace: a.Namespace, name: a.Name},
+			key{kind: b.Kind, namespace: b.Namespace, name: b.Name},
+		)
+	})
+	return out
+}
+
+// changes describes how a workload differs between two captures. Digests are
+// only compared while pods report them, so scaling to zero is not reported as
+// an image change.
+func changes(old, cur freezev1alpha1.WorkloadSnapshot) []string {
+	var out []string
+	if old.TemplateHash != cur.TemplateHash {
+		out = append(out, "template changed")
+	}
+	if r0, r1 := old.Replicas, cur.Replicas; r0 != nil && r1 != nil && *r0 != *r1 {
+		out = append(out, fmt.Sprintf("replicas: %d -> %d", *r0, *r1))
+	}
+
+	images := make(map[string]freezev1alpha1.ContainerImage, len(old.Images))
+	for _, img := range old.Images {
+		images[img.Container] = img
+	}
+	for _, img := range cur.Images {
+		was, ok := images[img.Container]
+		delete(images, img.Container)
+		switch {
+		case !ok:
+			out = append(out, fmt.Sprintf("container %s added: %s", img.Container, img.Image))
+		case was.Image != img.Image:
+			out = append(out, fmt.Sprintf("image %s: %s -> %s", img.Container, was.Image, img.Image))
+		case len(was.Digests) > 0 && len(img.Digests) > 0 && !slices.Equal(was.Digests, img.Digests):
+			out = append(out, fmt.Sprintf("digest %s: %v -> %v", img.Container, was.Digests, img.Digests))
+		}
+	}
+	for _, img := range old.Images {
+		if _, removed := images[img.Container]; removed {
+			out = append(out, fmt.Sprintf("container %s removed", img.Container))
+		}
+	}
+	return out
+}
+
+// compareKeys orders workloads by namespace, kind and name.
+func compareKeys(a, b key) int {
+	return cmp.Or(
+		cmp.Compare(a.namespace, b.namespace),
+		cmp.Compare(a.kind, b.kind),
+		cmp.Compare(a.name, b.name),
+	)
+}
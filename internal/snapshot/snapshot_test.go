@@ -0,0 +1,238 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+)
+
+func buildFakeClient(objs ...client.Object) client.Client {
+	s := runtime.NewScheme()
+	_ = corev1.AddToScheme(s)
+	_ = appsv1.AddToScheme(s)
+	_ = batchv1.AddToScheme(s)
+	return fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).WithReturnManagedFields().Build()
+}
+
+func newNamespace(name, env string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"env": env}}}
+}
+
+func newDeployment(namespace, name, image string, replicas int32) *appsv1.Deployment {
+	labels := map[string]string{"app": name}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(replicas),
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: image}}},
+			},
+		},
+	}
+}
+
+func newPod(namespace, name, app, imageID string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{"app": app}},
+		Status:     corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{Name: "app", ImageID: imageID}}},
+	}
+}
+
+func managedBy(manager string, at time.Time) metav1.ManagedFieldsEntry {
+	return metav1.ManagedFieldsEntry{
+		Manager:    manager,
+		Operation:  metav1.ManagedFieldsOperationUpdate,
+		APIVersion: "apps/v1",
+		Time:       ptr.To(metav1.NewTime(at)),
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:spec":{}}`)},
+	}
+}
+
+func prodTarget(kinds ...freezev1alpha1.TargetKind) *freezev1alpha1.TargetSpec {
+	return &freezev1alpha1.TargetSpec{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+		Kinds:             kinds,
+	}
+}
+
+func TestTake_CapturesTargetedWorkloads(t *testing.T) {
+	c := buildFakeClient(
+		newNamespace("prod", "prod"),
+		newNamespace("dev", "dev"),
+		newDeployment("prod", "api", "api:1.0", 3),
+		newDeployment("dev", "api", "api:1.0", 1),
+		newPod("prod", "api-1", "api", "registry/api@sha256:aaa"),
+		newPod("prod", "other", "web", "registry/web@sha256:bbb"),
+	)
+
+	capture, err := Take(context.Background(), c, prodTarget(freezev1alpha1.TargetKindDeployment), time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(capture.Workloads) != 1 {
+		t.Fatalf("expected one workload, got %+v", capture.Workloads)
+	}
+	w := capture.Workloads[0]
+	if w.Namespace != "prod" || w.Name != "api" || w.TemplateHash == "" || w.Replicas == nil || *w.Replicas != 3 {
+		t.Errorf("unexpected snapshot %+v", w)
+	}
+	want := []freezev1alpha1.ContainerImage{{Container: "app", Image: "api:1.0", Digests: []string{"registry/api@sha256:aaa"}}}
+	if !reflect.DeepEqual(w.Images, want) {
+		t.Errorf("expected images %+v, got %+v", want, w.Images)
+	}
+}
+
+func TestDiff_ReportsDrift(t *testing.T) {
+	ctx := context.Background()
+	target := prodTarget(freezev1alpha1.TargetKindDeployment)
+	start := time.Now().Add(-time.Hour)
+
+	before, err := Take(ctx, buildFakeClient(
+		newNamespace("prod", "prod"),
+		newDeployment("prod", "api", "api:1.0", 3),
+		newDeployment("prod", "web", "web:1.0", 2),
+		newDeployment("prod", "worker", "worker:1.0", 1),
+	), target, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	api := newDeployment("prod", "api", "api:1.1", 3)
+	api.ManagedFields = []metav1.ManagedFieldsEntry{
+		managedBy("kubectl-edit", time.Now()),
+		managedBy("helm", start.Add(-time.Hour)),
+	}
+	after, err := Take(ctx, buildFakeClient(
+		newNamespace("prod", "prod"),
+		api,
+		newDeployment("prod", "web", "web:1.0", 2),
+		newDeployment("prod", "cache", "cache:1.0", 1),
+	), target, start)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []freezev1alpha1.WorkloadDrift{
+		{
+			Kind: freezev1alpha1.TargetKindDeployment, Namespace: "prod", Name: "api",
+			Type:     freezev1alpha1.DriftTypeModified,
+			Changes:  []string{"template changed", "image app: api:1.0 -> api:1.1"},
+			Managers: []string{"kubectl-edit"},
+		},
+		{Kind: freezev1alpha1.TargetKindDeployment, Namespace: "prod", Name: "cache", Type: freezev1alpha1.DriftTypeAdded},
+		{Kind: freezev1alpha1.TargetKindDeployment, Namespace: "prod", Name: "worker", Type: freezev1alpha1.DriftTypeRemoved},
+	}
+	if got := Diff(before.Workloads, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected drift\n%+v\ngot\n%+v", want, got)
+	}
+}
+
+func TestChanges(t *testing.T) {
+	base := freezev1alpha1.WorkloadSnapshot{
+		TemplateHash: "abc",
+		Replicas:     ptr.To[int32](3),
+		Images:       []freezev1alpha1.ContainerImage{{Container: "app", Image: "api:1.0", Digests: []string{"sha256:a"}}},
+	}
+
+	tests := []struct {
+		name   string
+		modify func(w *freezev1alpha1.WorkloadSnapshot)
+		want   []string
+	}{
+		{"unchanged", func(*freezev1alpha1.WorkloadSnapshot) {}, nil},
+		{"scaled", func(w *freezev1alpha1.WorkloadSnapshot) { w.Replicas = ptr.To[int32](5) }, []string{"replicas: 3 -> 5"}},
+		{"retagged", func(w *freezev1alpha1.WorkloadSnapshot) {
+			w.Images = []freezev1alpha1.ContainerImage{{Container: "app", Image: "api:1.0", Digests: []string{"sha256:b"}}}
+		}, []string{"digest app: [sha256:a] -> [sha256:b]"}},
+		{"scaled to zero", func(w *freezev1alpha1.WorkloadSnapshot) {
+			w.Images = []freezev1alpha1.ContainerImage{{Container: "app", Image: "api:1.0"}}
+		}, nil},
+		{"container removed", func(w *freezev1alpha1.WorkloadSnapshot) { w.Images = nil }, []string{"container app removed"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cur := base
+			tt.modify(&cur)
+			if got := changes(base, cur); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTruncate_DiffCoversCapturedWorkloads(t *testing.T) {
+	ctx := context.Background()
+	target := prodTarget(freezev1alpha1.TargetKindDeployment)
+
+	before, err := Take(ctx, buildFakeClient(
+		newNamespace("prod", "prod"),
+		newDeployment("prod", "api", "api:1.0", 3),
+		newDeployment("prod", "web", "web:1.0", 2),
+		newDeployment("prod", "worker", "worker:1.0", 1),
+	), target, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if before.Truncate(3) {
+		t.Error("a capture within the limit should not be truncated")
+	}
+	if !before.Truncate(2) || len(before.Workloads) != 2 {
+		t.Fatalf("expected the capture truncated to 2 workloads, got %+v", before.Workloads)
+	}
+
+	after, err := Take(ctx, buildFakeClient(
+		newNamespace("prod", "prod"),
+		newDeployment("prod", "api", "api:1.0", 3),
+		newDeployment("prod", "cache", "cache:1.0", 1),
+		newDeployment("prod", "web", "web:1.0", 5),
+		newDeployment("prod", "worker", "worker:1.1", 1),
+	), target, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	after.Within(before.Workloads)
+
+	// worker was past the cut, so its change is not reported.
+	want := []freezev1alpha1.WorkloadDrift{
+		{Kind: freezev1alpha1.TargetKindDeployment, Namespace: "prod", Name: "cache", Type: freezev1alpha1.DriftTypeAdded},
+		{
+			Kind: freezev1alpha1.TargetKindDeployment, Namespace: "prod", Name: "web",
+			Type:    freezev1alpha1.DriftTypeModified,
+			Changes: []string{"replicas: 2 -> 5"},
+		},
+	}
+	if got := Diff(before.Workloads, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected drift\n%+v\ngot\n%+v", want, got)
+	}
+}
@@ -0,0 +1,117 @@
+package namespaces
+
+// +kubebuilder:rbac:groups=freeze-operator.io,resources=maintenancewindows,verbs=get;list;watch
+// +kubebuilder:rbac:groups=freeze-operator.io,resources=changefreezes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=freeze-operator.io,resources=freezeexceptions,verbs=get;list;watch
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/metrics"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/policy"
+)
+
+const WebhookPath = "/validate-freeze-operator-io-v1alpha1-namespaces"
+
+// Validator denies Namespace label changes that would move the namespace in or out
+// of the scope of an enforcing ChangeFreeze or MaintenanceWindow.
+type Validator struct {
+	Client  client.Client
+	Decoder admission.Decoder
+
+	OperatorNamespace string
+
+	// RequireApprovedExceptions and MinApprovals are passed to the policy evaluator.
+	RequireApprovedExceptions bool
+	MinApprovals              int32
+}
+
+func (v *Validator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	log := ctrl.Log.WithName("webhook").WithName("namespaces")
+
+	if v.Client == nil {
+		return admission.Errored(500, fmt.Errorf("client is nil"))
+	}
+	if v.Decoder == nil {
+		return admission.Errored(500, fmt.Errorf("decoder is nil"))
+	}
+	if req.Operation != admissionv1.Update {
+		return admission.Allowed("operation not enforced")
+	}
+
+	opNs := v.OperatorNamespace
+	if opNs == "" {
+		opNs = os.Getenv("POD_NAMESPACE")
+	}
+	if opNs != "" && slices.Contains(req.UserInfo.Groups, "system:serviceaccounts:"+opNs) {
+		return admission.Allowed("operator serviceaccount bypass")
+	}
+
+	oldNs := &corev1.Namespace{}
+	if err := v.Decoder.DecodeRaw(req.OldObject, oldNs); err != nil {
+		return admission.Errored(400, err)
+	}
+	newNs := &corev1.Namespace{}
+	if err := v.Decoder.DecodeRaw(req.Object, newNs); err != nil {
+		return admission.Errored(400, err)
+	}
+
+	if maps.Equal(oldNs.Labels, newNs.Labels) {
+		return admission.Allowed("labels unchanged")
+	}
+	// Finalizing a terminating namespace must never be blocked.
+	if oldNs.DeletionTimestamp != nil {
+		return admission.Allowed("namespace is terminating: bypass freeze policies")
+	}
+
+	ev := &policy.Evaluator{Client: v.Client, RequireApprovedExceptions: v.RequireApprovedExceptions, MinApprovals: v.MinApprovals}
+	dec, err := ev.EvaluateNamespaceChange(ctx, policy.NamespaceChangeInput{
+		Now:       time.Now().UTC(),
+		Namespace: newNs.Name,
+		OldLabels: oldNs.Labels,
+		NewLabels: newNs.Labels,
+		Username:  req.UserInfo.Username,
+		Groups:    req.UserInfo.Groups,
+	})
+	if err != nil {
+		return admission.Errored(500, err)
+	}
+
+	for i, o := range dec.MatchedOverrides {
+		p := dec.Overridden[i]
+		metrics.ExceptionOverrides.WithLabelValues(o.Name, string(p.Kind), p.Name).Inc()
+	}
+
+	if dec.Allowed {
+		return admission.Allowed("allowed by policy")
+	}
+
+	for _, p := range dec.AffectedPolicies {
+		metrics.DeniedRequests.WithLabelValues(string(p.Kind), p.Name, newNs.Name, "Namespace", string(req.Operation)).Inc()
+	}
+
+	msg := formatDenyMessage(dec)
+	log.Info("denied", "namespace", newNs.Name, "user", req.UserInfo.Username, "policies", dec.AffectedPolicies)
+	return admission.Denied(msg)
+}
+
+func formatDenyMessage(dec policy.NamespaceChangeDecision) string {
+	names := make([]string, 0, len(dec.AffectedPolicies))
+	for _, p := range dec.AffectedPolicies {
+		names = append(names, fmt.Sprintf("%s/%s", p.Kind, p.Name))
+	}
+	return fmt.Sprintf("Denied: namespace label change would change the scope of active policies: %s",
+		strings.Join(names, ", "))
+}
@@ -0,0 +1,187 @@
+package namespaces
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+)
+
+// ---------------------------------------------------------------------------
+// helpers
+// ---------------------------------------------------------------------------
+
+func buildValidator(t *testing.T, objs ...runtime.Object) *Validator {
+	t.Helper()
+	s := runtime.NewScheme()
+	if err := corev1.AddToScheme(s); err != nil {
+		t.Fatalf("scheme setup: %v", err)
+	}
+	if err := freezev1alpha1.AddToScheme(s); err != nil {
+		t.Fatalf("scheme setup: %v", err)
+	}
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).Build()
+	return &Validator{
+		Client:            cl,
+		Decoder:           admission.NewDecoder(s),
+		OperatorNamespace: "kube-system",
+	}
+}
+
+func makeNamespace(lbls map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Labels: lbls},
+	}
+}
+
+func makeUpdateRequest(t *testing.T, oldNs, newNs *corev1.Namespace, username string) admission.Request {
+	t.Helper()
+	oldRaw, err := json.Marshal(oldNs)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	newRaw, err := json.Marshal(newNs)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		UID:       "uid-ns-update",
+		Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "Namespace"},
+		Resource:  metav1.GroupVersionResource{Version: "v1", Resource: "namespaces"},
+		Operation: admissionv1.Update,
+		Name:      newNs.Name,
+		OldObject: runtime.RawExtension{Raw: oldRaw},
+		Object:    runtime.RawExtension{Raw: newRaw},
+		UserInfo:  authv1.UserInfo{Username: username, Groups: []string{"system:authenticated"}},
+	}}
+}
+
+func activeChangeFreeze(name string) *freezev1alpha1.ChangeFreeze {
+	now := time.Now().UTC()
+	return &freezev1alpha1.ChangeFreeze{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: freezev1alpha1.ChangeFreezeSpec{
+			StartTime: metav1.Time{Time: now.Add(-time.Hour)},
+			EndTime:   metav1.Time{Time: now.Add(time.Hour)},
+			Target: freezev1alpha1.TargetSpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				Kinds:             []freezev1alpha1.TargetKind{freezev1alpha1.TargetKindDeployment},
+			},
+			Rules: freezev1alpha1.PolicyRulesSpec{Deny: []freezev1alpha1.Action{freezev1alpha1.ActionRollout}},
+		},
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Tests
+// ---------------------------------------------------------------------------
+
+func TestValidator_RemoveSelectedLabel_Denied(t *testing.T) {
+	g := NewWithT(t)
+	v := buildValidator(t, activeChangeFreeze("holiday"))
+
+	req := makeUpdateRequest(t, makeNamespace(map[string]string{"env": "prod"}), makeNamespace(map[string]string{}), "alice")
+	resp := v.Handle(context.Background(), req)
+	g.Expect(resp.Allowed).To(BeFalse())
+	g.Expect(resp.Result.Message).To(ContainSubstring("ChangeFreeze/holiday"))
+}
+
+func TestValidator_AddSelectedLabel_Denied(t *testing.T) {
+	g := NewWithT(t)
+	v := buildValidator(t, activeChangeFreeze("holiday"))
+
+	req := makeUpdateRequest(t, makeNamespace(map[string]string{"env": "dev"}), makeNamespace(map[string]string{"env": "prod"}), "alice")
+	resp := v.Handle(context.Background(), req)
+	g.Expect(resp.Allowed).To(BeFalse())
+}
+
+func TestValidator_UnrelatedLabelChange_Allowed(t *testing.T) {
+	g := NewWithT(t)
+	v := buildValidator(t, activeChangeFreeze("holiday"))
+
+	req := makeUpdateRequest(t,
+		makeNamespace(map[string]string{"env": "prod"}),
+		makeNamespace(map[string]string{"env": "prod", "team": "payments"}), "alice")
+	resp := v.Handle(context.Background(), req)
+	g.Expect(resp.Allowed).To(BeTrue(), "label change that keeps the match must be allowed: %s", resp.Result.Message)
+}
+
+func TestValidator_InactiveFreeze_Allowed(t *testing.T) {
+	g := NewWithT(t)
+	cf := activeChangeFreeze("later")
+	cf.Spec.StartTime = metav1.Time{Time: time.Now().Add(time.Hour)}
+	cf.Spec.EndTime = metav1.Time{Time: time.Now().Add(2 * time.Hour)}
+	v := buildValidator(t, cf)
+
+	req := makeUpdateRequest(t, makeNamespace(map[string]string{"env": "prod"}), makeNamespace(nil), "alice")
+	resp := v.Handle(context.Background(), req)
+	g.Expect(resp.Allowed).To(BeTrue(), "freeze not started, relabel must be allowed: %s", resp.Result.Message)
+}
+
+func TestValidator_ExceptionCoveringPolicy_Allowed(t *testing.T) {
+	g := NewWithT(t)
+	now := time.Now().UTC()
+	ex := &freezev1alpha1.FreezeException{
+		ObjectMeta: metav1.ObjectMeta{Name: "ex-relabel"},
+		Spec: freezev1alpha1.FreezeExceptionSpec{
+			ActiveFrom: metav1.Time{Time: now.Add(-time.Minute)},
+			ActiveTo:   metav1.Time{Time: now.Add(time.Minute)},
+			Target: &freezev1alpha1.TargetSpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				Kinds:             []freezev1alpha1.TargetKind{freezev1alpha1.TargetKindDeployment},
+			},
+			Allow:  []freezev1alpha1.Action{freezev1alpha1.ActionRollout},
+			Reason: "decommission",
+		},
+	}
+	v := buildValidator(t, activeChangeFreeze("holiday"), ex)
+
+	req := makeUpdateRequest(t, makeNamespace(map[string]string{"env": "prod"}), makeNamespace(nil), "alice")
+	resp := v.Handle(context.Background(), req)
+	g.Expect(resp.Allowed).To(BeTrue(), "exception must override: %s", resp.Result.Message)
+}
+
+func TestValidator_ExceptionMissingDeniedAction_Denied(t *testing.T) {
+	g := NewWithT(t)
+	now := time.Now().UTC()
+	ex := &freezev1alpha1.FreezeException{
+		ObjectMeta: metav1.ObjectMeta{Name: "ex-scale"},
+		Spec: freezev1alpha1.FreezeExceptionSpec{
+			ActiveFrom: metav1.Time{Time: now.Add(-time.Minute)},
+			ActiveTo:   metav1.Time{Time: now.Add(time.Minute)},
+			Target: &freezev1alpha1.TargetSpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				Kinds:             []freezev1alpha1.TargetKind{freezev1alpha1.TargetKindDeployment},
+			},
+			Allow:  []freezev1alpha1.Action{freezev1alpha1.ActionScale},
+			Reason: "scale only",
+		},
+	}
+	v := buildValidator(t, activeChangeFreeze("holiday"), ex)
+
+	req := makeUpdateRequest(t, makeNamespace(map[string]string{"env": "prod"}), makeNamespace(nil), "alice")
+	resp := v.Handle(context.Background(), req)
+	g.Expect(resp.Allowed).To(BeFalse(), "exception that does not allow ROLL_OUT must not cover the relabel")
+}
+
+func TestValidator_OperatorServiceAccount_Bypassed(t *testing.T) {
+	g := NewWithT(t)
+	v := buildValidator(t, activeChangeFreeze("holiday"))
+
+	req := makeUpdateRequest(t, makeNamespace(map[string]string{"env": "prod"}), makeNamespace(nil), "operator")
+	req.UserInfo.Groups = []string{"system:serviceaccounts:kube-system"}
+	resp := v.Handle(context.Background(), req)
+	g.Expect(resp.Allowed).To(BeTrue())
+}
@@ -22,10 +22,12 @@ import (
 	"time"
 
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/policy"
 )
 
 // nolint:unused
@@ -33,48 +35,72 @@ import (
 var changefreezeLog = logf.Log.WithName("changefreeze-resource")
 
 // SetupChangeFreezeWebhookWithManager registers the webhook for ChangeFreeze in the manager.
-func SetupChangeFreezeWebhookWithManager(mgr ctrl.Manager) error {
+func SetupChangeFreezeWebhookWithManager(mgr ctrl.Manager, tamper TamperProtection) error {
 	return ctrl.NewWebhookManagedBy(mgr, &freezeoperatorv1alpha1.ChangeFreeze{}).
-		WithValidator(&ChangeFreezeCustomValidator{}).
+		WithValidator(&ChangeFreezeCustomValidator{TamperProtection: tamper, Client: mgr.GetClient()}).
 		Complete()
 }
 
-// +kubebuilder:webhook:path=/validate-freeze-operator-io-v1alpha1-changefreeze,mutating=false,failurePolicy=fail,sideEffects=None,groups=freeze-operator.io,resources=changefreezes,verbs=create;update,versions=v1alpha1,name=vchangefreeze-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-freeze-operator-io-v1alpha1-changefreeze,mutating=false,failurePolicy=fail,sideEffects=None,groups=freeze-operator.io,resources=changefreezes,verbs=create;update;delete,versions=v1alpha1,name=vchangefreeze-v1alpha1.kb.io,admissionReviewVersions=v1
 
 // ChangeFreezeCustomValidator struct is responsible for validating the ChangeFreeze resource
 // when it is created, updated, or deleted.
 //
 // NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
 // as this struct is used only for temporary operations and does not need to be deeply copied.
-type ChangeFreezeCustomValidator struct{}
+type ChangeFreezeCustomValidator struct {
+	TamperProtection TamperProtection
+
+	// Client creates the SubjectAccessReviews that authorize hook jobTemplateRefs.
+	Client client.Client
+}
 
 // ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type ChangeFreeze.
-func (v *ChangeFreezeCustomValidator) ValidateCreate(_ context.Context, obj *freezeoperatorv1alpha1.ChangeFreeze) (admission.Warnings, error) {
+func (v *ChangeFreezeCustomValidator) ValidateCreate(ctx context.Context, obj *freezeoperatorv1alpha1.ChangeFreeze) (admission.Warnings, error) {
 	changefreezeLog.Info("Validation for ChangeFreeze upon creation", "name", obj.GetName())
 
 	if err := v.validateChangeFreeze(obj); err != nil {
 		return nil, err
 	}
+	if err := authorizeHooks(ctx, v.Client, obj.Spec.Behavior.Hooks, nil); err != nil {
+		return nil, err
+	}
 
 	return nil, nil
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type ChangeFreeze.
-func (v *ChangeFreezeCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj *freezeoperatorv1alpha1.ChangeFreeze) (admission.Warnings, error) {
+func (v *ChangeFreezeCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj *freezeoperatorv1alpha1.ChangeFreeze) (admission.Warnings, error) {
 	changefreezeLog.Info("Validation for ChangeFreeze upon update", "name", newObj.GetName())
 
 	if err := v.validateChangeFreeze(newObj); err != nil {
 		return nil, err
 	}
+	if err := authorizeHooks(ctx, v.Client, newObj.Spec.Behavior.Hooks, oldObj.Spec.Behavior.Hooks); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if policy.ChangeFreezeActive(oldObj, now) {
+		violations := changeFreezeWeakening(oldObj, newObj, now)
+		if err := v.TamperProtection.guard(ctx, oldObj, "ChangeFreeze", oldObj.Name, "update", violations); err != nil {
+			return nil, err
+		}
+	}
 
 	return nil, nil
 }
 
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type ChangeFreeze.
-func (v *ChangeFreezeCustomValidator) ValidateDelete(_ context.Context, obj *freezeoperatorv1alpha1.ChangeFreeze) (admission.Warnings, error) {
+func (v *ChangeFreezeCustomValidator) ValidateDelete(ctx context.Context, obj *freezeoperatorv1alpha1.ChangeFreeze) (admission.Warnings, error) {
 	changefreezeLog.Info("Validation for ChangeFreeze upon deletion", "name", obj.GetName())
 
-	// No validation needed for deletion
+	if policy.ChangeFreezeActive(obj, time.Now().UTC()) {
+		if err := v.TamperProtection.guard(ctx, obj, "ChangeFreeze", obj.Name, "delete", []string{"deletes an active freeze"}); err != nil {
+			return nil, err
+		}
+	}
+
 	return nil, nil
 }
 
@@ -92,5 +118,31 @@ func (v *ChangeFreezeCustomValidator) validateChangeFreeze(obj *freezeoperatorv1
 		return fmt.Errorf("spec.endTime must be after spec.startTime")
 	}
 
+	return validateRateLimit(obj.Spec.Rules.RateLimit)
+}
+
+// validateRateLimit checks what the CRD schema cannot express for spec.rules.rateLimit.
+func validateRateLimit(rl *freezeoperatorv1alpha1.RateLimitSpec) error {
+	if rl == nil {
+		return nil
+	}
+	if rl.Period.Duration <= 0 {
+		return fmt.Errorf("spec.rules.rateLimit.period: must be greater than 0")
+	}
 	return nil
 }
+
+// changeFreezeWeakening lists the ways an update weakens an active ChangeFreeze.
+func changeFreezeWeakening(oldObj, newObj *freezeoperatorv1alpha1.ChangeFreeze, now time.Time) []string {
+	var out []string
+	if newObj.Spec.EndTime.Before(&oldObj.Spec.EndTime) {
+		out = append(out, fmt.Sprintf("shortens spec.endTime from %s to %s",
+			oldObj.Spec.EndTime.UTC().Format(time.RFC3339), newObj.Spec.EndTime.UTC().Format(time.RFC3339)))
+	}
+	if newObj.Spec.StartTime.After(now) {
+		out = append(out, "moves spec.startTime into the future")
+	}
+	out = append(out, loosenedRateLimit(oldObj.Spec.Rules.RateLimit, newObj.Spec.Rules.RateLimit)...)
+	out = append(out, loosenedBehavior(&oldObj.Spec.Behavior, &newObj.Spec.Behavior)...)
+	return append(out, narrowedTarget(&oldObj.Spec.Target, &newObj.Spec.Target, oldObj.Spec.Rules.Deny, newObj.Spec.Rules.Deny)...)
+}
@@ -21,7 +21,11 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authv1 "k8s.io/api/authentication/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
 )
@@ -125,4 +129,91 @@ var _ = Describe("ChangeFreeze Webhook", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 	})
+
+	Context("When tamper protection is enabled", func() {
+		var recorder *record.FakeRecorder
+
+		requestBy := func(groups ...string) admission.Request {
+			return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				UserInfo: authv1.UserInfo{Username: "alice", Groups: groups},
+			}}
+		}
+
+		BeforeEach(func() {
+			recorder = record.NewFakeRecorder(10)
+			validator = ChangeFreezeCustomValidator{TamperProtection: TamperProtection{
+				Enabled:     true,
+				AdminGroups: []string{"freeze-admins"},
+				Recorder:    recorder,
+			}}
+			now := time.Now().UTC()
+			obj.Name = "holiday"
+			obj.Spec.StartTime = metav1.Time{Time: now.Add(-time.Hour)}
+			obj.Spec.EndTime = metav1.Time{Time: now.Add(time.Hour)}
+			oldObj = obj.DeepCopy()
+		})
+
+		It("Should deny deleting an active freeze and record an event", func() {
+			reqCtx := admission.NewContextWithRequest(ctx, requestBy("developers"))
+			_, err := validator.ValidateDelete(reqCtx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(recorder.Events).To(Receive(ContainSubstring("TamperAttemptDenied")))
+		})
+
+		It("Should allow policy admins to delete an active freeze and record an event", func() {
+			reqCtx := admission.NewContextWithRequest(ctx, requestBy("freeze-admins"))
+			_, err := validator.ValidateDelete(reqCtx, obj)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(recorder.Events).To(Receive(ContainSubstring("TamperAttemptAllowed")))
+		})
+
+		It("Should allow deleting a freeze that is not active", func() {
+			obj.Spec.StartTime = metav1.Time{Time: time.Now().UTC().Add(time.Hour)}
+			obj.Spec.EndTime = metav1.Time{Time: time.Now().UTC().Add(2 * time.Hour)}
+			_, err := validator.ValidateDelete(admission.NewContextWithRequest(ctx, requestBy("developers")), obj)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("Should deny shortening endTime of an active freeze", func() {
+			obj.Spec.EndTime = metav1.Time{Time: time.Now().UTC().Add(time.Minute)}
+			_, err := validator.ValidateUpdate(admission.NewContextWithRequest(ctx, requestBy("developers")), oldObj, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("shortens spec.endTime"))
+		})
+
+		It("Should allow extending endTime of an active freeze", func() {
+			obj.Spec.EndTime = metav1.Time{Time: time.Now().UTC().Add(3 * time.Hour)}
+			_, err := validator.ValidateUpdate(admission.NewContextWithRequest(ctx, requestBy("developers")), oldObj, obj)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("Should deny narrowing the target of an active freeze", func() {
+			obj.Spec.Target.ObjectSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "x"}}
+			obj.Spec.Rules.Deny = []freezeoperatorv1alpha1.Action{freezeoperatorv1alpha1.ActionScale}
+			_, err := validator.ValidateUpdate(admission.NewContextWithRequest(ctx, requestBy("developers")), oldObj, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("objectSelector"))
+			Expect(err.Error()).To(ContainSubstring("ROLL_OUT"))
+		})
+
+		It("Should deny loosening the rate limit of an active freeze", func() {
+			oldObj.Spec.Rules.RateLimit = &freezeoperatorv1alpha1.RateLimitSpec{
+				Actions: []freezeoperatorv1alpha1.Action{freezeoperatorv1alpha1.ActionRollout},
+				Limit:   5,
+				Period:  metav1.Duration{Duration: time.Hour},
+			}
+			obj.Spec.Rules.RateLimit = oldObj.Spec.Rules.RateLimit.DeepCopy()
+			obj.Spec.Rules.RateLimit.Limit = 10
+			_, err := validator.ValidateUpdate(admission.NewContextWithRequest(ctx, requestBy("developers")), oldObj, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("raises spec.rules.rateLimit.limit from 5 to 10"))
+		})
+
+		It("Should deny enabling pauseDeployments on an active freeze", func() {
+			obj.Spec.Behavior.PauseDeployments = true
+			_, err := validator.ValidateUpdate(admission.NewContextWithRequest(ctx, requestBy("developers")), oldObj, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("enables spec.behavior.pauseDeployments"))
+		})
+	})
 })
@@ -0,0 +1,74 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+)
+
+// nolint:unused
+// log is for logging in this package.
+var deferredchangeLog = logf.Log.WithName("deferredchange-resource")
+
+// SetupDeferredChangeWebhookWithManager registers the webhook for DeferredChange in the manager.
+// operatorNamespace is the namespace of the operator service account.
+func SetupDeferredChangeWebhookWithManager(mgr ctrl.Manager, operatorNamespace string) error {
+	return ctrl.NewWebhookManagedBy(mgr, &freezeoperatorv1alpha1.DeferredChange{}).
+		WithValidator(&DeferredChangeCustomValidator{OperatorNamespace: operatorNamespace}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-freeze-operator-io-v1alpha1-deferredchange,mutating=false,failurePolicy=fail,sideEffects=None,groups=freeze-operator.io,resources=deferredchanges,verbs=create,versions=v1alpha1,name=vdeferredchange-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// DeferredChangeCustomValidator admits a DeferredChange only from the operator's own
+// service accounts. The operator applies DeferredChanges with its own permissions, so
+// anyone else creating one could change workloads they cannot edit.
+type DeferredChangeCustomValidator struct {
+	OperatorNamespace string
+}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type DeferredChange.
+func (v *DeferredChangeCustomValidator) ValidateCreate(ctx context.Context, obj *freezeoperatorv1alpha1.DeferredChange) (admission.Warnings, error) {
+	deferredchangeLog.Info("Validation for DeferredChange upon creation", "name", obj.GetName())
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot verify requester: %w", err)
+	}
+	if v.OperatorNamespace == "" || !slices.Contains(req.UserInfo.Groups, "system:serviceaccounts:"+v.OperatorNamespace) {
+		return nil, fmt.Errorf("DeferredChanges are created by the freeze-operator only")
+	}
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type DeferredChange.
+func (v *DeferredChangeCustomValidator) ValidateUpdate(_ context.Context, _, _ *freezeoperatorv1alpha1.DeferredChange) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type DeferredChange.
+func (v *DeferredChangeCustomValidator) ValidateDelete(_ context.Context, _ *freezeoperatorv1alpha1.DeferredChange) (admission.Warnings, error) {
+	return nil, nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+)
+
+var _ = Describe("DeferredChange Webhook", func() {
+	var (
+		obj       *freezeoperatorv1alpha1.DeferredChange
+		validator DeferredChangeCustomValidator
+	)
+
+	requestBy := func(username string, groups ...string) context.Context {
+		return admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			UserInfo:  authv1.UserInfo{Username: username, Groups: groups},
+		}})
+	}
+
+	BeforeEach(func() {
+		obj = &freezeoperatorv1alpha1.DeferredChange{ObjectMeta: metav1.ObjectMeta{Name: "app-x7k2p", Namespace: "prod"}}
+		validator = DeferredChangeCustomValidator{OperatorNamespace: "kube-freeze-operator-system"}
+	})
+
+	Context("When creating a DeferredChange", func() {
+		It("Should admit the operator service account", func() {
+			_, err := validator.ValidateCreate(requestBy(
+				"system:serviceaccount:kube-freeze-operator-system:kube-freeze-operator-controller-manager",
+				"system:serviceaccounts", "system:serviceaccounts:kube-freeze-operator-system",
+			), obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny everyone else", func() {
+			_, err := validator.ValidateCreate(requestBy("alice", "developers"), obj)
+			Expect(err).To(MatchError(ContainSubstring("created by the freeze-operator only")))
+		})
+
+		It("Should deny everyone when the operator namespace is unknown", func() {
+			validator.OperatorNamespace = ""
+			_, err := validator.ValidateCreate(requestBy("system:serviceaccount::x", "system:serviceaccounts:"), obj)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -18,26 +18,100 @@ package v1alpha1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"slices"
+	"time"
 
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/policy"
 )
 
 // nolint:unused
 // log is for logging in this package.
 var freezeexceptionLog = logf.Log.WithName("freezeexception-resource")
 
+// ExceptionPolicy holds cluster-wide rules that every FreezeException must satisfy.
+// The zero value imposes no additional rules.
+type ExceptionPolicy struct {
+	// RequireSeparateApprover requires spec.approvedBy to be set by the approver
+	// themselves, to differ from the creator and to belong to ApproverGroups.
+	RequireSeparateApprover bool
+	ApproverGroups          []string
+
+	// MaxDuration limits activeTo - activeFrom. Zero means unlimited.
+	MaxDuration time.Duration
+
+	RequireTicketURL bool
+	// TicketURLPattern, when set, must match spec.ticketURL.
+	TicketURLPattern *regexp.Regexp
+}
+
 // SetupFreezeExceptionWebhookWithManager registers the webhook for FreezeException in the manager.
-func SetupFreezeExceptionWebhookWithManager(mgr ctrl.Manager) error {
+func SetupFreezeExceptionWebhookWithManager(mgr ctrl.Manager, exceptionPolicy ExceptionPolicy) error {
 	return ctrl.NewWebhookManagedBy(mgr, &freezeoperatorv1alpha1.FreezeException{}).
-		WithValidator(&FreezeExceptionCustomValidator{}).
+		WithDefaulter(&FreezeExceptionCustomDefaulter{}).
+		WithValidator(&FreezeExceptionCustomValidator{Policy: exceptionPolicy, Reader: mgr.GetAPIReader()}).
 		Complete()
 }
 
+// +kubebuilder:webhook:path=/mutate-freeze-operator-io-v1alpha1-freezeexception,mutating=true,failurePolicy=fail,sideEffects=None,groups=freeze-operator.io,resources=freezeexceptions,verbs=create;update,versions=v1alpha1,name=mfreezeexception-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// FreezeExceptionCustomDefaulter records the creator of a FreezeException.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+type FreezeExceptionCustomDefaulter struct{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type FreezeException.
+func (d *FreezeExceptionCustomDefaulter) Default(ctx context.Context, obj *freezeoperatorv1alpha1.FreezeException) error {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil
+	}
+
+	switch req.Operation {
+	case admissionv1.Create:
+		// Always overwrite: the creator cannot be chosen by the client.
+		setAnnotation(obj, freezeoperatorv1alpha1.AnnotationCreatedBy, req.UserInfo.Username)
+	case admissionv1.Update:
+		// Carry the creator over when a replace drops the annotation; any other change
+		// is rejected by the validating webhook.
+		if _, ok := obj.Annotations[freezeoperatorv1alpha1.AnnotationCreatedBy]; ok {
+			return nil
+		}
+		var old freezeoperatorv1alpha1.FreezeException
+		if err := json.Unmarshal(req.OldObject.Raw, &old); err != nil {
+			return nil
+		}
+		if creator, ok := old.Annotations[freezeoperatorv1alpha1.AnnotationCreatedBy]; ok {
+			setAnnotation(obj, freezeoperatorv1alpha1.AnnotationCreatedBy, creator)
+		}
+	}
+	return nil
+}
+
+func setAnnotation(obj *freezeoperatorv1alpha1.FreezeException, key, value string) {
+	if obj.Annotations == nil {
+		obj.Annotations = map[string]string{}
+	}
+	obj.Annotations[key] = value
+}
+
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets;daemonsets,verbs=get
+// +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get
+
 // +kubebuilder:webhook:path=/validate-freeze-operator-io-v1alpha1-freezeexception,mutating=false,failurePolicy=fail,sideEffects=None,groups=freeze-operator.io,resources=freezeexceptions,verbs=create;update,versions=v1alpha1,name=vfreezeexception-v1alpha1.kb.io,admissionReviewVersions=v1
 
 // FreezeExceptionCustomValidator struct is responsible for validating the FreezeException resource
@@ -45,28 +119,72 @@ func SetupFreezeExceptionWebhookWithManager(mgr ctrl.Manager) error {
 //
 // NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
 // as this struct is used only for temporary operations and does not need to be deeply copied.
-type FreezeExceptionCustomValidator struct{}
+type FreezeExceptionCustomValidator struct {
+	Policy ExceptionPolicy
+
+	// Reader looks up the workloads listed in spec.objects. Lookups are skipped when nil.
+	Reader client.Reader
+}
 
 // ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type FreezeException.
-func (v *FreezeExceptionCustomValidator) ValidateCreate(_ context.Context, obj *freezeoperatorv1alpha1.FreezeException) (admission.Warnings, error) {
+func (v *FreezeExceptionCustomValidator) ValidateCreate(ctx context.Context, obj *freezeoperatorv1alpha1.FreezeException) (admission.Warnings, error) {
 	freezeexceptionLog.Info("Validation for FreezeException upon creation", "name", obj.GetName())
 
 	if err := v.validateFreezeException(obj); err != nil {
 		return nil, err
 	}
+	warnings := v.missingObjectWarnings(ctx, obj)
 
-	return nil, nil
+	if req, err := admission.RequestFromContext(ctx); err == nil {
+		creator := obj.Annotations[freezeoperatorv1alpha1.AnnotationCreatedBy]
+		if creator != req.UserInfo.Username {
+			return nil, fmt.Errorf("metadata.annotations[%s]: must be the requesting user %q",
+				freezeoperatorv1alpha1.AnnotationCreatedBy, req.UserInfo.Username)
+		}
+		// The creator cannot be the approver, so an approval can only be added later.
+		if v.Policy.RequireSeparateApprover && obj.Spec.ApprovedBy != "" {
+			return nil, fmt.Errorf("spec.approvedBy: must be set by the approver on update, not on create")
+		}
+	}
+
+	return warnings, nil
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type FreezeException.
-func (v *FreezeExceptionCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj *freezeoperatorv1alpha1.FreezeException) (admission.Warnings, error) {
+func (v *FreezeExceptionCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj *freezeoperatorv1alpha1.FreezeException) (admission.Warnings, error) {
 	freezeexceptionLog.Info("Validation for FreezeException upon update", "name", newObj.GetName())
 
 	if err := v.validateFreezeException(newObj); err != nil {
 		return nil, err
 	}
+	warnings := v.missingObjectWarnings(ctx, newObj)
 
-	return nil, nil
+	if oldObj.Annotations[freezeoperatorv1alpha1.AnnotationCreatedBy] != newObj.Annotations[freezeoperatorv1alpha1.AnnotationCreatedBy] {
+		return nil, fmt.Errorf("metadata.annotations[%s]: field is immutable", freezeoperatorv1alpha1.AnnotationCreatedBy)
+	}
+	// Otherwise the requester could drop the approval gate once the exception exists.
+	if newObj.Spec.RequiredApprovals < oldObj.Spec.RequiredApprovals {
+		return nil, fmt.Errorf("spec.requiredApprovals: cannot be lowered from %d to %d",
+			oldObj.Spec.RequiredApprovals, newObj.Spec.RequiredApprovals)
+	}
+
+	if v.Policy.RequireSeparateApprover && newObj.Spec.ApprovedBy != "" {
+		req, err := admission.RequestFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("spec.approvedBy: cannot verify approver: %w", err)
+		}
+		if newObj.Spec.ApprovedBy != oldObj.Spec.ApprovedBy {
+			if err := v.validateApproval(newObj, req); err != nil {
+				return nil, err
+			}
+		} else if !approvedSpecUnchanged(oldObj, newObj) && req.UserInfo.Username != newObj.Spec.ApprovedBy {
+			// Otherwise an approved exception could be widened after the fact.
+			return nil, fmt.Errorf("spec: an approved exception can only be changed by its approver %q; clear spec.approvedBy to request a new approval",
+				newObj.Spec.ApprovedBy)
+		}
+	}
+
+	return warnings, nil
 }
 
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type FreezeException.
@@ -83,10 +201,94 @@ func (v *FreezeExceptionCustomValidator) validateFreezeException(obj *freezeoper
 		return fmt.Errorf("spec.activeTo must be after spec.activeFrom")
 	}
 
+	if (obj.Spec.Target == nil) == (len(obj.Spec.Objects) == 0) {
+		return fmt.Errorf("spec: exactly one of target or objects must be set")
+	}
+
 	// Validate that at least one action is specified
 	if len(obj.Spec.Allow) == 0 {
 		return fmt.Errorf("spec.allow: must specify at least one action")
 	}
 
+	if c := obj.Spec.Constraints; c != nil {
+		for i, p := range c.AllowedImages {
+			if _, err := policy.CompileImagePattern(p); err != nil {
+				return fmt.Errorf("spec.constraints.allowedImages[%d]: invalid pattern %q: %w", i, p, err)
+			}
+		}
+	}
+
+	if v.Policy.MaxDuration > 0 {
+		if d := obj.Spec.ActiveTo.Sub(obj.Spec.ActiveFrom.Time); d > v.Policy.MaxDuration {
+			return fmt.Errorf("spec.activeTo: exception lasts %s, the cluster maximum is %s", d, v.Policy.MaxDuration)
+		}
+	}
+
+	if v.Policy.RequireTicketURL && obj.Spec.TicketURL == "" {
+		return fmt.Errorf("spec.ticketURL: required by cluster policy")
+	}
+	if v.Policy.TicketURLPattern != nil && obj.Spec.TicketURL != "" && !v.Policy.TicketURLPattern.MatchString(obj.Spec.TicketURL) {
+		return fmt.Errorf("spec.ticketURL: %q does not match the required pattern %q", obj.Spec.TicketURL, v.Policy.TicketURLPattern.String())
+	}
+
 	return nil
 }
+
+// validateApproval checks that the requester is the approver named in spec.approvedBy,
+// belongs to an approver group and did not create the exception.
+func (v *FreezeExceptionCustomValidator) validateApproval(obj *freezeoperatorv1alpha1.FreezeException, req admission.Request) error {
+	if !v.Policy.RequireSeparateApprover {
+		return nil
+	}
+	approver := obj.Spec.ApprovedBy
+	if approver == obj.Annotations[freezeoperatorv1alpha1.AnnotationCreatedBy] {
+		return fmt.Errorf("spec.approvedBy: the creator %q cannot approve their own exception", approver)
+	}
+	if req.UserInfo.Username != approver {
+		return fmt.Errorf("spec.approvedBy: must be set by the approver %q themselves, not by %q", approver, req.UserInfo.Username)
+	}
+	if !slices.ContainsFunc(req.UserInfo.Groups, func(g string) bool { return slices.Contains(v.Policy.ApproverGroups, g) }) {
+		return fmt.Errorf("spec.approvedBy: %q is not a member of the approver groups %v", approver, v.Policy.ApproverGroups)
+	}
+	return nil
+}
+
+// missingObjectWarnings warns about spec.objects entries that do not exist (yet).
+// Missing objects are not an error: an exception may be created ahead of the workload.
+func (v *FreezeExceptionCustomValidator) missingObjectWarnings(ctx context.Context, obj *freezeoperatorv1alpha1.FreezeException) admission.Warnings {
+	if v.Reader == nil {
+		return nil
+	}
+	var warnings admission.Warnings
+	for i, o := range obj.Spec.Objects {
+		gvk, ok := targetKindGVK[o.Kind]
+		if !ok {
+			continue
+		}
+		m := &metav1.PartialObjectMetadata{}
+		m.SetGroupVersionKind(gvk)
+		err := v.Reader.Get(ctx, client.ObjectKey{Namespace: o.Namespace, Name: o.Name}, m)
+		switch {
+		case apierrors.IsNotFound(err):
+			warnings = append(warnings, fmt.Sprintf("spec.objects[%d]: %s %s/%s does not exist", i, o.Kind, o.Namespace, o.Name))
+		case err != nil:
+			warnings = append(warnings, fmt.Sprintf("spec.objects[%d]: cannot verify %s %s/%s: %v", i, o.Kind, o.Namespace, o.Name, err))
+		}
+	}
+	return warnings
+}
+
+var targetKindGVK = map[freezeoperatorv1alpha1.TargetKind]schema.GroupVersionKind{
+	freezeoperatorv1alpha1.TargetKindDeployment:  {Group: "apps", Version: "v1", Kind: "Deployment"},
+	freezeoperatorv1alpha1.TargetKindStatefulSet: {Group: "apps", Version: "v1", Kind: "StatefulSet"},
+	freezeoperatorv1alpha1.TargetKindDaemonSet:   {Group: "apps", Version: "v1", Kind: "DaemonSet"},
+	freezeoperatorv1alpha1.TargetKindCronJob:     {Group: "batch", Version: "v1", Kind: "CronJob"},
+}
+
+// approvedSpecUnchanged reports whether the parts of the spec an approval covers are unchanged.
+func approvedSpecUnchanged(oldObj, newObj *freezeoperatorv1alpha1.FreezeException) bool {
+	a := oldObj.Spec.DeepCopy()
+	b := newObj.Spec.DeepCopy()
+	a.ApprovedBy, b.ApprovedBy = "", ""
+	return equality.Semantic.DeepEqual(a, b)
+}
@@ -17,11 +17,20 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
+	"encoding/json"
+	"regexp"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	authv1 "k8s.io/api/authentication/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
 )
@@ -41,7 +50,7 @@ var _ = Describe("FreezeException Webhook", func() {
 			Spec: freezeoperatorv1alpha1.FreezeExceptionSpec{
 				ActiveFrom: metav1.Time{Time: now.Add(-time.Hour)},
 				ActiveTo:   metav1.Time{Time: now.Add(time.Hour)},
-				Target: freezeoperatorv1alpha1.TargetSpec{
+				Target: &freezeoperatorv1alpha1.TargetSpec{
 					Kinds: []freezeoperatorv1alpha1.TargetKind{freezeoperatorv1alpha1.TargetKindDeployment},
 				},
 				Allow:  []freezeoperatorv1alpha1.Action{freezeoperatorv1alpha1.ActionRollout},
@@ -106,6 +115,39 @@ var _ = Describe("FreezeException Webhook", func() {
 			_, err := validator.ValidateCreate(ctx, obj)
 			Expect(err).ToNot(HaveOccurred())
 		})
+		It("Should deny setting both target and objects", func() {
+			obj.Spec.Objects = []freezeoperatorv1alpha1.ExceptionObjectReference{
+				{Kind: freezeoperatorv1alpha1.TargetKindDeployment, Namespace: "prod", Name: "payments-api"},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("exactly one of target or objects"))
+		})
+
+		It("Should warn about listed objects that do not exist", func() {
+			s := runtime.NewScheme()
+			Expect(appsv1.AddToScheme(s)).To(Succeed())
+			existing := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: "payments-api"}}
+			validator.Reader = fake.NewClientBuilder().WithScheme(s).WithObjects(existing).Build()
+
+			obj.Spec.Target = nil
+			obj.Spec.Objects = []freezeoperatorv1alpha1.ExceptionObjectReference{
+				{Kind: freezeoperatorv1alpha1.TargetKindDeployment, Namespace: "prod", Name: "payments-api"},
+				{Kind: freezeoperatorv1alpha1.TargetKindDeployment, Namespace: "prod", Name: "payments-apii"},
+			}
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("spec.objects[1]: Deployment prod/payments-apii does not exist")))
+		})
+
+		It("Should deny an invalid allowedImages regular expression", func() {
+			obj.Spec.Constraints = &freezeoperatorv1alpha1.FreezeExceptionConstraintsSpec{
+				AllowedImages: []string{"registry.example.com/*", "regex:api:v[0-9"},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.constraints.allowedImages[1]"))
+		})
 	})
 
 	Context("When updating FreezeException under Validating Webhook", func() {
@@ -120,6 +162,18 @@ var _ = Describe("FreezeException Webhook", func() {
 			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
 			Expect(err).To(HaveOccurred())
 		})
+
+		It("Should deny lowering requiredApprovals", func() {
+			oldObj.Spec.RequiredApprovals = 2
+			obj.Spec.RequiredApprovals = 0
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.requiredApprovals"))
+
+			obj.Spec.RequiredApprovals = 3
+			_, err = validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).ToNot(HaveOccurred())
+		})
 	})
 
 	Context("When deleting FreezeException under Validating Webhook", func() {
@@ -128,4 +182,114 @@ var _ = Describe("FreezeException Webhook", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 	})
+
+	Context("When cluster-wide exception limits are configured", func() {
+		It("Should deny an exception longer than the maximum duration", func() {
+			validator.Policy.MaxDuration = time.Hour
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cluster maximum"))
+		})
+
+		It("Should deny an exception without a required ticketURL", func() {
+			validator.Policy.RequireTicketURL = true
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.ticketURL"))
+		})
+
+		It("Should enforce the ticketURL pattern", func() {
+			validator.Policy.TicketURLPattern = regexp.MustCompile(`^https://tickets\.example\.com/`)
+			obj.Spec.TicketURL = "https://elsewhere.example.com/1"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+
+			obj.Spec.TicketURL = "https://tickets.example.com/INC-1"
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("When separate approval is required", func() {
+		requestBy := func(op admissionv1.Operation, username string, groups ...string) context.Context {
+			return admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: op,
+				UserInfo:  authv1.UserInfo{Username: username, Groups: groups},
+			}})
+		}
+
+		BeforeEach(func() {
+			validator.Policy = ExceptionPolicy{RequireSeparateApprover: true, ApproverGroups: []string{"release-managers"}}
+			obj.Annotations = map[string]string{freezeoperatorv1alpha1.AnnotationCreatedBy: "dev"}
+			oldObj = obj.DeepCopy()
+		})
+
+		It("Should record the creator from the request", func() {
+			obj.Annotations = map[string]string{freezeoperatorv1alpha1.AnnotationCreatedBy: "someone-else"}
+			defaulter := FreezeExceptionCustomDefaulter{}
+			Expect(defaulter.Default(requestBy(admissionv1.Create, "dev"), obj)).To(Succeed())
+			Expect(obj.Annotations).To(HaveKeyWithValue(freezeoperatorv1alpha1.AnnotationCreatedBy, "dev"))
+		})
+
+		It("Should carry the creator over when an update drops it", func() {
+			raw, err := json.Marshal(oldObj)
+			Expect(err).ToNot(HaveOccurred())
+			updateCtx := admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Update,
+				OldObject: runtime.RawExtension{Raw: raw},
+			}})
+			obj.Annotations = nil
+			defaulter := FreezeExceptionCustomDefaulter{}
+			Expect(defaulter.Default(updateCtx, obj)).To(Succeed())
+			Expect(obj.Annotations).To(HaveKeyWithValue(freezeoperatorv1alpha1.AnnotationCreatedBy, "dev"))
+		})
+
+		It("Should deny changing the creator annotation", func() {
+			obj.Annotations[freezeoperatorv1alpha1.AnnotationCreatedBy] = "mallory"
+			_, err := validator.ValidateUpdate(requestBy(admissionv1.Update, "dev"), oldObj, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("immutable"))
+		})
+
+		It("Should deny the creator approving their own exception", func() {
+			_, err := validator.ValidateCreate(requestBy(admissionv1.Create, "dev", "release-managers"), withApprover(obj, "dev"))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny setting approvedBy on create", func() {
+			_, err := validator.ValidateCreate(requestBy(admissionv1.Create, "dev", "release-managers"), withApprover(obj, "rm"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("must be set by the approver on update"))
+		})
+
+		It("Should deny setting approvedBy on behalf of someone else", func() {
+			_, err := validator.ValidateUpdate(requestBy(admissionv1.Update, "dev"), oldObj, withApprover(obj, "rm"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("themselves"))
+		})
+
+		It("Should deny approvers outside the approver groups", func() {
+			_, err := validator.ValidateUpdate(requestBy(admissionv1.Update, "rm", "developers"), oldObj, withApprover(obj, "rm"))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should allow an approver-group member to approve", func() {
+			_, err := validator.ValidateUpdate(requestBy(admissionv1.Update, "rm", "release-managers"), oldObj, withApprover(obj, "rm"))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("Should deny widening an approved exception", func() {
+			approved := withApprover(obj, "rm")
+			widened := approved.DeepCopy()
+			widened.Spec.Allow = append(widened.Spec.Allow, freezeoperatorv1alpha1.ActionDelete)
+			_, err := validator.ValidateUpdate(requestBy(admissionv1.Update, "dev"), approved, widened)
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
+
+func withApprover(obj *freezeoperatorv1alpha1.FreezeException, approver string) *freezeoperatorv1alpha1.FreezeException {
+	out := obj.DeepCopy()
+	out.Spec.ApprovedBy = approver
+	return out
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+)
+
+// ApproveVerb is the custom RBAC verb on freezeexceptions that allows a user to approve them.
+const ApproveVerb = "approve"
+
+// nolint:unused
+// log is for logging in this package.
+var freezeexceptionapprovalLog = logf.Log.WithName("freezeexceptionapproval-resource")
+
+// SetupFreezeExceptionApprovalWebhookWithManager registers the webhook for FreezeExceptionApproval in the manager.
+func SetupFreezeExceptionApprovalWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &freezeoperatorv1alpha1.FreezeExceptionApproval{}).
+		WithValidator(&FreezeExceptionApprovalCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+// +kubebuilder:rbac:groups=freeze-operator.io,resources=freezeexceptions,verbs=get;list;watch
+
+// +kubebuilder:webhook:path=/validate-freeze-operator-io-v1alpha1-freezeexceptionapproval,mutating=false,failurePolicy=fail,sideEffects=None,groups=freeze-operator.io,resources=freezeexceptionapprovals,verbs=create,versions=v1alpha1,name=vfreezeexceptionapproval-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// FreezeExceptionApprovalCustomValidator admits an approval only when the requester is
+// the approver, did not create the exception, and is allowed the "approve" verb on it.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+type FreezeExceptionApprovalCustomValidator struct {
+	Client client.Client
+}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type FreezeExceptionApproval.
+func (v *FreezeExceptionApprovalCustomValidator) ValidateCreate(ctx context.Context, obj *freezeoperatorv1alpha1.FreezeExceptionApproval) (admission.Warnings, error) {
+	freezeexceptionapprovalLog.Info("Validation for FreezeExceptionApproval upon creation", "name", obj.GetName())
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot verify approver: %w", err)
+	}
+	user := req.UserInfo.Username
+	if obj.Spec.Approver != user {
+		return nil, fmt.Errorf("spec.approver: must be the requesting user %q", user)
+	}
+
+	ex := &freezeoperatorv1alpha1.FreezeException{}
+	if err := v.Client.Get(ctx, client.ObjectKey{Name: obj.Spec.ExceptionName}, ex); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("spec.exceptionName: FreezeException %q not found", obj.Spec.ExceptionName)
+		}
+		return nil, err
+	}
+	if obj.Spec.ExceptionGeneration != ex.Generation {
+		return nil, fmt.Errorf("spec.exceptionGeneration: FreezeException %q is at generation %d, not %d; review the current spec before approving",
+			ex.Name, ex.Generation, obj.Spec.ExceptionGeneration)
+	}
+	if user == ex.Annotations[freezeoperatorv1alpha1.AnnotationCreatedBy] {
+		return nil, fmt.Errorf("spec.approver: the creator %q cannot approve their own exception", user)
+	}
+
+	sar := accessReview(req.UserInfo, &authorizationv1.ResourceAttributes{
+		Group:    freezeoperatorv1alpha1.GroupVersion.Group,
+		Version:  freezeoperatorv1alpha1.GroupVersion.Version,
+		Resource: "freezeexceptions",
+		Name:     ex.Name,
+		Verb:     ApproveVerb,
+	})
+	if err := v.Client.Create(ctx, sar); err != nil {
+		return nil, fmt.Errorf("subject access review: %w", err)
+	}
+	if !sar.Status.Allowed {
+		return nil, fmt.Errorf("user %q is not allowed to %s freezeexceptions/%s", user, ApproveVerb, ex.Name)
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type FreezeExceptionApproval.
+// The spec is immutable through CEL validation, so updates are not checked here.
+func (v *FreezeExceptionApprovalCustomValidator) ValidateUpdate(_ context.Context, _, newObj *freezeoperatorv1alpha1.FreezeExceptionApproval) (admission.Warnings, error) {
+	freezeexceptionapprovalLog.Info("Validation for FreezeExceptionApproval upon update", "name", newObj.GetName())
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type FreezeExceptionApproval.
+// Deleting an approval revokes it.
+func (v *FreezeExceptionApprovalCustomValidator) ValidateDelete(_ context.Context, obj *freezeoperatorv1alpha1.FreezeExceptionApproval) (admission.Warnings, error) {
+	freezeexceptionapprovalLog.Info("Validation for FreezeExceptionApproval upon deletion", "name", obj.GetName())
+	return nil, nil
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+)
+
+var _ = Describe("FreezeExceptionApproval Webhook", func() {
+	var (
+		obj       *freezeoperatorv1alpha1.FreezeExceptionApproval
+		validator FreezeExceptionApprovalCustomValidator
+		reviews   []*authorizationv1.SubjectAccessReview
+	)
+
+	// approvers lists the users the fake SubjectAccessReview allows to approve.
+	approvers := []string{"rm", "dev"}
+
+	requestBy := func(username string) context.Context {
+		return admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			UserInfo:  authv1.UserInfo{Username: username, Groups: []string{"release-managers"}},
+		}})
+	}
+
+	BeforeEach(func() {
+		reviews = nil
+		s := runtime.NewScheme()
+		Expect(freezeoperatorv1alpha1.AddToScheme(s)).To(Succeed())
+		Expect(authorizationv1.AddToScheme(s)).To(Succeed())
+
+		ex := &freezeoperatorv1alpha1.FreezeException{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "hotfix",
+				Generation:  2,
+				Annotations: map[string]string{freezeoperatorv1alpha1.AnnotationCreatedBy: "dev"},
+			},
+		}
+		cl := fake.NewClientBuilder().WithScheme(s).WithObjects(ex).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, o client.Object, opts ...client.CreateOption) error {
+					sar, ok := o.(*authorizationv1.SubjectAccessReview)
+					if !ok {
+						return c.Create(ctx, o, opts...)
+					}
+					reviews = append(reviews, sar)
+					for _, u := range approvers {
+						if sar.Spec.User == u {
+							sar.Status.Allowed = true
+						}
+					}
+					return nil
+				},
+			}).Build()
+
+		validator = FreezeExceptionApprovalCustomValidator{Client: cl}
+		obj = &freezeoperatorv1alpha1.FreezeExceptionApproval{
+			ObjectMeta: metav1.ObjectMeta{Name: "hotfix-rm"},
+			Spec: freezeoperatorv1alpha1.FreezeExceptionApprovalSpec{
+				ExceptionName:       "hotfix",
+				ExceptionGeneration: 2,
+				Approver:            "rm",
+			},
+		}
+	})
+
+	Context("When creating FreezeExceptionApproval under Validating Webhook", func() {
+		It("Should allow an authorized approver and check the approve verb", func() {
+			_, err := validator.ValidateCreate(requestBy("rm"), obj)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reviews).To(HaveLen(1))
+			attrs := reviews[0].Spec.ResourceAttributes
+			Expect(attrs.Verb).To(Equal(ApproveVerb))
+			Expect(attrs.Resource).To(Equal("freezeexceptions"))
+			Expect(attrs.Name).To(Equal("hotfix"))
+		})
+
+		It("Should deny approving on behalf of someone else", func() {
+			_, err := validator.ValidateCreate(requestBy("ops"), obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.approver"))
+		})
+
+		It("Should deny users without the approve verb", func() {
+			obj.Spec.Approver = "ops"
+			_, err := validator.ValidateCreate(requestBy("ops"), obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("not allowed to approve"))
+		})
+
+		It("Should deny the creator approving their own exception", func() {
+			obj.Spec.Approver = "dev"
+			_, err := validator.ValidateCreate(requestBy("dev"), obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cannot approve their own"))
+		})
+
+		It("Should deny approving a stale generation", func() {
+			obj.Spec.ExceptionGeneration = 1
+			_, err := validator.ValidateCreate(requestBy("rm"), obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("generation 2"))
+		})
+
+		It("Should deny approving a missing exception", func() {
+			obj.Spec.ExceptionName = "missing"
+			_, err := validator.ValidateCreate(requestBy("rm"), obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("not found"))
+		})
+	})
+})
@@ -0,0 +1,94 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+)
+
+// policyHooks returns the hooks of h by field name.
+func policyHooks(h *freezeoperatorv1alpha1.PolicyHooksSpec) map[string]*freezeoperatorv1alpha1.HookSpec {
+	if h == nil {
+		return nil
+	}
+	return map[string]*freezeoperatorv1alpha1.HookSpec{"onActivate": h.OnActivate, "onDeactivate": h.OnDeactivate}
+}
+
+// authorizeHooks checks that the requesting user may create Jobs in the namespace of
+// every hook jobTemplateRef that oldHooks does not already reference. The operator
+// launches hook Jobs with its own permissions, so otherwise anyone allowed to edit a
+// policy could run any CronJob's template, and its service account, in any namespace.
+func authorizeHooks(ctx context.Context, c client.Client, hooks, oldHooks *freezeoperatorv1alpha1.PolicyHooksSpec) error {
+	newRefs, oldRefs := policyHooks(hooks), policyHooks(oldHooks)
+	for _, name := range []string{"onActivate", "onDeactivate"} {
+		hook := newRefs[name]
+		if hook == nil {
+			continue
+		}
+		if old := oldRefs[name]; old != nil && old.JobTemplateRef == hook.JobTemplateRef {
+			continue
+		}
+		field := "spec.behavior.hooks." + name + ".jobTemplateRef"
+		if c == nil {
+			return fmt.Errorf("%s: cannot authorize the hook", field)
+		}
+		req, err := admission.RequestFromContext(ctx)
+		if err != nil {
+			return fmt.Errorf("%s: cannot authorize the hook: %w", field, err)
+		}
+		ns := hook.JobTemplateRef.Namespace
+		sar := accessReview(req.UserInfo, &authorizationv1.ResourceAttributes{
+			Group:     "batch",
+			Version:   "v1",
+			Resource:  "jobs",
+			Namespace: ns,
+			Verb:      "create",
+		})
+		if err := c.Create(ctx, sar); err != nil {
+			return fmt.Errorf("subject access review: %w", err)
+		}
+		if !sar.Status.Allowed {
+			return fmt.Errorf("%s: user %q is not allowed to create jobs in namespace %q", field, req.UserInfo.Username, ns)
+		}
+	}
+	return nil
+}
+
+// accessReview returns a SubjectAccessReview of attrs for the user of an admission request.
+func accessReview(user authenticationv1.UserInfo, attrs *authorizationv1.ResourceAttributes) *authorizationv1.SubjectAccessReview {
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, val := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(val)
+	}
+	return &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:               user.Username,
+			Groups:             user.Groups,
+			UID:                user.UID,
+			Extra:              extra,
+			ResourceAttributes: attrs,
+		},
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+)
+
+var _ = Describe("Hook authorization", func() {
+	var (
+		cl      client.Client
+		reviews []*authorizationv1.SubjectAccessReview
+	)
+
+	// The fake SubjectAccessReview only lets "ops" create Jobs in "hooks".
+	requestBy := func(username string) context.Context {
+		return admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			UserInfo: authv1.UserInfo{Username: username},
+		}})
+	}
+	hooksIn := func(ns string) *freezeoperatorv1alpha1.PolicyHooksSpec {
+		return &freezeoperatorv1alpha1.PolicyHooksSpec{
+			OnActivate: &freezeoperatorv1alpha1.HookSpec{
+				JobTemplateRef: freezeoperatorv1alpha1.JobTemplateReference{Namespace: ns, Name: "notify"},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		reviews = nil
+		s := runtime.NewScheme()
+		Expect(authorizationv1.AddToScheme(s)).To(Succeed())
+		cl = fake.NewClientBuilder().WithScheme(s).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, o client.Object, opts ...client.CreateOption) error {
+					sar, ok := o.(*authorizationv1.SubjectAccessReview)
+					if !ok {
+						return c.Create(ctx, o, opts...)
+					}
+					reviews = append(reviews, sar)
+					attrs := sar.Spec.ResourceAttributes
+					sar.Status.Allowed = sar.Spec.User == "ops" && attrs.Namespace == "hooks"
+					return nil
+				},
+			}).Build()
+	})
+
+	Context("When validating a ChangeFreeze", func() {
+		var (
+			obj       *freezeoperatorv1alpha1.ChangeFreeze
+			validator ChangeFreezeCustomValidator
+		)
+
+		BeforeEach(func() {
+			now := time.Now().UTC()
+			validator = ChangeFreezeCustomValidator{Client: cl}
+			obj = &freezeoperatorv1alpha1.ChangeFreeze{
+				Spec: freezeoperatorv1alpha1.ChangeFreezeSpec{
+					StartTime: metav1.Time{Time: now.Add(time.Hour)},
+					EndTime:   metav1.Time{Time: now.Add(3 * time.Hour)},
+					Behavior:  freezeoperatorv1alpha1.PolicyBehaviorSpec{Hooks: hooksIn("hooks")},
+				},
+			}
+		})
+
+		It("Should allow a hook in a namespace where the requester may create Jobs", func() {
+			_, err := validator.ValidateCreate(requestBy("ops"), obj)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reviews).To(HaveLen(1))
+			attrs := reviews[0].Spec.ResourceAttributes
+			Expect(attrs.Group).To(Equal("batch"))
+			Expect(attrs.Resource).To(Equal("jobs"))
+			Expect(attrs.Verb).To(Equal("create"))
+			Expect(attrs.Namespace).To(Equal("hooks"))
+		})
+
+		It("Should deny a hook in a namespace where the requester may not create Jobs", func() {
+			obj.Spec.Behavior.Hooks = hooksIn("kube-system")
+			_, err := validator.ValidateCreate(requestBy("ops"), obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.behavior.hooks.onActivate.jobTemplateRef"))
+			Expect(err.Error()).To(ContainSubstring(`not allowed to create jobs in namespace "kube-system"`))
+		})
+
+		It("Should not re-check hooks an update leaves unchanged", func() {
+			_, err := validator.ValidateUpdate(requestBy("dev"), obj.DeepCopy(), obj)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reviews).To(BeEmpty())
+		})
+
+		It("Should check a hook an update points elsewhere", func() {
+			oldObj := obj.DeepCopy()
+			obj.Spec.Behavior.Hooks.OnActivate.JobTemplateRef.Namespace = "kube-system"
+			_, err := validator.ValidateUpdate(requestBy("dev"), oldObj, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(reviews).To(HaveLen(1))
+		})
+	})
+
+	Context("When validating a MaintenanceWindow", func() {
+		It("Should deny a hook in a namespace where the requester may not create Jobs", func() {
+			validator := MaintenanceWindowCustomValidator{Client: cl}
+			obj := &freezeoperatorv1alpha1.MaintenanceWindow{
+				Spec: freezeoperatorv1alpha1.MaintenanceWindowSpec{
+					Mode:     freezeoperatorv1alpha1.MaintenanceWindowModeDenyOutsideWindows,
+					Windows:  []freezeoperatorv1alpha1.MaintenanceWindowWindowSpec{{Schedule: "0 2 * * 6", Duration: metav1.Duration{Duration: time.Hour}}},
+					Behavior: freezeoperatorv1alpha1.PolicyBehaviorSpec{Hooks: hooksIn("kube-system")},
+				},
+			}
+			_, err := validator.ValidateCreate(requestBy("dev"), obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("not allowed to create jobs"))
+		})
+	})
+})
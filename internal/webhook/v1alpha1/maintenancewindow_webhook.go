@@ -23,10 +23,12 @@ import (
 
 	"github.com/robfig/cron/v3"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/policy"
 )
 
 // nolint:unused
@@ -34,48 +36,72 @@ import (
 var maintenancewindowlog = logf.Log.WithName("maintenancewindow-resource")
 
 // SetupMaintenanceWindowWebhookWithManager registers the webhook for MaintenanceWindow in the manager.
-func SetupMaintenanceWindowWebhookWithManager(mgr ctrl.Manager) error {
+func SetupMaintenanceWindowWebhookWithManager(mgr ctrl.Manager, tamper TamperProtection) error {
 	return ctrl.NewWebhookManagedBy(mgr, &freezeoperatorv1alpha1.MaintenanceWindow{}).
-		WithValidator(&MaintenanceWindowCustomValidator{}).
+		WithValidator(&MaintenanceWindowCustomValidator{TamperProtection: tamper, Client: mgr.GetClient()}).
 		Complete()
 }
 
-// +kubebuilder:webhook:path=/validate-freeze-operator-io-v1alpha1-maintenancewindow,mutating=false,failurePolicy=fail,sideEffects=None,groups=freeze-operator.io,resources=maintenancewindows,verbs=create;update,versions=v1alpha1,name=vmaintenancewindow-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-freeze-operator-io-v1alpha1-maintenancewindow,mutating=false,failurePolicy=fail,sideEffects=None,groups=freeze-operator.io,resources=maintenancewindows,verbs=create;update;delete,versions=v1alpha1,name=vmaintenancewindow-v1alpha1.kb.io,admissionReviewVersions=v1
 
 // MaintenanceWindowCustomValidator struct is responsible for validating the MaintenanceWindow resource
 // when it is created, updated, or deleted.
 //
 // NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
 // as this struct is used only for temporary operations and does not need to be deeply copied.
-type MaintenanceWindowCustomValidator struct{}
+type MaintenanceWindowCustomValidator struct {
+	TamperProtection TamperProtection
+
+	// Client creates the SubjectAccessReviews that authorize hook jobTemplateRefs.
+	Client client.Client
+}
 
 // ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type MaintenanceWindow.
-func (v *MaintenanceWindowCustomValidator) ValidateCreate(_ context.Context, obj *freezeoperatorv1alpha1.MaintenanceWindow) (admission.Warnings, error) {
+func (v *MaintenanceWindowCustomValidator) ValidateCreate(ctx context.Context, obj *freezeoperatorv1alpha1.MaintenanceWindow) (admission.Warnings, error) {
 	maintenancewindowlog.Info("Validation for MaintenanceWindow upon creation", "name", obj.GetName())
 
 	if err := v.validateMaintenanceWindow(obj); err != nil {
 		return nil, err
 	}
+	if err := authorizeHooks(ctx, v.Client, obj.Spec.Behavior.Hooks, nil); err != nil {
+		return nil, err
+	}
 
 	return nil, nil
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type MaintenanceWindow.
-func (v *MaintenanceWindowCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj *freezeoperatorv1alpha1.MaintenanceWindow) (admission.Warnings, error) {
+func (v *MaintenanceWindowCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj *freezeoperatorv1alpha1.MaintenanceWindow) (admission.Warnings, error) {
 	maintenancewindowlog.Info("Validation for MaintenanceWindow upon update", "name", newObj.GetName())
 
 	if err := v.validateMaintenanceWindow(newObj); err != nil {
 		return nil, err
 	}
+	if err := authorizeHooks(ctx, v.Client, newObj.Spec.Behavior.Hooks, oldObj.Spec.Behavior.Hooks); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if denying, _ := policy.MaintenanceWindowDenying(oldObj, now); denying {
+		violations := maintenanceWindowWeakening(oldObj, newObj, now)
+		if err := v.TamperProtection.guard(ctx, oldObj, "MaintenanceWindow", oldObj.Name, "update", violations); err != nil {
+			return nil, err
+		}
+	}
 
 	return nil, nil
 }
 
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type MaintenanceWindow.
-func (v *MaintenanceWindowCustomValidator) ValidateDelete(_ context.Context, obj *freezeoperatorv1alpha1.MaintenanceWindow) (admission.Warnings, error) {
+func (v *MaintenanceWindowCustomValidator) ValidateDelete(ctx context.Context, obj *freezeoperatorv1alpha1.MaintenanceWindow) (admission.Warnings, error) {
 	maintenancewindowlog.Info("Validation for MaintenanceWindow upon deletion", "name", obj.GetName())
 
-	// No validation needed for deletion
+	if denying, _ := policy.MaintenanceWindowDenying(obj, time.Now().UTC()); denying {
+		if err := v.TamperProtection.guard(ctx, obj, "MaintenanceWindow", obj.Name, "delete", []string{"deletes a denying maintenance window"}); err != nil {
+			return nil, err
+		}
+	}
+
 	return nil, nil
 }
 
@@ -105,5 +131,16 @@ func (v *MaintenanceWindowCustomValidator) validateMaintenanceWindow(obj *freeze
 		}
 	}
 
-	return nil
+	return validateRateLimit(obj.Spec.Rules.RateLimit)
+}
+
+// maintenanceWindowWeakening lists the ways an update weakens a denying MaintenanceWindow.
+func maintenanceWindowWeakening(oldObj, newObj *freezeoperatorv1alpha1.MaintenanceWindow, now time.Time) []string {
+	var out []string
+	if denying, _ := policy.MaintenanceWindowDenying(newObj, now); !denying {
+		out = append(out, "stops denying changes now (spec.mode or spec.windows)")
+	}
+	out = append(out, loosenedRateLimit(oldObj.Spec.Rules.RateLimit, newObj.Spec.Rules.RateLimit)...)
+	out = append(out, loosenedBehavior(&oldObj.Spec.Behavior, &newObj.Spec.Behavior)...)
+	return append(out, narrowedTarget(&oldObj.Spec.Target, &newObj.Spec.Target, oldObj.Spec.Rules.Deny, newObj.Spec.Rules.Deny)...)
 }
@@ -17,11 +17,17 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authv1 "k8s.io/api/authentication/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
 )
@@ -130,4 +136,48 @@ var _ = Describe("MaintenanceWindow Webhook", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 	})
+
+	Context("When tamper protection is enabled", func() {
+		var recorder *record.FakeRecorder
+		var reqCtx context.Context
+
+		BeforeEach(func() {
+			recorder = record.NewFakeRecorder(10)
+			validator = MaintenanceWindowCustomValidator{TamperProtection: TamperProtection{
+				Enabled:     true,
+				AdminGroups: []string{"freeze-admins"},
+				Recorder:    recorder,
+			}}
+			// A one-minute window that opened 23h ago: the window is currently closed.
+			opened := time.Now().UTC().Add(-23 * time.Hour)
+			obj.Name = "nightly"
+			obj.Spec.Windows[0].Schedule = fmt.Sprintf("%d %d * * *", opened.Minute(), opened.Hour())
+			obj.Spec.Windows[0].Duration = metav1.Duration{Duration: time.Minute}
+			oldObj = obj.DeepCopy()
+			reqCtx = admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				UserInfo: authv1.UserInfo{Username: "alice", Groups: []string{"developers"}},
+			}})
+		})
+
+		It("Should deny deleting a denying maintenance window", func() {
+			_, err := validator.ValidateDelete(reqCtx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(recorder.Events).To(Receive(ContainSubstring("TamperAttemptDenied")))
+		})
+
+		It("Should deny opening a window right now", func() {
+			started := time.Now().UTC().Add(-time.Minute)
+			obj.Spec.Windows[0].Schedule = fmt.Sprintf("%d %d * * *", started.Minute(), started.Hour())
+			obj.Spec.Windows[0].Duration = metav1.Duration{Duration: time.Hour}
+			_, err := validator.ValidateUpdate(reqCtx, oldObj, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("stops denying"))
+		})
+
+		It("Should allow adding kinds to the target", func() {
+			obj.Spec.Target.Kinds = append(obj.Spec.Target.Kinds, freezeoperatorv1alpha1.TargetKindStatefulSet)
+			_, err := validator.ValidateUpdate(reqCtx, oldObj, obj)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
 })
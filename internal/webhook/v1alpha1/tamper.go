@@ -0,0 +1,147 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	freezeoperatorv1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+)
+
+const (
+	eventReasonTamperDenied  = "TamperAttemptDenied"
+	eventReasonTamperAllowed = "TamperAttemptAllowed"
+)
+
+// TamperProtection guards enforcing policies against being deleted or weakened.
+// When enabled, only members of AdminGroups may delete an active ChangeFreeze (or a
+// MaintenanceWindow that is currently denying), shorten it or narrow its target.
+// Every attempt is recorded as an Event on the policy.
+type TamperProtection struct {
+	Enabled     bool
+	AdminGroups []string
+	Recorder    record.EventRecorder
+}
+
+// guard denies the operation unless the requester is a policy admin. violations
+// describes how the operation weakens the policy; nothing is checked when empty.
+func (t TamperProtection) guard(ctx context.Context, obj runtime.Object, kind, name, operation string, violations []string) error {
+	if !t.Enabled || len(violations) == 0 {
+		return nil
+	}
+
+	username := "unknown"
+	var groups []string
+	if req, err := admission.RequestFromContext(ctx); err == nil {
+		username = req.UserInfo.Username
+		groups = req.UserInfo.Groups
+	}
+	what := strings.Join(violations, "; ")
+
+	if slices.ContainsFunc(groups, func(g string) bool { return slices.Contains(t.AdminGroups, g) }) {
+		t.event(obj, corev1.EventTypeWarning, eventReasonTamperAllowed,
+			"%s by policy admin %s allowed: %s", operation, username, what)
+		return nil
+	}
+
+	t.event(obj, corev1.EventTypeWarning, eventReasonTamperDenied,
+		"%s by %s denied: %s", operation, username, what)
+	return fmt.Errorf("%s %q is enforcing and tamper protection is enabled: %s requires membership in one of the policy admin groups %v",
+		kind, name, what, t.AdminGroups)
+}
+
+func (t TamperProtection) event(obj runtime.Object, eventType, reason, messageFmt string, args ...any) {
+	if t.Recorder == nil {
+		return
+	}
+	t.Recorder.Eventf(obj, eventType, reason, messageFmt, args...)
+}
+
+// narrowedTarget lists the ways newT selects fewer objects or actions than oldT.
+// Selector changes cannot be compared in general, so any change other than
+// dropping a selector altogether counts as narrowing.
+func narrowedTarget(oldT, newT *freezeoperatorv1alpha1.TargetSpec, oldDeny, newDeny []freezeoperatorv1alpha1.Action) []string {
+	var out []string
+	for _, k := range oldT.Kinds {
+		if !slices.Contains(newT.Kinds, k) {
+			out = append(out, fmt.Sprintf("removes kind %s from spec.target.kinds", k))
+		}
+	}
+	for _, a := range oldDeny {
+		if !slices.Contains(newDeny, a) {
+			out = append(out, fmt.Sprintf("removes action %s from spec.rules.deny", a))
+		}
+	}
+	if selectorNarrowed(oldT.NamespaceSelector, newT.NamespaceSelector) {
+		out = append(out, "changes spec.target.namespaceSelector")
+	}
+	if selectorNarrowed(oldT.ObjectSelector, newT.ObjectSelector) {
+		out = append(out, "changes spec.target.objectSelector")
+	}
+	return out
+}
+
+// loosenedRateLimit lists the ways newRL admits more changes than oldRL.
+func loosenedRateLimit(oldRL, newRL *freezeoperatorv1alpha1.RateLimitSpec) []string {
+	if oldRL == nil {
+		return nil
+	}
+	if newRL == nil {
+		return []string{"removes spec.rules.rateLimit"}
+	}
+	var out []string
+	for _, a := range oldRL.Actions {
+		if !slices.Contains(newRL.Actions, a) {
+			out = append(out, fmt.Sprintf("removes action %s from spec.rules.rateLimit.actions", a))
+		}
+	}
+	if newRL.Limit > oldRL.Limit {
+		out = append(out, fmt.Sprintf("raises spec.rules.rateLimit.limit from %d to %d", oldRL.Limit, newRL.Limit))
+	}
+	if newRL.Period.Duration < oldRL.Period.Duration {
+		out = append(out, fmt.Sprintf("shortens spec.rules.rateLimit.period from %s to %s", oldRL.Period.Duration, newRL.Period.Duration))
+	}
+	if oldRL.Scope != freezeoperatorv1alpha1.RateLimitScopeNamespace && newRL.Scope == freezeoperatorv1alpha1.RateLimitScopeNamespace {
+		out = append(out, "changes spec.rules.rateLimit.scope to Namespace")
+	}
+	return out
+}
+
+// loosenedBehavior lists the behavior changes that let through changes oldB denies.
+func loosenedBehavior(oldB, newB *freezeoperatorv1alpha1.PolicyBehaviorSpec) []string {
+	if !oldB.PauseDeployments && newB.PauseDeployments {
+		return []string{"enables spec.behavior.pauseDeployments"}
+	}
+	return nil
+}
+
+func selectorNarrowed(oldSel, newSel *metav1.LabelSelector) bool {
+	if newSel == nil {
+		return false
+	}
+	return !equality.Semantic.DeepEqual(oldSel, newSel)
+}
@@ -109,7 +109,7 @@ var _ = BeforeSuite(func() {
 	})
 	Expect(err).NotTo(HaveOccurred())
 
-	err = SetupMaintenanceWindowWebhookWithManager(mgr)
+	err = SetupMaintenanceWindowWebhookWithManager(mgr, TamperProtection{})
 	Expect(err).NotTo(HaveOccurred())
 
 	// +kubebuilder:scaffold:webhook
@@ -0,0 +1,70 @@
+package workloads
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/metrics"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/policy"
+)
+
+const eventReasonBreakGlass = "BreakGlass"
+
+// admitBreakGlass admits a request allowed by the break-glass annotation. Every such
+// override is recorded as a Warning Event on the workload, a metric, an operator log
+// entry and API server audit annotations.
+func (v *Validator) admitBreakGlass(req admission.Request, dec policy.Decision, kind freezev1alpha1.TargetKind, action freezev1alpha1.Action, reason string) admission.Response {
+	policyType, policyName := "", ""
+	if dec.MatchedPolicy != nil {
+		policyType = string(dec.MatchedPolicy.Kind)
+		policyName = dec.MatchedPolicy.Name
+	}
+	user := req.UserInfo.Username
+
+	ctrl.Log.WithName("webhook").WithName("workloads").WithName("audit").Info("break-glass override",
+		"namespace", req.Namespace, "name", req.Name, "kind", kind, "action", action,
+		"user", user, "groups", req.UserInfo.Groups, "policy", dec.MatchedPolicy,
+		"reason", reason, "dryRun", isDryRun(req), "uid", req.UID)
+
+	resp := admission.Allowed(dec.Reason)
+	resp.AuditAnnotations = map[string]string{
+		"break-glass-user":   user,
+		"break-glass-reason": reason,
+		"overridden-policy":  fmt.Sprintf("%s/%s", policyType, policyName),
+	}
+	if isDryRun(req) {
+		return resp
+	}
+
+	metrics.AllowedRequests.WithLabelValues(req.Namespace, string(kind), string(action)).Inc()
+	metrics.BreakGlassOverrides.WithLabelValues(policyType, policyName, req.Namespace, string(kind), string(action)).Inc()
+	if dec.MatchedPolicy != nil {
+		v.Reports.RecordBreakGlass(reportRef(*dec.MatchedPolicy), freezev1alpha1.BreakGlassUse{
+			Time:      metav1.Now(),
+			User:      user,
+			Namespace: req.Namespace,
+			Kind:      kind,
+			Name:      req.Name,
+			Action:    action,
+			Reason:    reason,
+		})
+	}
+
+	if v.Recorder != nil {
+		ref := &corev1.ObjectReference{
+			APIVersion: schema.GroupVersion{Group: req.Kind.Group, Version: req.Kind.Version}.String(),
+			Kind:       req.Kind.Kind,
+			Namespace:  req.Namespace,
+			Name:       req.Name,
+		}
+		v.Recorder.Eventf(ref, corev1.EventTypeWarning, eventReasonBreakGlass,
+			"%s by %s overrode %s/%s with break-glass: %s", action, user, policyType, policyName, reason)
+	}
+	return resp
+}
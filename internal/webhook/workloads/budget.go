@@ -0,0 +1,61 @@
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/policy"
+)
+
+// consumeBudgets takes one change from every rate limit in dec. It returns a response
+// when the request must not be admitted, along with the exhausted budget if that is
+// the cause. Budgets already consumed for a request that is not admitted are released.
+func (v *Validator) consumeBudgets(ctx context.Context, dec policy.Decision, dryRun bool) (*policy.Budget, *admission.Response) {
+	if v.Budgets == nil {
+		resp := admission.Errored(500, fmt.Errorf("%s %s sets a rate limit but change budgets are not configured", dec.Budgets[0].Policy.Kind, dec.Budgets[0].Policy.Name))
+		return nil, &resp
+	}
+
+	now := dec.EvaluationTime
+	var consumed []policy.Budget
+	release := func() {
+		for _, b := range consumed {
+			if err := v.Budgets.Release(ctx, b, now); err != nil {
+				ctrl.Log.WithName("webhook").WithName("workloads").Error(err, "release change budget", "policy", b.Policy)
+			}
+		}
+	}
+
+	for i := range dec.Budgets {
+		b := dec.Budgets[i]
+		ok, freeAt, err := v.Budgets.Consume(ctx, b, now, dryRun)
+		if err != nil {
+			release()
+			resp := admission.Errored(500, err)
+			return nil, &resp
+		}
+		if !ok {
+			release()
+			resp := admission.Denied(formatBudgetMessage(b, freeAt, now))
+			return &b, &resp
+		}
+		if !dryRun {
+			consumed = append(consumed, b)
+		}
+	}
+	return nil, nil
+}
+
+func formatBudgetMessage(b policy.Budget, freeAt, now time.Time) string {
+	scope := "cluster-wide"
+	if b.Namespace != "" {
+		scope = "in namespace " + b.Namespace
+	}
+	return fmt.Sprintf("Denied: change budget of %s %s is exhausted (%d per %s %s). Budget frees up at %s (in %s)",
+		b.Policy.Kind, b.Policy.Name, b.Limit, b.Period, scope,
+		freeAt.UTC().Format(time.RFC3339), policy.FormatLeadTime(freeAt.Sub(now)))
+}
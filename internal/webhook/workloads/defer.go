@@ -0,0 +1,98 @@
+package workloads
+
+// +kubebuilder:rbac:groups=freeze-operator.io,resources=deferredchanges,verbs=create
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/diff"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/policy"
+)
+
+// deferrable reports whether a denied request is recorded as a DeferredChange.
+// GitOps agents are skipped: they re-apply the change themselves once it is allowed.
+func deferrable(req admission.Request, dec policy.Decision, isGitOps bool) bool {
+	if !dec.DeferChanges || dec.MatchedPolicy == nil || isGitOps || isDryRun(req) || req.SubResource != "" {
+		return false
+	}
+	return req.Operation == admissionv1.Create || req.Operation == admissionv1.Update
+}
+
+// deferChange records a denied request as a DeferredChange in the workload namespace
+// and returns its name.
+func (v *Validator) deferChange(ctx context.Context, req admission.Request, dec policy.Decision, kind freezev1alpha1.TargetKind, action freezev1alpha1.Action) (string, error) {
+	object, err := diff.StripServerFields(req.Object.Raw)
+	if err != nil {
+		return "", err
+	}
+	var submitted metav1.PartialObjectMetadata
+	if err := json.Unmarshal(req.Object.Raw, &submitted); err != nil {
+		return "", fmt.Errorf("decode object: %w", err)
+	}
+	name := req.Name
+	if name == "" {
+		name = submitted.Name
+	}
+
+	dc := &freezev1alpha1.DeferredChange{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: deferredChangePrefix(name, submitted.GenerateName, kind),
+			Namespace:    req.Namespace,
+		},
+		Spec: freezev1alpha1.DeferredChangeSpec{
+			Kind:      kind,
+			Name:      name,
+			Operation: freezev1alpha1.DeferredOperation(req.Operation),
+			Action:    action,
+			Object:    runtime.RawExtension{Raw: object},
+			Requester: freezev1alpha1.DeferredChangeRequester{
+				Username: req.UserInfo.Username,
+				Groups:   req.UserInfo.Groups,
+			},
+			BlockingPolicy: freezev1alpha1.DeferredChangePolicyRef{
+				Kind: string(dec.MatchedPolicy.Kind),
+				Name: dec.MatchedPolicy.Name,
+			},
+		},
+	}
+	if req.Operation == admissionv1.Update {
+		var old metav1.PartialObjectMetadata
+		if err := json.Unmarshal(req.OldObject.Raw, &old); err != nil {
+			return "", fmt.Errorf("decode old object: %w", err)
+		}
+		base, err := diff.StripServerFields(req.OldObject.Raw)
+		if err != nil {
+			return "", fmt.Errorf("old object: %w", err)
+		}
+		dc.Spec.BaseGeneration = old.Generation
+		dc.Spec.BaseObject = &runtime.RawExtension{Raw: base}
+	}
+
+	if err := v.Client.Create(ctx, dc); err != nil {
+		return "", fmt.Errorf("create DeferredChange: %w", err)
+	}
+	return dc.Name, nil
+}
+
+// deferredChangePrefix returns the generateName of a DeferredChange for a workload.
+// A CREATE that left the name to generateName has no name yet; its own generateName
+// is used then, or the kind.
+func deferredChangePrefix(name, generateName string, kind freezev1alpha1.TargetKind) string {
+	switch {
+	case name != "":
+		return name + "-"
+	case generateName != "":
+		return generateName
+	default:
+		return strings.ToLower(string(kind)) + "-"
+	}
+}
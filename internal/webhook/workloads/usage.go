@@ -0,0 +1,11 @@
+package workloads
+
+// +kubebuilder:rbac:groups=freeze-operator.io,resources=freezeexceptions/status,verbs=get;update;patch
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func isDryRun(req admission.Request) bool {
+	return req.DryRun != nil && *req.DryRun
+}
@@ -4,11 +4,13 @@ package workloads
 // +kubebuilder:rbac:groups=freeze-operator.io,resources=maintenancewindows,verbs=get;list;watch
 // +kubebuilder:rbac:groups=freeze-operator.io,resources=changefreezes,verbs=get;list;watch
 // +kubebuilder:rbac:groups=freeze-operator.io,resources=freezeexceptions,verbs=get;list;watch
-// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get
-// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get
+// +kubebuilder:rbac:groups=freeze-operator.io,resources=rolloutlimits,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"slices"
@@ -21,14 +23,17 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/budget"
 	"github.com/jamalshahverdiev/kube-freeze-operator/internal/diff"
 	"github.com/jamalshahverdiev/kube-freeze-operator/internal/metrics"
 	"github.com/jamalshahverdiev/kube-freeze-operator/internal/policy"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/report"
 )
 
 const (
@@ -42,6 +47,23 @@ type Validator struct {
 	Decoder admission.Decoder
 
 	OperatorNamespace string
+
+	// RequireApprovedExceptions and MinApprovals are passed to the policy evaluator.
+	RequireApprovedExceptions bool
+	MinApprovals              int32
+
+	// BreakGlassGroups is passed to the policy evaluator. Recorder receives the
+	// Warning Event emitted for every break-glass override.
+	BreakGlassGroups []string
+	Recorder         record.EventRecorder
+
+	// Budgets consumes the rate limits of admitted requests. Requests subject to a
+	// rate limit are rejected when it is nil.
+	Budgets *budget.Counter
+
+	// Reports receives the denials, exception uses and break-glass overrides of each
+	// freeze occurrence for its FreezeReport. Nothing is recorded when nil.
+	Reports *report.Aggregator
 }
 
 func (v *Validator) Handle(ctx context.Context, req admission.Request) admission.Response {
@@ -72,9 +94,13 @@ func (v *Validator) Handle(ctx context.Context, req admission.Request) admission
 		return g == "system:serviceaccounts:argocd" || g == "system:serviceaccounts:flux-system"
 	})
 	var (
-		kind      freezev1alpha1.TargetKind
-		action    freezev1alpha1.Action
-		objLabels map[string]string
+		kind         freezev1alpha1.TargetKind
+		action       freezev1alpha1.Action
+		objLabels    map[string]string
+		oldObjLabels map[string]string
+		change       *diff.PodTemplateChange
+		breakGlass   string
+		paused       bool
 	)
 
 	// NOTE: `kubectl scale` typically hits the /scale subresource (e.g. deployments/scale),
@@ -121,13 +147,17 @@ func (v *Validator) Handle(ctx context.Context, req admission.Request) admission
 		}
 		kind = k
 
-		a, labels, err := v.classify(req, kind)
+		c, err := v.classify(req, kind)
 		if err != nil {
 			log.Error(err, "classify request")
 			return admission.Errored(400, err)
 		}
-		action = a
-		objLabels = labels
+		action = c.action
+		objLabels = c.labels
+		oldObjLabels = c.oldLabels
+		change = c.change
+		breakGlass = c.breakGlass
+		paused = c.paused
 	}
 
 	ns := req.Namespace
@@ -148,21 +178,74 @@ func (v *Validator) Handle(ctx context.Context, req admission.Request) admission
 		return admission.Allowed("namespace is terminating: bypass freeze policies")
 	}
 
-	ev := &policy.Evaluator{Client: v.Client}
-	dec, err := ev.Evaluate(ctx, policy.Input{
-		Now:           time.Now().UTC(),
-		Namespace:     ns,
-		NamespaceTags: nsObj.Labels,
-		Kind:          kind,
-		Action:        action,
-		ObjectLabels:  objLabels,
-		Username:      req.UserInfo.Username,
-		Groups:        req.UserInfo.Groups,
-	})
+	ev := &policy.Evaluator{
+		Client:                    v.Client,
+		RequireApprovedExceptions: v.RequireApprovedExceptions,
+		BreakGlassGroups:          v.BreakGlassGroups,
+		MinApprovals:              v.MinApprovals,
+	}
+	in := policy.Input{
+		Now:             time.Now().UTC(),
+		Namespace:       ns,
+		NamespaceTags:   nsObj.Labels,
+		Kind:            kind,
+		Action:          action,
+		Name:            req.Name,
+		ObjectLabels:    objLabels,
+		OldObjectLabels: oldObjLabels,
+		Change:          change,
+		Username:        req.UserInfo.Username,
+		Groups:          req.UserInfo.Groups,
+
+		BreakGlassReason: breakGlass,
+	}
+	dec, err := ev.Evaluate(ctx, in)
 	if err != nil {
 		return admission.Errored(500, err)
 	}
 
+	if dec.Allowed && dec.MatchedOverride != nil && dec.MatchedOverride.Kind == policy.PolicyKindBreakGlass {
+		return v.admitBreakGlass(req, dec, kind, action, breakGlass)
+	}
+
+	if dec.Allowed && dec.MatchedOverride != nil && !isDryRun(req) {
+		object := fmt.Sprintf("%s %s/%s", kind, ns, req.Name)
+		limited, err := policy.RecordExceptionUse(ctx, v.Client, reader, dec.MatchedOverride.Name, req.UserInfo.Username, object, dec.EvaluationTime)
+		if errors.Is(err, policy.ErrExceptionExhausted) {
+			// Another request spent the last use after this one was evaluated; another
+			// exception may still cover the request, so evaluate it once more without it.
+			log.Info("exception exhausted, re-evaluating", "namespace", ns, "kind", kind, "action", action, "user", req.UserInfo.Username, "exception", dec.MatchedOverride.Name)
+			in.ExcludeExceptions = []string{dec.MatchedOverride.Name}
+			if dec, err = ev.Evaluate(ctx, in); err != nil {
+				return admission.Errored(500, err)
+			}
+			limited = false
+			if dec.Allowed && dec.MatchedOverride != nil {
+				limited, err = policy.RecordExceptionUse(ctx, v.Client, reader, dec.MatchedOverride.Name, req.UserInfo.Username, object, dec.EvaluationTime)
+			}
+		}
+		switch {
+		case errors.Is(err, policy.ErrExceptionExhausted):
+			log.Info("denied", "namespace", ns, "kind", kind, "action", action, "user", req.UserInfo.Username, "exception", dec.MatchedOverride.Name, "reason", "exception exhausted")
+			return admission.Denied(fmt.Sprintf("Denied: FreezeException %q has no uses left", dec.MatchedOverride.Name))
+		case err != nil && limited:
+			return admission.Errored(500, fmt.Errorf("record use of FreezeException %q: %w", dec.MatchedOverride.Name, err))
+		case err != nil:
+			log.Error(err, "record exception use", "exception", dec.MatchedOverride.Name)
+		}
+	}
+
+	if dec.Allowed && len(dec.Budgets) > 0 {
+		if exhausted, resp := v.consumeBudgets(ctx, dec, isDryRun(req)); resp != nil {
+			if exhausted != nil {
+				metrics.DeniedRequests.WithLabelValues(string(exhausted.Policy.Kind), exhausted.Policy.Name, ns, string(kind), string(action)).Inc()
+				v.Reports.RecordDenied(reportRef(exhausted.Policy), req.UserInfo.Username, ns, action)
+				log.Info("denied", "namespace", ns, "kind", kind, "action", action, "user", req.UserInfo.Username, "policy", exhausted.Policy, "reason", "change budget exhausted")
+			}
+			return *resp
+		}
+	}
+
 	if dec.Allowed {
 		// Record allowed request metric
 		metrics.AllowedRequests.WithLabelValues(ns, string(kind), string(action)).Inc()
@@ -176,9 +259,21 @@ func (v *Validator) Handle(ctx context.Context, req admission.Request) admission
 				policyName = dec.MatchedPolicy.Name
 			}
 			metrics.ExceptionOverrides.WithLabelValues(dec.MatchedOverride.Name, policyType, policyName).Inc()
+			if dec.MatchedPolicy != nil {
+				v.Reports.RecordException(reportRef(*dec.MatchedPolicy), dec.MatchedOverride.Name, req.UserInfo.Username)
+			}
 		}
 
-		return admission.Allowed("allowed by policy")
+		return admission.Allowed("allowed by policy").WithWarnings(upcomingWarnings(dec)...)
+	}
+
+	if dec.PausesDeployments && paused && kind == freezev1alpha1.TargetKindDeployment && action == freezev1alpha1.ActionRollout {
+		// The policies pause Deployments instead of denying them; the new template
+		// is stored but not rolled out until the freeze ends.
+		metrics.AllowedRequests.WithLabelValues(ns, string(kind), string(action)).Inc()
+		return admission.Allowed("deployment stays paused").WithWarnings(fmt.Sprintf(
+			"Deployment %s/%s is paused by %s/%s; the rollout starts when the freeze ends",
+			ns, req.Name, dec.MatchedPolicy.Kind, dec.MatchedPolicy.Name))
 	}
 
 	// Record denied request metric
@@ -190,59 +285,149 @@ func (v *Validator) Handle(ctx context.Context, req admission.Request) admission
 			string(kind),
 			string(action),
 		).Inc()
+		v.Reports.RecordDenied(reportRef(*dec.MatchedPolicy), req.UserInfo.Username, ns, action)
 	}
 
 	msg := formatDenyMessage(dec, isGitOps)
+	if deferrable(req, dec, isGitOps) {
+		if name, err := v.deferChange(ctx, req, dec, kind, action); err != nil {
+			log.Error(err, "defer change", "namespace", ns, "name", req.Name)
+		} else {
+			msg += fmt.Sprintf(". The change was recorded as DeferredChange %s/%s and will be applied once the policies allow it", ns, name)
+		}
+	}
 	log.Info("denied", "namespace", ns, "kind", kind, "action", action, "user", req.UserInfo.Username, "policy", dec.MatchedPolicy, "reason", dec.Reason)
 	return admission.Denied(msg)
 }
 
-func (v *Validator) classify(req admission.Request, kind freezev1alpha1.TargetKind) (freezev1alpha1.Action, map[string]string, error) {
+// reportRef returns the reference a FreezeReport uses for ref.
+func reportRef(ref policy.PolicyRef) freezev1alpha1.PolicyReference {
+	return freezev1alpha1.PolicyReference{Kind: string(ref.Kind), Name: ref.Name}
+}
+
+// upcomingWarnings returns one admission warning per policy that will start to deny
+// the request within its notice period.
+func upcomingWarnings(dec policy.Decision) []string {
+	warnings := make([]string, 0, len(dec.Upcoming))
+	for _, u := range dec.Upcoming {
+		warnings = append(warnings, u.Message(dec.EvaluationTime))
+	}
+	return warnings
+}
+
+// classification is what the validator learns from a workload request.
+type classification struct {
+	action    freezev1alpha1.Action
+	labels    map[string]string
+	oldLabels map[string]string
+	change    *diff.PodTemplateChange
+
+	// breakGlass is the break-glass annotation of the new object, or of the deleted one.
+	breakGlass string
+
+	// paused reports whether an updated Deployment stays paused.
+	paused bool
+}
+
+// classify returns the action together with the labels to match policies against.
+// For UPDATE both the new and the old labels are returned so that relabelling an
+// object cannot move it out of an active policy's objectSelector. DELETE requests
+// only carry the old object, so its labels are the ones being evaluated. CREATE and
+// UPDATE also report the pod template change for exception constraints.
+func (v *Validator) classify(req admission.Request, kind freezev1alpha1.TargetKind) (classification, error) {
 	switch req.Operation {
 	case admissionv1.Create:
-		labels, err := v.decodeLabels(req.Object, kind)
-		return freezev1alpha1.ActionCreate, labels, err
+		obj, err := v.decode(req.Object, kind)
+		if err != nil {
+			return classification{}, err
+		}
+		accessor, err := metaAccessor(obj)
+		if err != nil {
+			return classification{}, err
+		}
+		tmpl, err := diff.PodTemplate(kind, obj)
+		if err != nil {
+			return classification{}, err
+		}
+		change := diff.ComparePodTemplates(nil, tmpl)
+		return classification{
+			action:     freezev1alpha1.ActionCreate,
+			labels:     accessor.GetLabels(),
+			change:     &change,
+			breakGlass: accessor.GetAnnotations()[freezev1alpha1.AnnotationBreakGlass],
+		}, nil
 	case admissionv1.Delete:
-		labels, err := v.decodeLabels(req.OldObject, kind)
-		return freezev1alpha1.ActionDelete, labels, err
+		obj, err := v.decode(req.OldObject, kind)
+		if err != nil {
+			return classification{}, err
+		}
+		accessor, err := metaAccessor(obj)
+		if err != nil {
+			return classification{}, err
+		}
+		return classification{
+			action:     freezev1alpha1.ActionDelete,
+			labels:     accessor.GetLabels(),
+			breakGlass: accessor.GetAnnotations()[freezev1alpha1.AnnotationBreakGlass],
+		}, nil
 	case admissionv1.Update:
-		oldObj, newObj, labels, err := v.decodeOldNew(req, kind)
+		oldObj, newObj, err := v.decodeOldNew(req, kind)
+		if err != nil {
+			return classification{}, err
+		}
+		newAccessor, err := metaAccessor(newObj)
+		if err != nil {
+			return classification{}, err
+		}
+		oldAccessor, err := metaAccessor(oldObj)
 		if err != nil {
-			return "", nil, err
+			return classification{}, err
 		}
 		a, err := diff.ClassifyUpdate(kind, oldObj, newObj)
-		return a, labels, err
+		if err != nil {
+			return classification{}, err
+		}
+		oldTmpl, err := diff.PodTemplate(kind, oldObj)
+		if err != nil {
+			return classification{}, err
+		}
+		newTmpl, err := diff.PodTemplate(kind, newObj)
+		if err != nil {
+			return classification{}, err
+		}
+		change := diff.ComparePodTemplates(oldTmpl, newTmpl)
+		dep, isDeployment := newObj.(*appsv1.Deployment)
+		return classification{
+			action:     a,
+			labels:     newAccessor.GetLabels(),
+			oldLabels:  nonNilLabels(oldAccessor.GetLabels()),
+			change:     &change,
+			breakGlass: newAccessor.GetAnnotations()[freezev1alpha1.AnnotationBreakGlass],
+			paused:     isDeployment && dep.Spec.Paused,
+		}, nil
 	default:
-		return "", nil, fmt.Errorf("unsupported operation: %s", req.Operation)
-	}
-}
-
-func (v *Validator) decodeLabels(raw runtime.RawExtension, kind freezev1alpha1.TargetKind) (map[string]string, error) {
-	obj, err := v.decode(raw, kind)
-	if err != nil {
-		return nil, err
-	}
-	accessor, err := metaAccessor(obj)
-	if err != nil {
-		return nil, err
+		return classification{}, fmt.Errorf("unsupported operation: %s", req.Operation)
 	}
-	return accessor.GetLabels(), nil
 }
 
-func (v *Validator) decodeOldNew(req admission.Request, kind freezev1alpha1.TargetKind) (runtime.Object, runtime.Object, map[string]string, error) {
+func (v *Validator) decodeOldNew(req admission.Request, kind freezev1alpha1.TargetKind) (runtime.Object, runtime.Object, error) {
 	oldObj, err := v.decode(req.OldObject, kind)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, err
 	}
 	newObj, err := v.decode(req.Object, kind)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, err
 	}
-	accessor, err := metaAccessor(newObj)
-	if err != nil {
-		return nil, nil, nil, err
+	return oldObj, newObj, nil
+}
+
+// nonNilLabels distinguishes "old object had no labels" from "no old object".
+func nonNilLabels(labels map[string]string) map[string]string {
+	if labels == nil {
+		return map[string]string{}
 	}
-	return oldObj, newObj, accessor.GetLabels(), nil
+	return labels
 }
 
 func (v *Validator) decode(raw runtime.RawExtension, kind freezev1alpha1.TargetKind) (runtime.Object, error) {
@@ -332,4 +517,5 @@ func metaAccessor(obj runtime.Object) (metav1Object, error) {
 
 type metav1Object interface {
 	GetLabels() map[string]string
+	GetAnnotations() map[string]string
 }
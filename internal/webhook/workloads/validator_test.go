@@ -10,14 +10,22 @@ import (
 	admissionv1 "k8s.io/api/admission/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	authv1 "k8s.io/api/authentication/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	freezev1alpha1 "github.com/jamalshahverdiev/kube-freeze-operator/api/v1alpha1"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/budget"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/policy"
+	"github.com/jamalshahverdiev/kube-freeze-operator/internal/report"
 )
 
 // ---------------------------------------------------------------------------
@@ -29,6 +37,7 @@ func testScheme(t *testing.T) *runtime.Scheme {
 	s := runtime.NewScheme()
 	must(t, corev1.AddToScheme(s))
 	must(t, appsv1.AddToScheme(s))
+	must(t, coordinationv1.AddToScheme(s))
 	must(t, freezev1alpha1.AddToScheme(s))
 	return s
 }
@@ -42,6 +51,8 @@ func must(t *testing.T, err error) {
 
 func ptrInt32(v int32) *int32 { return &v }
 
+func ptrBool(v bool) *bool { return &v }
+
 // makeDeployment returns a minimal Deployment ready for JSON serialization.
 func makeDeployment(lbls map[string]string, image string, replicas int32) *appsv1.Deployment {
 	return &appsv1.Deployment{
@@ -162,7 +173,7 @@ func activeFreezeException(name, ns string, allow []freezev1alpha1.Action) *free
 		Spec: freezev1alpha1.FreezeExceptionSpec{
 			ActiveFrom: metav1.Time{Time: now.Add(-time.Minute)},
 			ActiveTo:   metav1.Time{Time: now.Add(time.Minute)},
-			Target: freezev1alpha1.TargetSpec{
+			Target: &freezev1alpha1.TargetSpec{
 				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": ns}},
 				Kinds:             []freezev1alpha1.TargetKind{freezev1alpha1.TargetKindDeployment},
 			},
@@ -182,6 +193,18 @@ func prodNamespace() *corev1.Namespace {
 }
 
 // buildValidator constructs a ready-to-use Validator backed by a fake client.
+// exceptionApproval returns an approval of the given generation of exception by approver.
+func exceptionApproval(name, exception, approver string, generation int64) *freezev1alpha1.FreezeExceptionApproval {
+	return &freezev1alpha1.FreezeExceptionApproval{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: freezev1alpha1.FreezeExceptionApprovalSpec{
+			ExceptionName:       exception,
+			ExceptionGeneration: generation,
+			Approver:            approver,
+		},
+	}
+}
+
 func buildValidator(t *testing.T, objs ...runtime.Object) *Validator {
 	t.Helper()
 	s := testScheme(t)
@@ -192,6 +215,7 @@ func buildValidator(t *testing.T, objs ...runtime.Object) *Validator {
 	cl := fake.NewClientBuilder().
 		WithScheme(s).
 		WithRuntimeObjects(clientObjs...).
+		WithStatusSubresource(&freezev1alpha1.FreezeException{}).
 		Build()
 
 	decoder := admission.NewDecoder(s)
@@ -549,7 +573,7 @@ func TestValidator_FreezeException_UserConstraint_AllowedForMatchingUser(t *test
 		Spec: freezev1alpha1.FreezeExceptionSpec{
 			ActiveFrom: metav1.Time{Time: now.Add(-time.Minute)},
 			ActiveTo:   metav1.Time{Time: now.Add(time.Minute)},
-			Target: freezev1alpha1.TargetSpec{
+			Target: &freezev1alpha1.TargetSpec{
 				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
 				Kinds:             []freezev1alpha1.TargetKind{freezev1alpha1.TargetKindDeployment},
 			},
@@ -577,7 +601,7 @@ func TestValidator_FreezeException_UserConstraint_DeniedForOtherUser(t *testing.
 		Spec: freezev1alpha1.FreezeExceptionSpec{
 			ActiveFrom: metav1.Time{Time: now.Add(-time.Minute)},
 			ActiveTo:   metav1.Time{Time: now.Add(time.Minute)},
-			Target: freezev1alpha1.TargetSpec{
+			Target: &freezev1alpha1.TargetSpec{
 				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
 				Kinds:             []freezev1alpha1.TargetKind{freezev1alpha1.TargetKindDeployment},
 			},
@@ -605,7 +629,7 @@ func TestValidator_FreezeException_RequireLabels_Matched_Allowed(t *testing.T) {
 		Spec: freezev1alpha1.FreezeExceptionSpec{
 			ActiveFrom: metav1.Time{Time: now.Add(-time.Minute)},
 			ActiveTo:   metav1.Time{Time: now.Add(time.Minute)},
-			Target: freezev1alpha1.TargetSpec{
+			Target: &freezev1alpha1.TargetSpec{
 				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
 				Kinds:             []freezev1alpha1.TargetKind{freezev1alpha1.TargetKindDeployment},
 			},
@@ -633,7 +657,7 @@ func TestValidator_FreezeException_RequireLabels_NotMatched_Denied(t *testing.T)
 		Spec: freezev1alpha1.FreezeExceptionSpec{
 			ActiveFrom: metav1.Time{Time: now.Add(-time.Minute)},
 			ActiveTo:   metav1.Time{Time: now.Add(time.Minute)},
-			Target: freezev1alpha1.TargetSpec{
+			Target: &freezev1alpha1.TargetSpec{
 				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
 				Kinds:             []freezev1alpha1.TargetKind{freezev1alpha1.TargetKindDeployment},
 			},
@@ -666,3 +690,491 @@ func TestValidator_SchemaRegistered_for(t *testing.T) {
 		Group: "freeze-operator.io", Version: "v1alpha1", Kind: "ChangeFreeze",
 	}))
 }
+
+// criticalChangeFreeze returns an active ChangeFreeze scoped by objectSelector tier=critical.
+func criticalChangeFreeze(name string, deny []freezev1alpha1.Action) *freezev1alpha1.ChangeFreeze {
+	cf := activeChangeFreeze(name, deny)
+	cf.Spec.Target.ObjectSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "critical"}}
+	return cf
+}
+
+// 24. Removing the selected label together with an image change is still denied.
+func TestValidator_ObjectSelector_RelabelWithRollout_Denied(t *testing.T) {
+	g := NewWithT(t)
+	cf := criticalChangeFreeze("cf-critical", []freezev1alpha1.Action{freezev1alpha1.ActionRollout})
+	v := buildValidator(t, prodNamespace(), cf)
+
+	old := makeDeployment(map[string]string{"app": "x", "tier": "critical"}, "img:v1", 1)
+	newDep := makeDeployment(map[string]string{"app": "x"}, "img:v2", 1)
+
+	resp := v.Handle(context.Background(), makeUpdateRequest(t, old, newDep, []string{"system:authenticated"}))
+	g.Expect(resp.Allowed).To(BeFalse(), "old labels matched the freeze, rollout must be denied")
+	g.Expect(resp.Result.Message).To(ContainSubstring("cf-critical"))
+}
+
+// 25. A label-only change that moves the object out of scope is denied on its own,
+// even when the classified action is not in the deny list.
+func TestValidator_ObjectSelector_LabelEscape_Denied(t *testing.T) {
+	g := NewWithT(t)
+	cf := criticalChangeFreeze("cf-escape", []freezev1alpha1.Action{freezev1alpha1.ActionScale})
+	v := buildValidator(t, prodNamespace(), cf)
+
+	old := makeDeployment(map[string]string{"app": "x", "tier": "critical"}, "img:v1", 1)
+	newDep := makeDeployment(map[string]string{"app": "x"}, "img:v1", 1)
+	newDep.Spec.Template.Labels = old.Spec.Template.Labels
+
+	resp := v.Handle(context.Background(), makeUpdateRequest(t, old, newDep, []string{"system:authenticated"}))
+	g.Expect(resp.Allowed).To(BeFalse(), "moving the object out of an active policy scope must be denied")
+	g.Expect(resp.Result.Message).To(ContainSubstring("out of the policy scope"))
+}
+
+// 26. Adding a label that the exception requires does not qualify the change for it.
+func TestValidator_FreezeException_RequireLabels_AddedInSameUpdate_Denied(t *testing.T) {
+	g := NewWithT(t)
+	cf := activeChangeFreeze("cf-add-label", []freezev1alpha1.Action{freezev1alpha1.ActionRollout})
+	ex := activeFreezeException("ex-add-label", "prod", []freezev1alpha1.Action{freezev1alpha1.ActionRollout})
+	ex.Spec.Constraints = &freezev1alpha1.FreezeExceptionConstraintsSpec{
+		RequireLabels: map[string]string{"hotfix": "true"},
+	}
+	v := buildValidator(t, prodNamespace(), cf, ex)
+
+	old := makeDeployment(map[string]string{"app": "x"}, "img:v1", 1)
+	newDep := makeDeployment(map[string]string{"app": "x", "hotfix": "true"}, "img:v2", 1)
+
+	resp := v.Handle(context.Background(), makeUpdateRequest(t, old, newDep, []string{"system:authenticated"}))
+	g.Expect(resp.Allowed).To(BeFalse(), "exception must cover the object before the change as well")
+}
+
+// 27. Unapproved exceptions are ignored when approval is required.
+func TestValidator_RequireApprovedExceptions_UnapprovedIgnored(t *testing.T) {
+	g := NewWithT(t)
+	cf := activeChangeFreeze("cf-approval", []freezev1alpha1.Action{freezev1alpha1.ActionRollout})
+	ex := activeFreezeException("ex-unapproved", "prod", []freezev1alpha1.Action{freezev1alpha1.ActionRollout})
+	v := buildValidator(t, prodNamespace(), cf, ex)
+	v.RequireApprovedExceptions = true
+
+	old := makeDeployment(map[string]string{"app": "x"}, "img:v1", 1)
+	newDep := makeDeployment(map[string]string{"app": "x"}, "img:v2", 1)
+
+	resp := v.Handle(context.Background(), makeUpdateRequest(t, old, newDep, []string{"system:authenticated"}))
+	g.Expect(resp.Allowed).To(BeFalse(), "exception without approvedBy must not override the freeze")
+
+	ex.Spec.ApprovedBy = "release-manager"
+	v = buildValidator(t, prodNamespace(), cf, ex)
+	v.RequireApprovedExceptions = true
+	resp = v.Handle(context.Background(), makeUpdateRequest(t, old, newDep, []string{"system:authenticated"}))
+	g.Expect(resp.Allowed).To(BeTrue(), "approved exception must override: %s", resp.Result.Message)
+}
+
+// 28. Exceptions below their approval quorum are ignored; approvals of an earlier
+// generation do not count, and neither do approvals only recorded in status.
+func TestValidator_FreezeException_RequiredApprovals_Quorum(t *testing.T) {
+	g := NewWithT(t)
+	cf := activeChangeFreeze("cf-quorum", []freezev1alpha1.Action{freezev1alpha1.ActionRollout})
+	ex := activeFreezeException("ex-quorum", "prod", []freezev1alpha1.Action{freezev1alpha1.ActionRollout})
+	ex.Generation = 2
+	ex.Spec.RequiredApprovals = 2
+	// A forged status must not reach quorum on its own.
+	ex.Status.Approvals = []freezev1alpha1.FreezeExceptionApproverStatus{
+		{User: "alice", Approval: "forged-alice", Generation: 2},
+		{User: "bob", Approval: "forged-bob", Generation: 2},
+	}
+	alice := exceptionApproval("ex-quorum-alice", "ex-quorum", "alice", 2)
+	bob := exceptionApproval("ex-quorum-bob", "ex-quorum", "bob", 1)
+
+	old := makeDeployment(map[string]string{"app": "x"}, "img:v1", 1)
+	newDep := makeDeployment(map[string]string{"app": "x"}, "img:v2", 1)
+
+	v := buildValidator(t, prodNamespace(), cf, ex, alice, bob)
+	resp := v.Handle(context.Background(), makeUpdateRequest(t, old, newDep, []string{"system:authenticated"}))
+	g.Expect(resp.Allowed).To(BeFalse(), "one current approval out of two must not override the freeze")
+
+	bob2 := exceptionApproval("ex-quorum-bob-2", "ex-quorum", "bob", 2)
+	v = buildValidator(t, prodNamespace(), cf, ex, alice, bob, bob2)
+	resp = v.Handle(context.Background(), makeUpdateRequest(t, old, newDep, []string{"system:authenticated"}))
+	g.Expect(resp.Allowed).To(BeTrue(), "exception at quorum must override: %s", resp.Result.Message)
+
+	v.MinApprovals = 3
+	resp = v.Handle(context.Background(), makeUpdateRequest(t, old, newDep, []string{"system:authenticated"}))
+	g.Expect(resp.Allowed).To(BeFalse(), "the cluster minimum must apply over a lower spec.requiredApprovals")
+}
+
+// 29. Each admitted request is recorded in the exception status; once maxUses is
+// spent the exception no longer matches.
+func TestValidator_FreezeException_MaxUses(t *testing.T) {
+	g := NewWithT(t)
+	cf := activeChangeFreeze("cf-once", []freezev1alpha1.Action{freezev1alpha1.ActionRollout})
+	ex := activeFreezeException("ex-once", "prod", []freezev1alpha1.Action{freezev1alpha1.ActionRollout})
+	ex.Spec.MaxUses = 1
+	v := buildValidator(t, prodNamespace(), cf, ex)
+
+	old := makeDeployment(map[string]string{"app": "x"}, "img:v1", 1)
+	newDep := makeDeployment(map[string]string{"app": "x"}, "img:v2", 1)
+
+	dryRun := makeUpdateRequest(t, old, newDep, []string{"system:authenticated"})
+	dryRun.DryRun = ptrBool(true)
+	resp := v.Handle(context.Background(), dryRun)
+	g.Expect(resp.Allowed).To(BeTrue(), "dry-run must be admitted: %s", resp.Result.Message)
+
+	resp = v.Handle(context.Background(), makeUpdateRequest(t, old, newDep, []string{"system:authenticated"}))
+	g.Expect(resp.Allowed).To(BeTrue(), "first use must be admitted: %s", resp.Result.Message)
+
+	got := &freezev1alpha1.FreezeException{}
+	g.Expect(v.Client.Get(context.Background(), types.NamespacedName{Name: "ex-once"}, got)).To(Succeed())
+	g.Expect(got.Status.Usage.Count).To(Equal(int32(1)), "dry-run must not spend a use")
+	g.Expect(got.Status.Usage.LastUser).To(Equal("user@example.com"))
+	g.Expect(got.Status.Usage.LastObject).To(Equal("Deployment prod/my-dep"))
+	g.Expect(got.Status.Usage.LastUsedTime).NotTo(BeNil())
+
+	resp = v.Handle(context.Background(), makeUpdateRequest(t, old, newDep, []string{"system:authenticated"}))
+	g.Expect(resp.Allowed).To(BeFalse(), "exhausted exception must not override the freeze")
+	g.Expect(resp.Result.Message).To(ContainSubstring("cf-once"))
+}
+
+// 30. An exception spent between evaluation and recording denies the request.
+func TestValidator_FreezeException_ExhaustedConcurrently_Denied(t *testing.T) {
+	g := NewWithT(t)
+	ex := activeFreezeException("ex-race", "prod", []freezev1alpha1.Action{freezev1alpha1.ActionRollout})
+	ex.Spec.MaxUses = 1
+	v := buildValidator(t, ex)
+
+	// Another replica spends the last use.
+	_, err := policy.RecordExceptionUse(context.Background(), v.Client, v.Reader, "ex-race", "bob", "Deployment prod/other", time.Now())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	limited, err := policy.RecordExceptionUse(context.Background(), v.Client, v.Reader, "ex-race", "alice", "Deployment prod/my-dep", time.Now())
+	g.Expect(limited).To(BeTrue())
+	g.Expect(err).To(MatchError(policy.ErrExceptionExhausted))
+}
+
+// 31. An exception restricted to allowedImages only covers rollouts of matching images.
+func TestValidator_FreezeException_AllowedImages(t *testing.T) {
+	g := NewWithT(t)
+	cf := activeChangeFreeze("cf-images", []freezev1alpha1.Action{freezev1alpha1.ActionRollout})
+	ex := activeFreezeException("ex-hotfix", "prod", []freezev1alpha1.Action{freezev1alpha1.ActionRollout})
+	ex.Spec.Constraints = &freezev1alpha1.FreezeExceptionConstraintsSpec{AllowedImages: []string{"registry.example.com/api:*"}}
+	v := buildValidator(t, prodNamespace(), cf, ex)
+
+	old := makeDeployment(map[string]string{"app": "x"}, "registry.example.com/api:v1", 1)
+
+	hotfix := makeDeployment(map[string]string{"app": "x"}, "registry.example.com/api:v1.0.1", 1)
+	resp := v.Handle(context.Background(), makeUpdateRequest(t, old, hotfix, []string{"system:authenticated"}))
+	g.Expect(resp.Allowed).To(BeTrue(), "matching image must be covered by the exception: %s", resp.Result.Message)
+
+	feature := makeDeployment(map[string]string{"app": "x"}, "registry.example.com/feature:v1", 1)
+	resp = v.Handle(context.Background(), makeUpdateRequest(t, old, feature, []string{"system:authenticated"}))
+	g.Expect(resp.Allowed).To(BeFalse(), "unrelated image must not be covered by the exception")
+
+	sidecar := hotfix.DeepCopy()
+	sidecar.Spec.Template.Spec.Containers = append(sidecar.Spec.Template.Spec.Containers,
+		corev1.Container{Name: "extra", Image: "docker.io/extra:latest"})
+	resp = v.Handle(context.Background(), makeUpdateRequest(t, old, sidecar, []string{"system:authenticated"}))
+	g.Expect(resp.Allowed).To(BeFalse(), "every changed container image must match")
+}
+
+// 32. An exception listing objects by name covers only those objects.
+func TestValidator_FreezeException_Objects(t *testing.T) {
+	g := NewWithT(t)
+	cf := activeChangeFreeze("cf-objects", []freezev1alpha1.Action{freezev1alpha1.ActionRollout})
+	ex := activeFreezeException("ex-payments-api", "prod", []freezev1alpha1.Action{freezev1alpha1.ActionRollout})
+	ex.Spec.Target = nil
+	ex.Spec.Objects = []freezev1alpha1.ExceptionObjectReference{
+		{Kind: freezev1alpha1.TargetKindDeployment, Namespace: "prod", Name: "payments-api"},
+	}
+	v := buildValidator(t, prodNamespace(), cf, ex)
+
+	lbls := map[string]string{"app.kubernetes.io/part-of": "payments"}
+	old := makeDeployment(lbls, "img:v1", 1)
+	old.Name = "payments-api"
+	newDep := old.DeepCopy()
+	newDep.Spec.Template.Spec.Containers[0].Image = "img:v2"
+	resp := v.Handle(context.Background(), makeUpdateRequest(t, old, newDep, []string{"system:authenticated"}))
+	g.Expect(resp.Allowed).To(BeTrue(), "listed object must be covered: %s", resp.Result.Message)
+
+	old.Name, newDep.Name = "payments-worker", "payments-worker"
+	resp = v.Handle(context.Background(), makeUpdateRequest(t, old, newDep, []string{"system:authenticated"}))
+	g.Expect(resp.Allowed).To(BeFalse(), "sibling workload must not be covered")
+}
+
+// 33. The break-glass annotation overrides a freeze only for break-glass group members,
+// and every override is recorded as a Warning Event.
+func TestValidator_BreakGlass(t *testing.T) {
+	g := NewWithT(t)
+	cf := activeChangeFreeze("cf-incident", []freezev1alpha1.Action{freezev1alpha1.ActionRollout})
+	v := buildValidator(t, prodNamespace(), cf)
+	recorder := record.NewFakeRecorder(10)
+	v.BreakGlassGroups = []string{"sre-oncall"}
+	v.Recorder = recorder
+
+	old := makeDeployment(map[string]string{"app": "x"}, "img:v1", 1)
+	newDep := makeDeployment(map[string]string{"app": "x"}, "img:v2", 1)
+	newDep.Annotations = map[string]string{freezev1alpha1.AnnotationBreakGlass: "INC-42 payments down"}
+
+	resp := v.Handle(context.Background(), makeUpdateRequest(t, old, newDep, []string{"developers"}))
+	g.Expect(resp.Allowed).To(BeFalse(), "break-glass must be limited to the configured groups")
+	g.Expect(recorder.Events).To(BeEmpty())
+
+	resp = v.Handle(context.Background(), makeUpdateRequest(t, old, newDep, []string{"sre-oncall"}))
+	g.Expect(resp.Allowed).To(BeTrue(), "break-glass must override the freeze: %s", resp.Result.Message)
+	g.Expect(resp.AuditAnnotations).To(HaveKeyWithValue("break-glass-reason", "INC-42 payments down"))
+	g.Expect(recorder.Events).To(Receive(And(ContainSubstring("Warning BreakGlass"), ContainSubstring("ChangeFreeze/cf-incident"))))
+
+	dryRun := makeUpdateRequest(t, old, newDep, []string{"sre-oncall"})
+	dryRun.DryRun = ptrBool(true)
+	resp = v.Handle(context.Background(), dryRun)
+	g.Expect(resp.Allowed).To(BeTrue())
+	g.Expect(recorder.Events).To(BeEmpty(), "dry-run must not emit events")
+}
+
+// 34. Inside a ChangeFreeze notice period requests are allowed with an admission warning.
+func TestValidator_NoticePeriodWarning(t *testing.T) {
+	g := NewWithT(t)
+	cf := activeChangeFreeze("holiday-2026", []freezev1alpha1.Action{freezev1alpha1.ActionRollout})
+	cf.Spec.StartTime = metav1.Time{Time: time.Now().UTC().Add(3 * time.Hour)}
+	cf.Spec.EndTime = metav1.Time{Time: time.Now().UTC().Add(24 * time.Hour)}
+	cf.Spec.NoticePeriod = &metav1.Duration{Duration: 6 * time.Hour}
+	v := buildValidator(t, prodNamespace(), cf)
+
+	old := makeDeployment(map[string]string{"app": "x"}, "img:v1", 1)
+	newDep := makeDeployment(map[string]string{"app": "x"}, "img:v2", 1)
+	resp := v.Handle(context.Background(), makeUpdateRequest(t, old, newDep, nil))
+	g.Expect(resp.Allowed).To(BeTrue())
+	g.Expect(resp.Warnings).To(ConsistOf(HavePrefix("ChangeFreeze holiday-2026 starts in ")))
+}
+
+// 35. A rate-limited ChangeFreeze admits changes until its budget is spent, then
+// denies with the time the budget frees up; other actions are not limited.
+func TestValidator_RateLimit(t *testing.T) {
+	g := NewWithT(t)
+	cf := activeChangeFreeze("slow-down", nil)
+	cf.Spec.Rules.RateLimit = &freezev1alpha1.RateLimitSpec{
+		Actions: []freezev1alpha1.Action{freezev1alpha1.ActionRollout},
+		Limit:   1,
+		Period:  metav1.Duration{Duration: time.Hour},
+	}
+	v := buildValidator(t, prodNamespace(), cf)
+
+	old := makeDeployment(map[string]string{"app": "x"}, "img:v1", 1)
+	newDep := makeDeployment(map[string]string{"app": "x"}, "img:v2", 1)
+
+	resp := v.Handle(context.Background(), makeUpdateRequest(t, old, newDep, nil))
+	g.Expect(resp.Allowed).To(BeFalse(), "rate limits must not be skipped when budgets are not configured")
+
+	v.Budgets = &budget.Counter{Client: v.Client, Reader: v.Reader, Namespace: "kube-system"}
+	resp = v.Handle(context.Background(), makeUpdateRequest(t, old, newDep, nil))
+	g.Expect(resp.Allowed).To(BeTrue(), resp.Result.Message)
+
+	dryRun := makeUpdateRequest(t, old, newDep, nil)
+	dryRun.DryRun = ptrBool(true)
+	resp = v.Handle(context.Background(), dryRun)
+	g.Expect(resp.Allowed).To(BeFalse(), "dry-run must report an exhausted budget")
+
+	resp = v.Handle(context.Background(), makeUpdateRequest(t, old, newDep, nil))
+	g.Expect(resp.Allowed).To(BeFalse())
+	g.Expect(resp.Result.Message).To(ContainSubstring("change budget of ChangeFreeze slow-down is exhausted (1 per 1h0m0s cluster-wide)"))
+	g.Expect(resp.Result.Message).To(ContainSubstring("Budget frees up at"))
+
+	scaled := makeDeployment(map[string]string{"app": "x"}, "img:v1", 3)
+	resp = v.Handle(context.Background(), makeUpdateRequest(t, old, scaled, nil))
+	g.Expect(resp.Allowed).To(BeTrue(), "actions outside rateLimit.actions are not limited")
+}
+
+// 36. A RolloutLimit denies a ROLL_OUT while the limit of concurrent rollouts is
+// reached, listing the workloads that are rolling out.
+func TestValidator_RolloutLimit(t *testing.T) {
+	g := NewWithT(t)
+	rl := &freezev1alpha1.RolloutLimit{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-rollouts"},
+		Spec: freezev1alpha1.RolloutLimitSpec{
+			Target: freezev1alpha1.TargetSpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				Kinds:             []freezev1alpha1.TargetKind{freezev1alpha1.TargetKindDeployment},
+			},
+			MaxConcurrent: 1,
+		},
+	}
+	rolling := makeDeployment(map[string]string{"app": "other"}, "img:v2", 2)
+	rolling.Name = "other"
+	rolling.Status = appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 1, AvailableReplicas: 2}
+	v := buildValidator(t, prodNamespace(), rl, rolling)
+
+	old := makeDeployment(map[string]string{"app": "x"}, "img:v1", 1)
+	newDep := makeDeployment(map[string]string{"app": "x"}, "img:v2", 1)
+	resp := v.Handle(context.Background(), makeUpdateRequest(t, old, newDep, nil))
+	g.Expect(resp.Allowed).To(BeFalse())
+	g.Expect(resp.Result.Message).To(ContainSubstring("Denied by RolloutLimit/prod-rollouts"))
+	g.Expect(resp.Result.Message).To(ContainSubstring("(1/1): Deployment prod/other"))
+}
+
+// 37. A policy with behavior.deferChanges records a denied UPDATE as a DeferredChange
+// holding the submitted object, the requester and the blocking policy.
+func TestValidator_DeferChanges(t *testing.T) {
+	g := NewWithT(t)
+	cf := activeChangeFreeze("cf-defer", []freezev1alpha1.Action{freezev1alpha1.ActionRollout})
+	cf.Spec.Behavior.DeferChanges = true
+	v := buildValidator(t, prodNamespace(), cf)
+
+	old := makeDeployment(map[string]string{"app": "x"}, "img:v1", 1)
+	old.Generation = 4
+	newDep := makeDeployment(map[string]string{"app": "x"}, "img:v2", 1)
+	newDep.Generation = 4
+	newDep.ResourceVersion = "123"
+
+	dryRun := makeUpdateRequest(t, old, newDep, []string{"developers"})
+	dryRun.DryRun = ptrBool(true)
+	resp := v.Handle(context.Background(), dryRun)
+	g.Expect(resp.Allowed).To(BeFalse())
+
+	var list freezev1alpha1.DeferredChangeList
+	g.Expect(v.Client.List(context.Background(), &list)).To(Succeed())
+	g.Expect(list.Items).To(BeEmpty(), "dry-run requests must not be deferred")
+
+	resp = v.Handle(context.Background(), makeUpdateRequest(t, old, newDep, []string{"developers"}))
+	g.Expect(resp.Allowed).To(BeFalse())
+	g.Expect(resp.Result.Message).To(ContainSubstring("recorded as DeferredChange prod/my-dep-"))
+
+	g.Expect(v.Client.List(context.Background(), &list)).To(Succeed())
+	g.Expect(list.Items).To(HaveLen(1))
+	dc := list.Items[0]
+	g.Expect(dc.Namespace).To(Equal("prod"))
+	g.Expect(dc.Spec.Name).To(Equal("my-dep"))
+	g.Expect(dc.Spec.Operation).To(Equal(freezev1alpha1.DeferredOperationUpdate))
+	g.Expect(dc.Spec.Action).To(Equal(freezev1alpha1.ActionRollout))
+	g.Expect(dc.Spec.BaseGeneration).To(Equal(int64(4)))
+	g.Expect(dc.Spec.Requester.Groups).To(Equal([]string{"developers"}))
+	g.Expect(dc.Spec.BlockingPolicy).To(Equal(freezev1alpha1.DeferredChangePolicyRef{Kind: "ChangeFreeze", Name: "cf-defer"}))
+
+	var stored appsv1.Deployment
+	g.Expect(json.Unmarshal(dc.Spec.Object.Raw, &stored)).To(Succeed())
+	g.Expect(stored.Spec.Template.Spec.Containers[0].Image).To(Equal("img:v2"))
+	g.Expect(stored.ResourceVersion).To(BeEmpty())
+	g.Expect(stored.Generation).To(BeZero())
+
+	var base appsv1.Deployment
+	g.Expect(dc.Spec.BaseObject).NotTo(BeNil())
+	g.Expect(json.Unmarshal(dc.Spec.BaseObject.Raw, &base)).To(Succeed())
+	g.Expect(base.Spec.Template.Spec.Containers[0].Image).To(Equal("img:v1"))
+	g.Expect(base.Generation).To(BeZero())
+}
+
+// 38. A policy with behavior.pauseDeployments admits a rollout that keeps the
+// Deployment paused, and still denies one that unpauses it.
+func TestValidator_PauseDeployments(t *testing.T) {
+	g := NewWithT(t)
+	cf := activeChangeFreeze("cf-pause", []freezev1alpha1.Action{freezev1alpha1.ActionRollout})
+	cf.Spec.Behavior.PauseDeployments = true
+	v := buildValidator(t, prodNamespace(), cf)
+
+	old := makeDeployment(map[string]string{"app": "x"}, "img:v1", 1)
+	old.Spec.Paused = true
+	newDep := makeDeployment(map[string]string{"app": "x"}, "img:v2", 1)
+	newDep.Spec.Paused = true
+
+	resp := v.Handle(context.Background(), makeUpdateRequest(t, old, newDep, []string{"developers"}))
+	g.Expect(resp.Allowed).To(BeTrue(), resp.Result.Message)
+	g.Expect(resp.Warnings).To(ConsistOf("Deployment prod/my-dep is paused by ChangeFreeze/cf-pause; the rollout starts when the freeze ends"))
+
+	newDep.Spec.Paused = false
+	resp = v.Handle(context.Background(), makeUpdateRequest(t, old, newDep, []string{"developers"}))
+	g.Expect(resp.Allowed).To(BeFalse(), "unpausing the Deployment must still be denied")
+
+	// Another policy that denies without pausing keeps the rollout denied.
+	v = buildValidator(t, prodNamespace(), cf, activeChangeFreeze("cf-deny", []freezev1alpha1.Action{freezev1alpha1.ActionRollout}))
+	newDep.Spec.Paused = true
+	resp = v.Handle(context.Background(), makeUpdateRequest(t, old, newDep, []string{"developers"}))
+	g.Expect(resp.Allowed).To(BeFalse())
+}
+
+// 39. Denials and exception uses are recorded for the FreezeReport of the policy
+// they concern, once the controller has begun its occurrence.
+func TestValidator_RecordsFreezeSummary(t *testing.T) {
+	g := NewWithT(t)
+	cf := activeChangeFreeze("cf-report", []freezev1alpha1.Action{freezev1alpha1.ActionCreate, freezev1alpha1.ActionRollout})
+	ex := activeFreezeException("ex-hotfix", "prod", []freezev1alpha1.Action{freezev1alpha1.ActionRollout})
+	v := buildValidator(t, prodNamespace(), cf, ex)
+	v.Reports = &report.Aggregator{}
+	v.Reports.Begin(freezev1alpha1.PolicyReference{Kind: "ChangeFreeze", Name: "cf-report"}, cf.Spec.StartTime.Time)
+
+	dep := makeDeployment(map[string]string{"app": "x"}, "img:v1", 1)
+	resp := v.Handle(context.Background(), makeCreateRequest(t, dep, "alice", []string{"system:authenticated"}))
+	g.Expect(resp.Allowed).To(BeFalse())
+
+	newDep := makeDeployment(map[string]string{"app": "x"}, "img:v2", 1)
+	resp = v.Handle(context.Background(), makeUpdateRequest(t, dep, newDep, []string{"system:authenticated"}))
+	g.Expect(resp.Allowed).To(BeTrue(), resp.Result.Message)
+
+	summary, ok := v.Reports.Summary(freezev1alpha1.PolicyReference{Kind: "ChangeFreeze", Name: "cf-report"})
+	g.Expect(ok).To(BeTrue())
+	g.Expect(summary.Denied).To(Equal(1))
+	g.Expect(summary.DeniedByUser).To(ConsistOf(freezev1alpha1.RequestCount{Name: "alice", Count: 1}))
+	g.Expect(summary.DeniedByNamespace).To(ConsistOf(freezev1alpha1.RequestCount{Name: "prod", Count: 1}))
+	g.Expect(summary.DeniedByAction).To(ConsistOf(freezev1alpha1.RequestCount{Name: "CREATE", Count: 1}))
+	g.Expect(summary.Exceptions).To(ConsistOf(freezev1alpha1.ExceptionUse{Exception: "ex-hotfix", User: "user@example.com", Count: 1}))
+}
+
+// 40. A denied CREATE that leaves the name to generateName is deferred under the
+// object's generateName.
+func TestValidator_DeferChanges_GenerateName(t *testing.T) {
+	g := NewWithT(t)
+	cf := activeChangeFreeze("cf-defer", []freezev1alpha1.Action{freezev1alpha1.ActionCreate})
+	cf.Spec.Behavior.DeferChanges = true
+	v := buildValidator(t, prodNamespace(), cf)
+
+	dep := makeDeployment(map[string]string{"app": "x"}, "img:v1", 1)
+	dep.Name = ""
+	dep.GenerateName = "batch-"
+	resp := v.Handle(context.Background(), makeCreateRequest(t, dep, "alice", []string{"developers"}))
+	g.Expect(resp.Allowed).To(BeFalse())
+
+	var list freezev1alpha1.DeferredChangeList
+	g.Expect(v.Client.List(context.Background(), &list)).To(Succeed())
+	g.Expect(list.Items).To(HaveLen(1))
+	dc := list.Items[0]
+	g.Expect(dc.Name).To(HavePrefix("batch-"))
+	g.Expect(dc.Spec.Name).To(BeEmpty())
+	g.Expect(dc.Spec.BaseObject).To(BeNil())
+}
+
+// 41. When an exception is spent between evaluation and recording, the request is
+// evaluated once more without it and may be admitted by another exception.
+func TestValidator_FreezeException_ExhaustedConcurrently_FallsBack(t *testing.T) {
+	g := NewWithT(t)
+	cf := activeChangeFreeze("cf-race", []freezev1alpha1.Action{freezev1alpha1.ActionRollout})
+	spent := activeFreezeException("ex-a-once", "prod", []freezev1alpha1.Action{freezev1alpha1.ActionRollout})
+	spent.Spec.MaxUses = 1
+	backup := activeFreezeException("ex-b-backup", "prod", []freezev1alpha1.Action{freezev1alpha1.ActionRollout})
+
+	old := makeDeployment(map[string]string{"app": "x"}, "img:v1", 1)
+	newDep := makeDeployment(map[string]string{"app": "x"}, "img:v2", 1)
+
+	// Another replica spends the last use of ex-a-once after this one evaluated it.
+	raceReader := func(v *Validator) client.Reader {
+		return interceptor.NewClient(v.Client.(client.WithWatch), interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if err := c.Get(ctx, key, obj, opts...); err != nil {
+					return err
+				}
+				if ex, ok := obj.(*freezev1alpha1.FreezeException); ok && ex.Name == "ex-a-once" {
+					ex.Status.Usage.Count = ex.Spec.MaxUses
+				}
+				return nil
+			},
+		})
+	}
+
+	v := buildValidator(t, prodNamespace(), cf, spent, backup)
+	v.Reader = raceReader(v)
+	resp := v.Handle(context.Background(), makeUpdateRequest(t, old, newDep, []string{"system:authenticated"}))
+	g.Expect(resp.Allowed).To(BeTrue(), "another exception must admit the request: %s", resp.Result.Message)
+
+	got := &freezev1alpha1.FreezeException{}
+	g.Expect(v.Client.Get(context.Background(), types.NamespacedName{Name: "ex-b-backup"}, got)).To(Succeed())
+	g.Expect(got.Status.Usage.Count).To(Equal(int32(1)), "the use must be recorded on the exception that admitted the request")
+
+	// Without another exception the freeze denies the request.
+	v = buildValidator(t, prodNamespace(), cf, spent)
+	v.Reader = raceReader(v)
+	resp = v.Handle(context.Background(), makeUpdateRequest(t, old, newDep, []string{"system:authenticated"}))
+	g.Expect(resp.Allowed).To(BeFalse())
+	g.Expect(resp.Result.Message).To(ContainSubstring("cf-race"))
+}